@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/app"
+	"playcount-monitor-backend/internal/config"
+)
+
+func main() {
+	once := flag.Bool("once", false, "write one backup archive and exit instead of backing up on schedule")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(".env")
+	if err != nil {
+		log.Fatalf("failed to load config, %v", err)
+	}
+
+	lg := log.New()
+
+	ctx := context.Background()
+
+	if !*once {
+		if err := app.RunBackupWorker(ctx, cfg, lg); err != nil {
+			log.Fatalf("failed to start backup worker app, %v", err)
+		}
+		return
+	}
+
+	path, err := app.RunBackup(ctx, cfg, lg)
+	if err != nil {
+		log.Fatalf("failed to run backup, %v", err)
+	}
+
+	lg.Infof("backed up to %s", path)
+}
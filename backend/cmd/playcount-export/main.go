@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"playcount-monitor-backend/internal/app"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	format := flag.String("format", string(exportprovide.FormatCSV), "csv, ndjson or parquet")
+	entities := flag.String("entity", "", "comma separated entities out of user, mapset and beatmap, all of them by default")
+	from := flag.String("from", "", "RFC 3339 timestamp, start of history by default")
+	to := flag.String("to", "", "RFC 3339 timestamp, end of history by default")
+	out := flag.String("out", "", "output file, stdout by default")
+	flag.Parse()
+
+	cmd := &exportprovide.Command{Format: exportprovide.Format(*format)}
+	for _, entity := range strings.Split(*entities, ",") {
+		if entity = strings.TrimSpace(entity); entity != "" {
+			cmd.Entities = append(cmd.Entities, model.StatEntity(entity))
+		}
+	}
+	var err error
+	if *from != "" {
+		if cmd.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			log.Fatalf("invalid from, %v", err)
+		}
+	}
+	if *to != "" {
+		if cmd.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			log.Fatalf("invalid to, %v", err)
+		}
+	}
+
+	cfg, err := config.LoadConfig(".env")
+	if err != nil {
+		log.Fatalf("failed to load config, %v", err)
+	}
+
+	// logs go to stderr so that stdout carries export only
+	lg := log.New()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if *out == "" {
+		if err := app.RunExport(ctx, cfg, lg, cmd, os.Stdout); err != nil {
+			log.Fatalf("failed to run export, %v", err)
+		}
+		return
+	}
+
+	if err := exportToFile(ctx, cfg, lg, cmd, *out); err != nil {
+		log.Fatalf("failed to run export, %v", err)
+	}
+}
+
+// exportToFile removes the file when export fails so that a truncated export isn't mistaken for a whole one
+func exportToFile(ctx context.Context, cfg *config.Config, lg *log.Logger, cmd *exportprovide.Command, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = app.RunExport(ctx, cfg, lg, cmd, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/app"
+	"playcount-monitor-backend/internal/config"
+)
+
+func main() {
+	archive := flag.String("archive", "", "path of backup archive to restore")
+	flag.Parse()
+
+	if *archive == "" {
+		log.Fatalf("archive is required")
+	}
+
+	cfg, err := config.LoadConfig(".env")
+	if err != nil {
+		log.Fatalf("failed to load config, %v", err)
+	}
+
+	lg := log.New()
+
+	manifest, err := app.RunRestore(context.Background(), cfg, lg, *archive)
+	if err != nil {
+		log.Fatalf("failed to run restore, %v", err)
+	}
+
+	for _, table := range manifest.Tables {
+		lg.Infof("restored %d rows of %s", table.Rows, table.Name)
+	}
+	lg.Infof("restored backup of %s at schema version %s", manifest.CreatedAt.Format("2006-01-02 15:04:05"), manifest.SchemaVersion)
+}
@@ -0,0 +1,28 @@
+package accountserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+)
+
+type accountManager interface {
+	Get(ctx context.Context, session *dto.Session) (*dto.Account, error)
+	Follow(ctx context.Context, session *dto.Session) (*dto.Account, error)
+	Unfollow(ctx context.Context, session *dto.Session) (*dto.Account, error)
+}
+
+type ServiceImpl struct {
+	lg             *log.Logger
+	accountManager accountManager
+}
+
+func New(
+	lg *log.Logger,
+	accountManager accountManager,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:             lg,
+		accountManager: accountManager,
+	}
+}
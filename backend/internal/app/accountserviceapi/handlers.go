@@ -0,0 +1,36 @@
+package accountserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/principal"
+)
+
+// routes are behind session middleware, so session is always there
+
+func (s *ServiceImpl) Get(c echo.Context) error {
+	account, err := s.accountManager.Get(c.Request().Context(), principal.Session(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+func (s *ServiceImpl) Follow(c echo.Context) error {
+	account, err := s.accountManager.Follow(c.Request().Context(), principal.Session(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+func (s *ServiceImpl) Unfollow(c echo.Context) error {
+	account, err := s.accountManager.Unfollow(c.Request().Context(), principal.Session(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
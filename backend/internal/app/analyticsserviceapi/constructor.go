@@ -0,0 +1,28 @@
+package analyticsserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	analyticsprovide "playcount-monitor-backend/internal/usecase/analytics/provide"
+)
+
+type analyticsProvider interface {
+	GetForUser(ctx context.Context, id int, cmd *analyticsprovide.Command) (*analyticsprovide.Analytics, error)
+	GetForMapset(ctx context.Context, id int, cmd *analyticsprovide.Command) (*analyticsprovide.Analytics, error)
+	GetForBeatmap(ctx context.Context, id int, cmd *analyticsprovide.Command) (*analyticsprovide.Analytics, error)
+}
+
+type ServiceImpl struct {
+	lg                *log.Logger
+	analyticsProvider analyticsProvider
+}
+
+func New(
+	lg *log.Logger,
+	analyticsProvider analyticsProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:                lg,
+		analyticsProvider: analyticsProvider,
+	}
+}
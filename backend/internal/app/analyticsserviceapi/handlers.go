@@ -0,0 +1,42 @@
+package analyticsserviceapi
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	analyticsprovide "playcount-monitor-backend/internal/usecase/analytics/provide"
+	"strconv"
+)
+
+type getter func(ctx context.Context, id int, cmd *analyticsprovide.Command) (*analyticsprovide.Analytics, error)
+
+func (s *ServiceImpl) GetForUser(c echo.Context) error {
+	return s.get(c, s.analyticsProvider.GetForUser)
+}
+
+func (s *ServiceImpl) GetForMapset(c echo.Context) error {
+	return s.get(c, s.analyticsProvider.GetForMapset)
+}
+
+func (s *ServiceImpl) GetForBeatmap(c echo.Context) error {
+	return s.get(c, s.analyticsProvider.GetForBeatmap)
+}
+
+func (s *ServiceImpl) get(c echo.Context, get getter) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	cmd, err := mapQueryParamsToCommand(c.QueryParam("from"), c.QueryParam("to"), c.QueryParam("granularity"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	analytics, err := get(c.Request().Context(), id, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, analytics)
+}
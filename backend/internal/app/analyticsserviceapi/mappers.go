@@ -0,0 +1,63 @@
+package analyticsserviceapi
+
+import (
+	"errors"
+	"fmt"
+	analyticsprovide "playcount-monitor-backend/internal/usecase/analytics/provide"
+	"playcount-monitor-backend/internal/usecase/timeseries"
+	"time"
+)
+
+const defaultWindow = 30 * 24 * time.Hour
+
+const dateLayout = "2006-01-02"
+
+// mapQueryParamsToCommand defaults to the last 30 days with daily granularity
+func mapQueryParamsToCommand(fromParam, toParam, granularityParam string) (*analyticsprovide.Command, error) {
+	to := time.Now().UTC()
+	if toParam != "" {
+		var err error
+		if to, err = parseTime(toParam); err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		// date without time covers the whole day
+		if len(toParam) == len(dateLayout) {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+	}
+
+	from := to.Add(-defaultWindow)
+	if fromParam != "" {
+		var err error
+		if from, err = parseTime(fromParam); err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+
+	if from.After(to) {
+		return nil, errors.New("from is after to")
+	}
+
+	granularity := timeseries.Day
+	if granularityParam != "" {
+		granularity = timeseries.Granularity(granularityParam)
+		if !granularity.IsValid() {
+			return nil, fmt.Errorf("invalid granularity %q, expected day, week or month", granularityParam)
+		}
+	}
+
+	return &analyticsprovide.Command{
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+	}, nil
+}
+
+// parseTime accepts both RFC3339 timestamps and plain dates
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+
+	return time.Parse(dateLayout, s)
+}
@@ -0,0 +1,26 @@
+package anomalyserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	anomalyprovide "playcount-monitor-backend/internal/usecase/anomaly/provide"
+)
+
+type anomalyProvider interface {
+	List(ctx context.Context, cmd *anomalyprovide.ListCommand) (*anomalyprovide.ListResponse, error)
+}
+
+type ServiceImpl struct {
+	lg              *log.Logger
+	anomalyProvider anomalyProvider
+}
+
+func New(
+	lg *log.Logger,
+	anomalyProvider anomalyProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:              lg,
+		anomalyProvider: anomalyProvider,
+	}
+}
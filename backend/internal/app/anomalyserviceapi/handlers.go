@@ -0,0 +1,59 @@
+package anomalyserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/http/pagequery"
+	anomalyprovide "playcount-monitor-backend/internal/usecase/anomaly/provide"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	filter := make(model.AnomalyFilter)
+	if userID := c.QueryParam("user_id"); userID != "" {
+		userIDInt, err := strconv.Atoi(userID)
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+		filter[model.AnomalyUserIDField] = userIDInt
+	}
+
+	switch entityType := model.AnomalyEntityType(c.QueryParam("type")); entityType {
+	case "":
+	case model.AnomalyMapset, model.AnomalyBeatmap:
+		filter[model.AnomalyEntityTypeField] = string(entityType)
+	default:
+		return echo.ErrBadRequest
+	}
+
+	switch severity := model.AnomalySeverity(c.QueryParam("severity")); severity {
+	case "":
+	case model.AnomalyLow, model.AnomalyMedium, model.AnomalyHigh:
+		filter[model.AnomalySeverityField] = string(severity)
+	default:
+		return echo.ErrBadRequest
+	}
+
+	if kind := c.QueryParam("kind"); kind != "" {
+		filter[model.AnomalyKindField] = kind
+	}
+
+	listResp, err := s.anomalyProvider.List(c.Request().Context(), &anomalyprovide.ListCommand{
+		Query:  pageQuery,
+		Filter: filter,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, AnomalyListResponse{
+		Anomalies: listResp.Anomalies,
+		Page:      listResp.Page,
+	})
+}
@@ -0,0 +1,8 @@
+package anomalyserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type AnomalyListResponse struct {
+	Anomalies []*dto.Anomaly `json:"anomalies"`
+	*dto.Page
+}
@@ -0,0 +1,35 @@
+package apikeyserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+	apikeymanage "playcount-monitor-backend/internal/usecase/apikey/manage"
+)
+
+type apiKeyManager interface {
+	Create(ctx context.Context, cmd *apikeymanage.Command) (*dto.APIKey, error)
+	Revoke(ctx context.Context, id int) error
+}
+
+type apiKeyProvider interface {
+	List(ctx context.Context) ([]*dto.APIKey, error)
+}
+
+type ServiceImpl struct {
+	lg             *log.Logger
+	apiKeyManager  apiKeyManager
+	apiKeyProvider apiKeyProvider
+}
+
+func New(
+	lg *log.Logger,
+	apiKeyManager apiKeyManager,
+	apiKeyProvider apiKeyProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:             lg,
+		apiKeyManager:  apiKeyManager,
+		apiKeyProvider: apiKeyProvider,
+	}
+}
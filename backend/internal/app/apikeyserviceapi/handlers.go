@@ -0,0 +1,47 @@
+package apikeyserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	apikeymanage "playcount-monitor-backend/internal/usecase/apikey/manage"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	keys, err := s.apiKeyProvider.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (s *ServiceImpl) Create(c echo.Context) error {
+	req := new(APIKeyRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	key, err := s.apiKeyManager.Create(c.Request().Context(), &apikeymanage.Command{
+		Name: req.Name,
+		Role: req.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+func (s *ServiceImpl) Revoke(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if err := s.apiKeyManager.Revoke(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
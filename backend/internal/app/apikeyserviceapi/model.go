@@ -0,0 +1,6 @@
+package apikeyserviceapi
+
+type APIKeyRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
@@ -0,0 +1,26 @@
+package auditserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	auditprovide "playcount-monitor-backend/internal/usecase/audit/provide"
+)
+
+type auditProvider interface {
+	List(ctx context.Context, cmd *auditprovide.ListCommand) (*auditprovide.ListResponse, error)
+}
+
+type ServiceImpl struct {
+	lg            *log.Logger
+	auditProvider auditProvider
+}
+
+func New(
+	lg *log.Logger,
+	auditProvider auditProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:            lg,
+		auditProvider: auditProvider,
+	}
+}
@@ -0,0 +1,27 @@
+package auditserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/pagequery"
+	auditprovide "playcount-monitor-backend/internal/usecase/audit/provide"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := s.auditProvider.List(c.Request().Context(), &auditprovide.ListCommand{
+		Query: pageQuery,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, AuditListResponse{
+		Entries: listResp.Entries,
+		Page:    listResp.Page,
+	})
+}
@@ -0,0 +1,8 @@
+package auditserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type AuditListResponse struct {
+	Entries []*dto.AuditEntry `json:"entries"`
+	*dto.Page
+}
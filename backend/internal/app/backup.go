@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/app/backupworker"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/backuprepository"
+	"playcount-monitor-backend/internal/usecase/backup"
+	"time"
+)
+
+// RunBackupWorker writes backup archives on schedule until it is stopped
+func RunBackupWorker(
+	ctx context.Context,
+	cfg *config.Config,
+	lg *log.Logger,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	backupUc, err := initBackupUseCase(cfg, lg, "backup-worker")
+	if err != nil {
+		return err
+	}
+
+	w := backupworker.New(cfg, lg, backupUc)
+
+	w.Start(ctx)
+
+	gracefulShutDown(ctx, cancel)
+
+	return nil
+}
+
+// RunBackup writes one backup archive and returns its path
+func RunBackup(
+	ctx context.Context,
+	cfg *config.Config,
+	lg *log.Logger,
+) (string, error) {
+	backupUc, err := initBackupUseCase(cfg, lg, "backup")
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.BackupTimeout)
+	defer cancel()
+
+	path, err := backupUc.Create(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up: %w", err)
+	}
+
+	return path, nil
+}
+
+// RunRestore migrates database and loads archive at path into it, database has to be empty
+func RunRestore(
+	ctx context.Context,
+	cfg *config.Config,
+	lg *log.Logger,
+	path string,
+) (*backup.Manifest, error) {
+	backupUc, err := initBackupUseCase(cfg, lg, "restore")
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := backupUc.Restore(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func initBackupUseCase(cfg *config.Config, lg *log.Logger, ns string) (*backup.UseCase, error) {
+	db, err := bootstrap.InitDB(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init db: %w", err)
+	}
+
+	// restore compares archive with the latest migration, so schema is brought up to date first
+	err = bootstrap.ApplyMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	const waitForConnection = 5 * time.Second
+	txm := bootstrap.ConnectTxManager(ns, waitForConnection, db, lg)
+
+	backupRepo := backuprepository.New(cfg, lg)
+
+	return backup.New(cfg, lg, txm, backupRepo), nil
+}
@@ -0,0 +1,33 @@
+package backupworker
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"time"
+)
+
+type (
+	backuper interface {
+		Create(ctx context.Context) (string, error)
+		LastCreatedAt() (time.Time, error)
+	}
+
+	Worker struct {
+		cfg      *config.Config
+		lg       *log.Logger
+		backuper backuper
+	}
+)
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	backuper backuper,
+) *Worker {
+	return &Worker{
+		cfg:      cfg,
+		lg:       lg,
+		backuper: backuper,
+	}
+}
@@ -0,0 +1,70 @@
+package backupworker
+
+import (
+	"context"
+	"time"
+)
+
+// Start writes a backup every BackupInterval, the first one is due BackupInterval after the newest
+// archive in BackupDir so that restarts don't reset the schedule
+func (w *Worker) Start(ctx context.Context) func() error {
+	finished := make(chan struct{}, 1)
+
+	go func() {
+		wait := w.untilNextBackup()
+		if wait > 0 {
+			w.lg.Infof("waiting %v until next backup", wait)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				finished <- struct{}{}
+				w.lg.Infof("backup worker finished")
+				return
+			case <-time.After(wait):
+			}
+
+			w.backup(ctx)
+			wait = w.cfg.BackupInterval
+		}
+	}()
+
+	return func() error {
+		<-finished
+		return nil
+	}
+}
+
+func (w *Worker) backup(ctx context.Context) {
+	w.lg.Infof("backup started")
+
+	loopCtx, cancel := context.WithTimeout(ctx, w.cfg.BackupTimeout)
+	defer cancel()
+
+	path, err := w.backuper.Create(loopCtx)
+	if err != nil {
+		w.lg.Errorf("encountered error while backing up: %v", err)
+		return
+	}
+
+	w.lg.Infof("backed up to %s", path)
+}
+
+func (w *Worker) untilNextBackup() time.Duration {
+	last, err := w.backuper.LastCreatedAt()
+	if err != nil {
+		w.lg.Errorf("failed to get time of last backup: %v", err)
+		return 0
+	}
+
+	if last.IsZero() {
+		return 0
+	}
+
+	if wait := w.cfg.BackupInterval - time.Since(last); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
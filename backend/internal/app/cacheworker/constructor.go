@@ -0,0 +1,31 @@
+package cacheworker
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+)
+
+type (
+	invalidator interface {
+		Check(ctx context.Context) error
+	}
+
+	Worker struct {
+		cfg         *config.Config
+		lg          *log.Logger
+		invalidator invalidator
+	}
+)
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	invalidator invalidator,
+) *Worker {
+	return &Worker{
+		cfg:         cfg,
+		lg:          lg,
+		invalidator: invalidator,
+	}
+}
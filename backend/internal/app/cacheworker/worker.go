@@ -0,0 +1,33 @@
+package cacheworker
+
+import (
+	"context"
+	"time"
+)
+
+// Start looks for completed tracking runs every CacheCheckInterval, tracking worker runs in its own
+// process so cache of http server can't be purged by it directly
+func (w *Worker) Start(ctx context.Context) func() error {
+	finished := make(chan struct{}, 1)
+
+	go func() {
+		for {
+			if err := w.invalidator.Check(ctx); err != nil {
+				w.lg.Errorf("failed to check for completed tracking runs: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				finished <- struct{}{}
+				w.lg.Infof("cache worker finished")
+				return
+			case <-time.After(w.cfg.CacheCheckInterval):
+			}
+		}
+	}()
+
+	return func() error {
+		<-finished
+		return nil
+	}
+}
@@ -0,0 +1,35 @@
+package digestserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+	digestmanage "playcount-monitor-backend/internal/usecase/digest/manage"
+)
+
+type digestManager interface {
+	Create(ctx context.Context, cmd *digestmanage.Command) (*dto.DigestSubscription, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type digestProvider interface {
+	List(ctx context.Context) ([]*dto.DigestSubscription, error)
+}
+
+type ServiceImpl struct {
+	lg             *log.Logger
+	digestManager  digestManager
+	digestProvider digestProvider
+}
+
+func New(
+	lg *log.Logger,
+	digestManager digestManager,
+	digestProvider digestProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:             lg,
+		digestManager:  digestManager,
+		digestProvider: digestProvider,
+	}
+}
@@ -0,0 +1,48 @@
+package digestserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	digestmanage "playcount-monitor-backend/internal/usecase/digest/manage"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	subscriptions, err := s.digestProvider.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+func (s *ServiceImpl) Create(c echo.Context) error {
+	req := new(SubscriptionRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	subscription, err := s.digestManager.Create(c.Request().Context(), &digestmanage.Command{
+		Email:     req.Email,
+		Frequency: req.Frequency,
+		UserIDs:   req.UserIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+func (s *ServiceImpl) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if err := s.digestManager.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
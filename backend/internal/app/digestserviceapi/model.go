@@ -0,0 +1,7 @@
+package digestserviceapi
+
+type SubscriptionRequest struct {
+	Email     string `json:"email"`
+	Frequency string `json:"frequency"`
+	UserIDs   []int  `json:"user_ids"`
+}
@@ -0,0 +1,31 @@
+package digestworker
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+)
+
+type (
+	sender interface {
+		Send(ctx context.Context) error
+	}
+
+	Worker struct {
+		cfg    *config.Config
+		lg     *log.Logger
+		sender sender
+	}
+)
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	sender sender,
+) *Worker {
+	return &Worker{
+		cfg:    cfg,
+		lg:     lg,
+		sender: sender,
+	}
+}
@@ -0,0 +1,34 @@
+package digestworker
+
+import (
+	"context"
+	"time"
+)
+
+// Start checks digest subscriptions every DigestCheckInterval, each subscription decides itself whether it is due
+func (w *Worker) Start(ctx context.Context) func() error {
+	finished := make(chan struct{}, 1)
+
+	go func() {
+		for {
+			w.lg.Infof("digest worker started")
+
+			if err := w.sender.Send(ctx); err != nil {
+				w.lg.Errorf("encountered error while sending digests: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				finished <- struct{}{}
+				w.lg.Infof("digest worker finished")
+				return
+			case <-time.After(w.cfg.DigestCheckInterval):
+			}
+		}
+	}()
+
+	return func() error {
+		<-finished
+		return nil
+	}
+}
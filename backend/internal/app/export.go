@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/exportrepository"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+	"time"
+)
+
+// RunExport writes stats history to w once and returns, it only reads so migrations aren't applied
+func RunExport(
+	ctx context.Context,
+	cfg *config.Config,
+	lg *log.Logger,
+	cmd *exportprovide.Command,
+	w io.Writer,
+) error {
+	db, err := bootstrap.InitDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init db: %w", err)
+	}
+
+	const waitForConnection = 5 * time.Second
+	txm := bootstrap.ConnectTxManager("export", waitForConnection, db, lg)
+
+	exportRepo := exportrepository.New(cfg, lg)
+	exportUc := exportprovide.New(cfg, lg, txm, exportRepo)
+
+	if err := exportUc.Export(ctx, cmd, w); err != nil {
+		return fmt.Errorf("failed to export stats: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package exportserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"io"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+)
+
+type exportProvider interface {
+	Export(ctx context.Context, cmd *exportprovide.Command, w io.Writer) error
+}
+
+type ServiceImpl struct {
+	lg             *log.Logger
+	exportProvider exportProvider
+}
+
+func New(
+	lg *log.Logger,
+	exportProvider exportProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:             lg,
+		exportProvider: exportProvider,
+	}
+}
@@ -0,0 +1,64 @@
+package exportserviceapi
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const exportFileName = "stats"
+
+// GetStats streams stats history as attachment. Headers are sent with the first written bytes,
+// so a failed export is answered with a problem until then and is cut short after that
+func (s *ServiceImpl) GetStats(c echo.Context) error {
+	cmd, err := mapQueryParamsToCommand(c.QueryParam("format"), c.QueryParam("entity"), c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	w := &attachmentWriter{
+		res:         c.Response(),
+		contentType: cmd.Format.ContentType(),
+		fileName:    exportFileName + "." + cmd.Format.Extension(),
+	}
+
+	if err := s.exportProvider.Export(c.Request().Context(), cmd, w); err != nil {
+		if !c.Response().Committed {
+			return err
+		}
+
+		// status is already sent, client sees truncated body
+		s.lg.WithField("request_id", c.Response().Header().Get(echo.HeaderXRequestID)).
+			Errorf("failed to export stats: %v", err)
+		return nil
+	}
+
+	// export may have written nothing, e.g. ndjson without rows
+	w.writeHeader()
+
+	return nil
+}
+
+// attachmentWriter writes attachment headers and status right before the first body bytes
+type attachmentWriter struct {
+	res         *echo.Response
+	contentType string
+	fileName    string
+}
+
+func (w *attachmentWriter) writeHeader() {
+	if w.res.Committed {
+		return
+	}
+
+	header := w.res.Header()
+	header.Set(echo.HeaderContentType, w.contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.fileName))
+	w.res.WriteHeader(http.StatusOK)
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	w.writeHeader()
+
+	return w.res.Write(p)
+}
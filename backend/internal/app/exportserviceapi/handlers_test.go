@@ -0,0 +1,82 @@
+package exportserviceapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/database/repository/model"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExportProvider struct {
+	cmd  *exportprovide.Command
+	body string
+	err  error
+}
+
+func (p *fakeExportProvider) Export(_ context.Context, cmd *exportprovide.Command, w io.Writer) error {
+	p.cmd = cmd
+	if p.body != "" {
+		if _, err := io.WriteString(w, p.body); err != nil {
+			return err
+		}
+	}
+
+	return p.err
+}
+
+func TestServiceImpl_GetStats(t *testing.T) {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	get := func(p *fakeExportProvider, target string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		err := New(lg, p).GetStats(echo.New().NewContext(req, rec))
+
+		return rec, err
+	}
+
+	p := &fakeExportProvider{body: "entity,id,timestamp,metric,value\n"}
+	rec, err := get(p, "/api/export/stats?format=ndjson&entity=user,%20beatmap&to=2024-01-31")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="stats.jsonl"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, []model.StatEntity{model.StatEntityUser, model.StatEntityBeatmap}, p.cmd.Entities)
+	assert.Equal(t, time.Date(2024, 1, 31, 23, 59, 59, int(time.Second-1), time.UTC), p.cmd.To)
+	assert.True(t, p.cmd.From.IsZero())
+
+	// nothing written yet, error is left to error handler
+	p = &fakeExportProvider{err: errors.New("boom")}
+	_, err = get(p, "/api/export/stats")
+	assert.Error(t, err)
+	assert.Equal(t, exportprovide.FormatCSV, p.cmd.Format)
+
+	// failure after headers are sent truncates body
+	p = &fakeExportProvider{body: "partial", err: errors.New("boom")}
+	rec, err = get(p, "/api/export/stats?format=csv")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+
+	// empty export still answers with attachment
+	rec, err = get(&fakeExportProvider{}, "/api/export/stats?format=parquet")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="stats.parquet"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+	_, err = get(&fakeExportProvider{}, "/api/export/stats?from=yesterday")
+	var httpErr *echo.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
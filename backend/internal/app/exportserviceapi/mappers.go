@@ -0,0 +1,56 @@
+package exportserviceapi
+
+import (
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// mapQueryParamsToCommand defaults to csv of every entity over whole history
+func mapQueryParamsToCommand(formatParam, entityParam, fromParam, toParam string) (*exportprovide.Command, error) {
+	cmd := &exportprovide.Command{Format: exportprovide.FormatCSV}
+	if formatParam != "" {
+		cmd.Format = exportprovide.Format(formatParam)
+	}
+
+	for _, value := range strings.Split(entityParam, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			cmd.Entities = append(cmd.Entities, model.StatEntity(value))
+		}
+	}
+
+	if fromParam != "" {
+		from, err := parseTime(fromParam)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		cmd.From = from
+	}
+
+	if toParam != "" {
+		to, err := parseTime(toParam)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		// date without time covers the whole day
+		if len(toParam) == len(dateLayout) {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		cmd.To = to
+	}
+
+	return cmd, nil
+}
+
+// parseTime accepts both RFC3339 timestamps and plain dates
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+
+	return time.Parse(dateLayout, s)
+}
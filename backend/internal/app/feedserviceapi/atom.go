@@ -0,0 +1,75 @@
+package feedserviceapi
+
+import (
+	"encoding/xml"
+	"playcount-monitor-backend/internal/dto"
+	"time"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomFeed struct {
+	XMLName xml.Name     `xml:"feed"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []*atomLink  `xml:"link"`
+	Author  *atomAuthor  `xml:"author"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []*atomLink `xml:"link"`
+	Author  *atomAuthor `xml:"author"`
+	Summary string      `xml:"summary"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// renderAtom renders feed as Atom 1.0 document, selfURL is the url feed is served at
+func renderAtom(feed *dto.Feed, selfURL string, author string) ([]byte, error) {
+	doc := &atomFeed{
+		Xmlns:   atomNamespace,
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links:   []*atomLink{{Rel: "self", Type: "application/atom+xml", Href: selfURL}},
+		Author:  &atomAuthor{Name: author},
+		Entries: make([]*atomEntry, 0, len(feed.Entries)),
+	}
+
+	for _, entry := range feed.Entries {
+		links := []*atomLink{{Rel: "alternate", Type: "text/html", Href: entry.URL}}
+		if entry.ImageURL != "" {
+			links = append(links, &atomLink{Rel: "enclosure", Type: "image/jpeg", Href: entry.ImageURL})
+		}
+
+		doc.Entries = append(doc.Entries, &atomEntry{
+			ID:      entry.ID,
+			Title:   entry.Title,
+			Updated: entry.Updated.UTC().Format(time.RFC3339),
+			Links:   links,
+			Author:  &atomAuthor{Name: entry.Author},
+			Summary: entry.Summary,
+		})
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
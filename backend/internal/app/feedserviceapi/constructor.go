@@ -0,0 +1,27 @@
+package feedserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+)
+
+type feedProvider interface {
+	GetGlobal(ctx context.Context) (*dto.Feed, error)
+	GetForUser(ctx context.Context, userID int) (*dto.Feed, error)
+}
+
+type ServiceImpl struct {
+	lg           *log.Logger
+	feedProvider feedProvider
+}
+
+func New(
+	lg *log.Logger,
+	feedProvider feedProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:           lg,
+		feedProvider: feedProvider,
+	}
+}
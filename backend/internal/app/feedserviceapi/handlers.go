@@ -0,0 +1,77 @@
+package feedserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/dto"
+	"strconv"
+	"time"
+)
+
+const (
+	atomContentType = "application/atom+xml; charset=utf-8"
+	feedAuthor      = "playcount-monitor"
+)
+
+func (s *ServiceImpl) GetGlobal(c echo.Context) error {
+	feed, err := s.feedProvider.GetGlobal(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return respondWithFeed(c, feed)
+}
+
+func (s *ServiceImpl) GetForUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	feed, err := s.feedProvider.GetForUser(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return respondWithFeed(c, feed)
+}
+
+// respondWithFeed renders feed and answers 304 when reader already has its latest entry by If-Modified-Since,
+// ETag and If-None-Match are handled by conditionalGet middleware of read routes
+func respondWithFeed(c echo.Context, feed *dto.Feed) error {
+	req := c.Request()
+	body, err := renderAtom(feed, c.Scheme()+"://"+req.Host+req.URL.Path, feedAuthor)
+	if err != nil {
+		return err
+	}
+
+	if !feed.Updated.IsZero() {
+		c.Response().Header().Set(echo.HeaderLastModified, feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModifiedSince(req, feed.Updated) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, atomContentType, body)
+}
+
+// notModifiedSince follows RFC 7232, If-Modified-Since is ignored when If-None-Match is present
+func notModifiedSince(req *http.Request, updated time.Time) bool {
+	if req.Header.Get("If-None-Match") != "" || updated.IsZero() {
+		return false
+	}
+
+	ifModifiedSince := req.Header.Get(echo.HeaderIfModifiedSince)
+	if ifModifiedSince == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// http dates have second precision
+	return !updated.Truncate(time.Second).After(since)
+}
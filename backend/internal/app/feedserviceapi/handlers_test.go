@@ -0,0 +1,86 @@
+package feedserviceapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/dto"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeedProvider struct {
+	feed *dto.Feed
+}
+
+func (p *fakeFeedProvider) GetGlobal(context.Context) (*dto.Feed, error) {
+	return p.feed, nil
+}
+
+func (p *fakeFeedProvider) GetForUser(context.Context, int) (*dto.Feed, error) {
+	return p.feed, nil
+}
+
+func TestServiceImpl_GetGlobal(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 10, 30, 15, 500, time.UTC)
+	s := New(nil, &fakeFeedProvider{feed: &dto.Feed{
+		ID:      "urn:playcount-monitor:feed:global",
+		Title:   "Mapping activity",
+		Updated: updated,
+		Entries: []*dto.FeedEntry{{
+			ID:      "urn:playcount-monitor:milestone:1",
+			Title:   "artist - title passed 100000 plays",
+			URL:     "https://osu.ppy.sh/beatmapsets/1",
+			Author:  "mapper",
+			Updated: updated,
+		}},
+	}})
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/feed/atom", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, s.GetGlobal(echo.New().NewContext(req, rec)))
+
+		return rec
+	}
+
+	rec := get(nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, atomContentType, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "Tue, 02 Jan 2024 10:30:15 GMT", rec.Header().Get(echo.HeaderLastModified))
+	assert.Contains(t, rec.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, rec.Body.String(), `<link rel="self" type="application/atom+xml" href="http://example.com/api/feed/atom"></link>`)
+	assert.Contains(t, rec.Body.String(), `<title>artist - title passed 100000 plays</title>`)
+
+	assert.Empty(t, rec.Header().Get("ETag"), "etag is set by conditionalGet middleware")
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		wantCode int
+	}{
+		{"not modified since", map[string]string{echo.HeaderIfModifiedSince: "Tue, 02 Jan 2024 10:30:15 GMT"}, http.StatusNotModified},
+		{"not modified since later date", map[string]string{echo.HeaderIfModifiedSince: "Wed, 03 Jan 2024 00:00:00 GMT"}, http.StatusNotModified},
+		{"modified since", map[string]string{echo.HeaderIfModifiedSince: "Tue, 02 Jan 2024 10:30:14 GMT"}, http.StatusOK},
+		{"malformed date", map[string]string{echo.HeaderIfModifiedSince: "yesterday"}, http.StatusOK},
+		{"etag takes precedence", map[string]string{
+			"If-None-Match":            `"other"`,
+			echo.HeaderIfModifiedSince: "Tue, 02 Jan 2024 10:30:15 GMT",
+		}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(tt.headers)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, "Tue, 02 Jan 2024 10:30:15 GMT", rec.Header().Get(echo.HeaderLastModified))
+		})
+	}
+}
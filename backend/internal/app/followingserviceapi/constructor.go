@@ -3,7 +3,7 @@ package followingserviceapi
 import (
 	"context"
 	log "github.com/sirupsen/logrus"
-	"playcount-monitor-backend/internal/dto"
+	followingprovide "playcount-monitor-backend/internal/usecase/following/provide"
 )
 
 type followingCreator interface {
@@ -11,7 +11,7 @@ type followingCreator interface {
 }
 
 type followingProvider interface {
-	List(ctx context.Context) ([]*dto.Following, error)
+	List(ctx context.Context, cmd *followingprovide.ListCommand) (*followingprovide.ListResponse, error)
 }
 
 type ServiceImpl struct {
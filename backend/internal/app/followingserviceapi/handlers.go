@@ -2,6 +2,8 @@ package followingserviceapi
 
 import (
 	"github.com/labstack/echo/v4"
+	"playcount-monitor-backend/internal/http/pagequery"
+	followingprovide "playcount-monitor-backend/internal/usecase/following/provide"
 	"strconv"
 )
 
@@ -24,10 +26,18 @@ func (s *ServiceImpl) Create(c echo.Context) error {
 }
 
 func (s *ServiceImpl) List(c echo.Context) error {
-	trackingList, err := s.followingProvider.List(c.Request().Context())
+	pageQuery, err := pagequery.Parse(c)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(200, trackingList)
+	listResp, err := s.followingProvider.List(c.Request().Context(), &followingprovide.ListCommand{Query: pageQuery})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, FollowingListResponse{
+		Following: listResp.Following,
+		Page:      listResp.Page,
+	})
 }
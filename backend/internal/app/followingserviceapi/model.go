@@ -0,0 +1,8 @@
+package followingserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type FollowingListResponse struct {
+	Following []*dto.Following `json:"following"`
+	*dto.Page
+}
@@ -16,6 +16,9 @@ type mapsetProvider interface {
 	Get(ctx context.Context, id int) (*dto.Mapset, error)
 	List(ctx context.Context, cmd *mapsetprovide.ListCommand) (*mapsetprovide.ListResponse, error)
 	ListForUser(ctx context.Context, userID int, cmd *mapsetprovide.ListCommand) (*mapsetprovide.ListResponse, error)
+	ListGuestForUser(ctx context.Context, userID int, cmd *mapsetprovide.ListCommand) (*mapsetprovide.ListResponse, error)
+	ListTrending(ctx context.Context, cmd *mapsetprovide.ListTrendingCommand) (*mapsetprovide.ListTrendingResponse, error)
+	Search(ctx context.Context, cmd *mapsetprovide.SearchCommand) (*mapsetprovide.SearchResponse, error)
 }
 
 type ServiceImpl struct {
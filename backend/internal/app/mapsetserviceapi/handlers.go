@@ -3,6 +3,7 @@ package mapsetserviceapi
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"playcount-monitor-backend/internal/http/pagequery"
 	"playcount-monitor-backend/internal/usecase/command"
 	mapsetprovide "playcount-monitor-backend/internal/usecase/mapset/provide"
 	"strconv"
@@ -38,9 +39,9 @@ func (s *ServiceImpl) Get(c echo.Context) error {
 }
 
 func (s *ServiceImpl) List(c echo.Context) error {
-	pageInt, err := getPageQueryParam(c)
+	pageQuery, err := pagequery.Parse(c)
 	if err != nil {
-		return echo.ErrBadRequest
+		return err
 	}
 
 	mapsetSort := mapSortQueryParamsToMapsetSort(
@@ -53,22 +54,72 @@ func (s *ServiceImpl) List(c echo.Context) error {
 		c.QueryParam("status"),
 	)
 
+	accountID, watchlistID, err := getWatchlistQueryParam(c)
+	if err != nil {
+		return err
+	}
+
+	stats, err := getStatsQueryParam(c)
+	if err != nil {
+		return err
+	}
+
 	listResp, err := s.mapsetProvider.List(
 		c.Request().Context(),
 		&mapsetprovide.ListCommand{
-			Page:   pageInt,
-			Sort:   mapsetSort,
-			Filter: mapsetFilter,
+			Query:       pageQuery,
+			Sort:        mapsetSort,
+			Filter:      mapsetFilter,
+			Stats:       stats,
+			AccountID:   accountID,
+			WatchlistID: watchlistID,
 		},
 	)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return err
 	}
 
 	response := MapsetListResponse{
-		Mapsets:     listResp.Mapsets,
-		CurrentPage: listResp.CurrentPage,
-		Pages:       listResp.Pages,
+		Mapsets: listResp.Mapsets,
+		Page:    listResp.Page,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (s *ServiceImpl) Search(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	search, err := mapSearchQueryParamsToMapsetSearch(c)
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	stats, err := getStatsQueryParam(c)
+	if err != nil {
+		return err
+	}
+
+	searchResp, err := s.mapsetProvider.Search(
+		c.Request().Context(),
+		&mapsetprovide.SearchCommand{
+			Query:  pageQuery,
+			Sort:   mapSearchSortQueryParams(c.QueryParam("sort"), c.QueryParam("direction")),
+			Search: search,
+			Stats:  stats,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	response := MapsetSearchResponse{
+		Mapsets: searchResp.Mapsets,
+		Facets:  searchResp.Facets,
+		Page:    searchResp.Page,
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -80,9 +131,9 @@ func (s *ServiceImpl) ListForUser(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
-	pageInt, err := getPageQueryParam(c)
+	pageQuery, err := pagequery.Parse(c)
 	if err != nil {
-		return echo.ErrBadRequest
+		return err
 	}
 
 	mapsetSort := mapSortQueryParamsToMapsetSort(
@@ -95,23 +146,111 @@ func (s *ServiceImpl) ListForUser(c echo.Context) error {
 		c.QueryParam("status"),
 	)
 
+	stats, err := getStatsQueryParam(c)
+	if err != nil {
+		return err
+	}
+
 	listResp, err := s.mapsetProvider.ListForUser(
 		c.Request().Context(),
 		idInt,
 		&mapsetprovide.ListCommand{
-			Page:   pageInt,
+			Query:  pageQuery,
 			Sort:   mapsetSort,
 			Filter: mapsetFilter,
+			Stats:  stats,
 		},
 	)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return err
 	}
 
 	response := MapsetListResponse{
-		Mapsets:     listResp.Mapsets,
-		CurrentPage: listResp.CurrentPage,
-		Pages:       listResp.Pages,
+		Mapsets: listResp.Mapsets,
+		Page:    listResp.Page,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (s *ServiceImpl) ListGuestForUser(c echo.Context) error {
+	idInt, err := getUserIDFromContext(c)
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	mapsetSort := mapSortQueryParamsToMapsetSort(
+		c.QueryParam("sort"),
+		c.QueryParam("direction"),
+	)
+
+	mapsetFilter := mapSearchAndFilterQueryParamsToMapsetFilter(
+		c.QueryParam("search"),
+		c.QueryParam("status"),
+	)
+
+	listResp, err := s.mapsetProvider.ListGuestForUser(
+		c.Request().Context(),
+		idInt,
+		&mapsetprovide.ListCommand{
+			Query:  pageQuery,
+			Sort:   mapsetSort,
+			Filter: mapsetFilter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	response := GuestMapsetListResponse{
+		Mapsets: listResp.Mapsets,
+		Page:    listResp.Page,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (s *ServiceImpl) ListTrending(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	var days int
+	if daysParam := c.QueryParam("days"); daysParam != "" {
+		days, err = strconv.Atoi(daysParam)
+		if err != nil || days <= 0 {
+			return echo.ErrBadRequest
+		}
+	}
+
+	stats, err := getStatsQueryParam(c)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := s.mapsetProvider.ListTrending(
+		c.Request().Context(),
+		&mapsetprovide.ListTrendingCommand{
+			Query:      pageQuery,
+			PeriodDays: days,
+			Sort:       mapTrendingSortQueryParam(c.QueryParam("sort")),
+			Filter:     mapTrendingFilterQueryParams(c.QueryParam("status"), c.QueryParam("genre")),
+			Stats:      stats,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	response := TrendingMapsetListResponse{
+		Mapsets: listResp.Mapsets,
+		Page:    listResp.Page,
 	}
 
 	return c.JSON(http.StatusOK, response)
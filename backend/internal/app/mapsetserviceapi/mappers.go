@@ -3,8 +3,12 @@ package mapsetserviceapi
 import (
 	"errors"
 	"github.com/labstack/echo/v4"
+	"math"
 	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/http/principal"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"strconv"
+	"strings"
 )
 
 func mapSortQueryParamsToMapsetSort(fieldParam string, directionParam string) model.MapsetSort {
@@ -23,6 +27,8 @@ func mapSortQueryParamsToMapsetSort(fieldParam string, directionParam string) mo
 			field = model.MapsetFavs
 		case "last_comments":
 			field = model.MapsetComms
+		case "last_passcount":
+			field = model.MapsetPasscount
 		}
 
 		switch directionParam {
@@ -45,7 +51,7 @@ func mapSearchAndFilterQueryParamsToMapsetFilter(search string, status string) m
 	res := make(model.MapsetFilter)
 
 	if search != "" {
-		res[model.MapsetArtistOrTitleOrTagsFields] = search
+		res[model.MapsetSearchFields] = search
 	}
 
 	if checkIfStatusIsValid(status) {
@@ -55,6 +61,101 @@ func mapSearchAndFilterQueryParamsToMapsetFilter(search string, status string) m
 	return res
 }
 
+func mapSearchSortQueryParams(fieldParam string, directionParam string) model.MapsetSort {
+	if fieldParam == "relevance" {
+		return model.MapsetSort{Field: model.MapsetRelevance}
+	}
+
+	return mapSortQueryParamsToMapsetSort(fieldParam, directionParam)
+}
+
+// mapSearchQueryParamsToMapsetSearch reads query, comma separated multi-value filters and
+// <name>_min, <name>_max range filters
+func mapSearchQueryParamsToMapsetSearch(c echo.Context) (*model.MapsetSearch, error) {
+	search := &model.MapsetSearch{
+		Query:     strings.TrimSpace(c.QueryParam("q")),
+		Statuses:  splitQueryParam(c.QueryParam("status")),
+		Genres:    splitQueryParam(c.QueryParam("genre")),
+		Languages: splitQueryParam(c.QueryParam("language")),
+	}
+
+	for _, status := range search.Statuses {
+		if !checkIfStatusIsValid(status) {
+			return nil, errors.New("invalid status")
+		}
+	}
+
+	for name, r := range map[string]*model.Range{
+		"bpm":       &search.BPM,
+		"playcount": &search.Playcount,
+		"passcount": &search.Passcount,
+		"stars":     &search.StarRating,
+		"length":    &search.Length,
+		"ar":        &search.AR,
+		"cs":        &search.CS,
+	} {
+		var err error
+		if r.Min, err = getFloatQueryParam(c, name+"_min"); err != nil {
+			return nil, err
+		}
+		if r.Max, err = getFloatQueryParam(c, name+"_max"); err != nil {
+			return nil, err
+		}
+	}
+
+	return search, nil
+}
+
+func splitQueryParam(param string) []string {
+	var res []string
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			res = append(res, value)
+		}
+	}
+
+	return res
+}
+
+func getFloatQueryParam(c echo.Context, name string) (*float64, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(value) {
+		return nil, errors.New("invalid " + name)
+	}
+
+	return &value, nil
+}
+
+func mapTrendingSortQueryParam(sortParam string) model.TrendingSortField {
+	if sortParam == "relative" {
+		return model.TrendingRelative
+	}
+
+	return model.TrendingAbsolute
+}
+
+func mapTrendingFilterQueryParams(status string, genre string) model.MapsetFilter {
+	res := make(model.MapsetFilter)
+
+	if checkIfStatusIsValid(status) {
+		res[model.MapsetStatusField] = status
+	}
+
+	if genre != "" {
+		res[model.MapsetGenreField] = genre
+	}
+
+	return res
+}
+
 func checkIfStatusIsValid(status string) bool {
 	return status == "graveyard" ||
 		status == "wip" ||
@@ -65,22 +166,6 @@ func checkIfStatusIsValid(status string) bool {
 		status == "loved"
 }
 
-func getPageQueryParam(c echo.Context) (int, error) {
-	page := c.QueryParam("page")
-	var pageInt int
-	if page == "" {
-		pageInt = 1
-	} else {
-		var err error
-		pageInt, err = strconv.Atoi(page)
-		if err != nil || pageInt <= 0 {
-			return 0, err
-		}
-	}
-
-	return pageInt, nil
-}
-
 func getUserIDFromContext(c echo.Context) (int, error) {
 	id := c.Param("id")
 	if id == "" {
@@ -88,3 +173,36 @@ func getUserIDFromContext(c echo.Context) (int, error) {
 	}
 	return strconv.Atoi(id)
 }
+
+// getWatchlistQueryParam returns logged in account and watchlist it asks to narrow list to,
+// watchlists are private so filtering by one needs a session
+func getWatchlistQueryParam(c echo.Context) (int, int, error) {
+	watchlist := c.QueryParam("watchlist")
+	if watchlist == "" {
+		return 0, 0, nil
+	}
+
+	watchlistID, err := strconv.Atoi(watchlist)
+	if err != nil || watchlistID <= 0 {
+		return 0, 0, echo.ErrBadRequest
+	}
+
+	session := principal.Session(c)
+	if session == nil {
+		return 0, 0, domainerror.Unauthenticated("log in with osu! to filter by watchlist")
+	}
+
+	return session.UserID, watchlistID, nil
+}
+
+// getStatsQueryParam reads whether stats history is listed, latest stats are listed without it
+func getStatsQueryParam(c echo.Context) (model.ListingStats, error) {
+	switch stats := model.ListingStats(c.QueryParam("stats")); stats {
+	case "", model.ListingStatsHistory:
+		return model.ListingStatsHistory, nil
+	case model.ListingStatsLatest:
+		return stats, nil
+	default:
+		return "", echo.ErrBadRequest
+	}
+}
@@ -3,7 +3,22 @@ package mapsetserviceapi
 import "playcount-monitor-backend/internal/dto"
 
 type MapsetListResponse struct {
-	Mapsets     []*dto.Mapset `json:"mapsets"`
-	CurrentPage int           `json:"current_page"`
-	Pages       int           `json:"pages"`
+	Mapsets []*dto.Mapset `json:"mapsets"`
+	*dto.Page
+}
+
+type MapsetSearchResponse struct {
+	Mapsets []*dto.Mapset     `json:"mapsets"`
+	Facets  *dto.MapsetFacets `json:"facets"`
+	*dto.Page
+}
+
+type GuestMapsetListResponse struct {
+	Mapsets []*dto.Mapset `json:"mapsets"`
+	*dto.Page
+}
+
+type TrendingMapsetListResponse struct {
+	Mapsets []*dto.TrendingMapset `json:"mapsets"`
+	*dto.Page
 }
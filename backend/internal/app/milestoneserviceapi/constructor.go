@@ -0,0 +1,26 @@
+package milestoneserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	milestoneprovide "playcount-monitor-backend/internal/usecase/milestone/provide"
+)
+
+type milestoneProvider interface {
+	List(ctx context.Context, cmd *milestoneprovide.ListCommand) (*milestoneprovide.ListResponse, error)
+}
+
+type ServiceImpl struct {
+	lg                *log.Logger
+	milestoneProvider milestoneProvider
+}
+
+func New(
+	lg *log.Logger,
+	milestoneProvider milestoneProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:                lg,
+		milestoneProvider: milestoneProvider,
+	}
+}
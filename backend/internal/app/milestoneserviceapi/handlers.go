@@ -0,0 +1,51 @@
+package milestoneserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/http/pagequery"
+	milestoneprovide "playcount-monitor-backend/internal/usecase/milestone/provide"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	filter := make(model.MilestoneFilter)
+	if userID := c.QueryParam("user_id"); userID != "" {
+		userIDInt, err := strconv.Atoi(userID)
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+		filter[model.MilestoneUserIDField] = userIDInt
+	}
+
+	switch entityType := model.MilestoneEntityType(c.QueryParam("type")); entityType {
+	case "":
+	case model.MilestoneUser, model.MilestoneMapset:
+		filter[model.MilestoneEntityTypeField] = string(entityType)
+	default:
+		return echo.ErrBadRequest
+	}
+
+	if kind := c.QueryParam("kind"); kind != "" {
+		filter[model.MilestoneKindField] = kind
+	}
+
+	listResp, err := s.milestoneProvider.List(c.Request().Context(), &milestoneprovide.ListCommand{
+		Query:  pageQuery,
+		Filter: filter,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, MilestoneListResponse{
+		Milestones: listResp.Milestones,
+		Page:       listResp.Page,
+	})
+}
@@ -0,0 +1,8 @@
+package milestoneserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type MilestoneListResponse struct {
+	Milestones []*dto.Milestone `json:"milestones"`
+	*dto.Page
+}
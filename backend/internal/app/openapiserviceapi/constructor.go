@@ -0,0 +1,23 @@
+package openapiserviceapi
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type ServiceImpl struct {
+	lg       *log.Logger
+	document []byte
+	docsPage []byte
+}
+
+func New(
+	lg *log.Logger,
+	document []byte,
+	docsPage []byte,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:       lg,
+		document: document,
+		docsPage: docsPage,
+	}
+}
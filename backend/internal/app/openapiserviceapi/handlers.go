@@ -0,0 +1,14 @@
+package openapiserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+func (s *ServiceImpl) GetDocument(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, s.document)
+}
+
+func (s *ServiceImpl) GetDocs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, s.docsPage)
+}
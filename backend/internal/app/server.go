@@ -4,15 +4,33 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"playcount-monitor-backend/internal/app/cacheworker"
 	"playcount-monitor-backend/internal/bootstrap"
 	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/anomalyrepository"
+	"playcount-monitor-backend/internal/database/repository/apikeyrepository"
+	"playcount-monitor-backend/internal/database/repository/auditrepository"
 	"playcount-monitor-backend/internal/database/repository/beatmaprepository"
+	"playcount-monitor-backend/internal/database/repository/digestrepository"
+	"playcount-monitor-backend/internal/database/repository/exportrepository"
 	"playcount-monitor-backend/internal/database/repository/followingrepository"
+	"playcount-monitor-backend/internal/database/repository/mapseteventrepository"
+	"playcount-monitor-backend/internal/database/repository/mapsetlistingrepository"
 	"playcount-monitor-backend/internal/database/repository/mapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/milestonerepository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/repository/sessionrepository"
+	"playcount-monitor-backend/internal/database/repository/trackrepository"
 	"playcount-monitor-backend/internal/database/repository/userrepository"
+	"playcount-monitor-backend/internal/database/repository/watchedmapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/watchlistrepository"
+	"playcount-monitor-backend/internal/database/repository/webhookrepository"
 	"playcount-monitor-backend/internal/http"
 	"playcount-monitor-backend/internal/service/osuapi"
 	"playcount-monitor-backend/internal/service/osuapitokenprovider"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/service/tokencipher"
+	"playcount-monitor-backend/internal/usecase/cache"
 	"playcount-monitor-backend/internal/usecase/factory"
 	"syscall"
 	"time"
@@ -22,6 +40,9 @@ import (
 	netHttp "net/http"
 )
 
+// adminAPIKeyName is name ADMIN_API_KEY is stored under
+const adminAPIKeyName = "bootstrap admin"
+
 func Run(baseCtx context.Context, cfg *config.Config, lg *log.Logger) error {
 	db, err := bootstrap.InitDB(cfg)
 	if err != nil {
@@ -39,23 +60,70 @@ func Run(baseCtx context.Context, cfg *config.Config, lg *log.Logger) error {
 	mapsetRepo := mapsetrepository.New(cfg, lg)
 	beatmapRepo := beatmaprepository.New(cfg, lg)
 	followingRepo := followingrepository.New(cfg, lg)
+	anomalyRepo := anomalyrepository.New(cfg, lg)
+	milestoneRepo := milestonerepository.New(cfg, lg)
+	webhookRepo := webhookrepository.New(cfg, lg)
+	mapsetEventRepo := mapseteventrepository.New(cfg, lg)
+	digestRepo := digestrepository.New(cfg, lg)
+	apiKeyRepo := apikeyrepository.New(cfg, lg)
+	auditRepo := auditrepository.New(cfg, lg)
+	sessionRepo := sessionrepository.New(cfg, lg)
+	watchlistRepo := watchlistrepository.New(cfg, lg)
+	watchedMapsetRepo := watchedmapsetrepository.New(cfg, lg)
+	mapsetListingRepo := mapsetlistingrepository.New(cfg, lg)
+	trackRepo := trackrepository.New(cfg, lg)
+	exportRepo := exportrepository.New(cfg, lg)
 
 	// init api
 	httpClient := netHttp.Client{}
 	osuTokenProvider := osuapitokenprovider.New(cfg, &httpClient)
 	osuAPI := osuapi.New(cfg, osuTokenProvider, &httpClient)
+	osuOAuth := osuoauth.New(cfg, &httpClient)
+
+	// login with osu! stays disabled until tokens can be stored encrypted
+	var tokenCipher tokencipher.Interface
+	if cfg.TokenEncryptionKey != "" {
+		tokenCipher = tokencipher.New(cfg.TokenEncryptionKey)
+	}
+
+	// results of read use cases are kept until the next tracking run
+	var resultCache cache.Interface
+	if cfg.CacheSize > 0 {
+		resultCache = cache.NewLRU(cfg.CacheSize)
+	}
 
 	// useCase factory
-	f, err := factory.New(cfg, lg, txm, osuAPI, &factory.Repositories{
-		UserRepo:      userRepo,
-		BeatmapRepo:   beatmapRepo,
-		MapsetRepo:    mapsetRepo,
-		FollowingRepo: followingRepo,
+	f, err := factory.New(cfg, lg, txm, osuAPI, osuOAuth, tokenCipher, resultCache, &factory.Repositories{
+		UserRepo:          userRepo,
+		BeatmapRepo:       beatmapRepo,
+		MapsetRepo:        mapsetRepo,
+		MapsetListingRepo: mapsetListingRepo,
+		FollowingRepo:     followingRepo,
+		AnomalyRepo:       anomalyRepo,
+		MilestoneRepo:     milestoneRepo,
+		WebhookRepo:       webhookRepo,
+		MapsetEventRepo:   mapsetEventRepo,
+		DigestRepo:        digestRepo,
+		APIKeyRepo:        apiKeyRepo,
+		AuditRepo:         auditRepo,
+		SessionRepo:       sessionRepo,
+		WatchlistRepo:     watchlistRepo,
+		WatchedMapsetRepo: watchedMapsetRepo,
+		TrackRepo:         trackRepo,
+		ExportRepo:        exportRepo,
 	})
 	if err != nil {
 		return err
 	}
 
+	// without any admin key there would be no way to create keys through api
+	if cfg.AdminAPIKey != "" {
+		err = f.MakeManageAPIKeyUseCase().Ensure(baseCtx, adminAPIKeyName, cfg.AdminAPIKey, model.RoleAdmin)
+		if err != nil {
+			return err
+		}
+	}
+
 	httpServer, err := http.New(cfg, lg, f)
 	if err != nil {
 		return err
@@ -65,6 +133,10 @@ func Run(baseCtx context.Context, cfg *config.Config, lg *log.Logger) error {
 
 	httpServer.Start()
 
+	if resultCache != nil {
+		cacheworker.New(cfg, lg, f.MakeCacheInvalidator()).Start(ctx)
+	}
+
 	gracefulShutDown(ctx, cancel)
 
 	return nil
@@ -0,0 +1,32 @@
+package sessionserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/dto"
+)
+
+type sessionManager interface {
+	Begin() (*dto.LoginRedirect, error)
+	Login(ctx context.Context, code string) (*dto.Session, error)
+	Logout(ctx context.Context, plaintext string) error
+}
+
+type ServiceImpl struct {
+	cfg            *config.Config
+	lg             *log.Logger
+	sessionManager sessionManager
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	sessionManager sessionManager,
+) *ServiceImpl {
+	return &ServiceImpl{
+		cfg:            cfg,
+		lg:             lg,
+		sessionManager: sessionManager,
+	}
+}
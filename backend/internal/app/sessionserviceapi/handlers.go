@@ -0,0 +1,84 @@
+package sessionserviceapi
+
+import (
+	"crypto/subtle"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/principal"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"time"
+)
+
+const (
+	// stateCookie carries login state to callback, it is scoped to login paths and lives as long as
+	// user may reasonably take to approve access on osu!
+	stateCookie     = "pm_oauth_state"
+	stateCookiePath = "/api/auth/osu"
+	stateCookieTTL  = 10 * time.Minute
+)
+
+// Login sends user to osu! to approve access
+func (s *ServiceImpl) Login(c echo.Context) error {
+	redirect, err := s.sessionManager.Begin()
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(s.cookie(stateCookie, redirect.State, stateCookiePath, time.Now().Add(stateCookieTTL)))
+
+	return c.Redirect(http.StatusFound, redirect.URL)
+}
+
+// Callback is where osu! sends user back to, it starts session and sends user on to the frontend
+func (s *ServiceImpl) Callback(c echo.Context) error {
+	state, err := c.Cookie(stateCookie)
+	if err != nil || state.Value == "" ||
+		subtle.ConstantTimeCompare([]byte(state.Value), []byte(c.QueryParam("state"))) != 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "login state mismatch, start login again")
+	}
+	c.SetCookie(s.cookie(stateCookie, "", stateCookiePath, time.Unix(0, 0)))
+
+	// set instead of code when user denies access
+	if c.QueryParam("error") != "" {
+		return domainerror.Unauthenticated("osu! authorization was denied")
+	}
+
+	code := c.QueryParam("code")
+	if code == "" {
+		return echo.ErrBadRequest
+	}
+
+	session, err := s.sessionManager.Login(c.Request().Context(), code)
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(s.cookie(principal.SessionCookie, session.Token, "/", session.ExpiresAt))
+
+	return c.Redirect(http.StatusFound, s.cfg.LoginRedirectURL)
+}
+
+func (s *ServiceImpl) Logout(c echo.Context) error {
+	if cookie, err := c.Cookie(principal.SessionCookie); err == nil && cookie.Value != "" {
+		if err := s.sessionManager.Logout(c.Request().Context(), cookie.Value); err != nil {
+			return err
+		}
+	}
+
+	c.SetCookie(s.cookie(principal.SessionCookie, "", "/", time.Unix(0, 0)))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *ServiceImpl) cookie(name string, value string, path string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   s.cfg.SessionCookieSecure,
+		// lax still sends cookie on top level navigation back from osu!, but not on cross site posts
+		SameSite: http.SameSiteLaxMode,
+	}
+}
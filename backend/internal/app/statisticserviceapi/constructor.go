@@ -12,7 +12,7 @@ type ServiceImpl struct {
 }
 
 type statisticProvider interface {
-	GetForUser(ctx context.Context, id int) (*statisticprovide.UserMapStatistics, error)
+	GetForUser(ctx context.Context, id int, includeGuest bool) (*statisticprovide.UserMapStatistics, error)
 }
 
 func New(
@@ -15,9 +15,18 @@ func (s *ServiceImpl) GetUserMapStatistics(c echo.Context) error {
 		return echo.ErrBadRequest
 	}
 
-	userStatistics, err := s.statisticProvider.GetForUser(c.Request().Context(), idInt)
+	// guest difficulties are included unless explicitly turned off
+	includeGuest := true
+	if param := c.QueryParam("include_guest"); param != "" {
+		includeGuest, err = strconv.ParseBool(param)
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+	}
+
+	userStatistics, err := s.statisticProvider.GetForUser(c.Request().Context(), idInt, includeGuest)
 	if err != nil {
-		return echo.ErrInternalServerError
+		return err
 	}
 
 	return c.JSON(200, userStatistics)
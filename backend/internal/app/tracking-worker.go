@@ -4,16 +4,28 @@ import (
 	"context"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"net/http"
+	"playcount-monitor-backend/internal/app/digestworker"
 	"playcount-monitor-backend/internal/app/trackingworker"
 	"playcount-monitor-backend/internal/bootstrap"
 	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/anomalyrepository"
 	"playcount-monitor-backend/internal/database/repository/beatmaprepository"
+	"playcount-monitor-backend/internal/database/repository/digestrepository"
 	"playcount-monitor-backend/internal/database/repository/followingrepository"
+	"playcount-monitor-backend/internal/database/repository/mapseteventrepository"
 	"playcount-monitor-backend/internal/database/repository/mapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/milestonerepository"
 	"playcount-monitor-backend/internal/database/repository/trackrepository"
 	"playcount-monitor-backend/internal/database/repository/userrepository"
+	"playcount-monitor-backend/internal/database/repository/watchedmapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/watchlistrepository"
+	"playcount-monitor-backend/internal/database/repository/webhookrepository"
+	"playcount-monitor-backend/internal/service/mailer"
+	"playcount-monitor-backend/internal/service/notifier"
 	"playcount-monitor-backend/internal/service/osuapi"
 	"playcount-monitor-backend/internal/service/osuapitokenprovider"
+	"playcount-monitor-backend/internal/usecase/digest/send"
 	"playcount-monitor-backend/internal/usecase/track"
 	"time"
 )
@@ -46,12 +58,23 @@ func RunTrackingWorker(
 	beatmapRepo := beatmaprepository.New(cfg, lg)
 	followingRepo := followingrepository.New(cfg, lg)
 	trackRepo := trackrepository.New(cfg, lg)
+	anomalyRepo := anomalyrepository.New(cfg, lg)
+	milestoneRepo := milestonerepository.New(cfg, lg)
+	webhookRepo := webhookrepository.New(cfg, lg)
+	mapsetEventRepo := mapseteventrepository.New(cfg, lg)
+	digestRepo := digestrepository.New(cfg, lg)
+	watchlistRepo := watchlistrepository.New(cfg, lg)
+	watchedMapsetRepo := watchedmapsetrepository.New(cfg, lg)
 
 	// init api
 	httpClient := bootstrap.NewHTTPClient()
 	osuTokenProvider := osuapitokenprovider.New(cfg, &httpClient)
 	osuAPI := osuapi.New(cfg, osuTokenProvider, &httpClient)
 
+	// webhooks get their own client so they don't count towards api requests
+	webhookHTTPClient := http.Client{}
+	webhookNotifier := notifier.NewWebhook(cfg, lg, txm, webhookRepo, &webhookHTTPClient)
+
 	worker := trackingworker.New(cfg, lg, track.New(
 		cfg,
 		txm,
@@ -60,11 +83,30 @@ func RunTrackingWorker(
 		mapsetRepo,
 		beatmapRepo,
 		followingRepo,
+		watchlistRepo,
+		watchedMapsetRepo,
 		trackRepo,
+		anomalyRepo,
+		milestoneRepo,
+		mapsetEventRepo,
+		webhookNotifier,
 	))
 
 	worker.Start(ctx)
 
+	// digests are built out of snapshots the tracker stores, so they share its process
+	digestWorker := digestworker.New(cfg, lg, digestsend.New(
+		cfg,
+		lg,
+		txm,
+		digestRepo,
+		mapsetRepo,
+		mapsetEventRepo,
+		mailer.New(cfg),
+	))
+
+	digestWorker.Start(ctx)
+
 	gracefulShutDown(ctx, cancel)
 
 	return nil
@@ -5,6 +5,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/dto"
+	userprovide "playcount-monitor-backend/internal/usecase/user/provide"
 )
 
 type userCreator interface {
@@ -14,7 +15,7 @@ type userCreator interface {
 type userProvider interface {
 	Get(ctx context.Context, id int) (*dto.User, error)
 	GetByName(ctx context.Context, name string) (*dto.User, error)
-	List(ctx context.Context) ([]*dto.User, error)
+	List(ctx context.Context, cmd *userprovide.ListCommand) (*userprovide.ListResponse, error)
 }
 
 type userUpdater interface {
@@ -3,6 +3,10 @@ package userserviceapi
 import (
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/http/pagequery"
+	"playcount-monitor-backend/internal/http/principal"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	userprovide "playcount-monitor-backend/internal/usecase/user/provide"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
@@ -56,10 +60,33 @@ func (s *ServiceImpl) GetByName(c echo.Context) error {
 }
 
 func (s *ServiceImpl) List(c echo.Context) error {
-	users, err := s.userProvider.List(c.Request().Context())
+	pageQuery, err := pagequery.Parse(c)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(200, users)
+	cmd := &userprovide.ListCommand{Query: pageQuery}
+	if watchlist := c.QueryParam("watchlist"); watchlist != "" {
+		watchlistID, err := strconv.Atoi(watchlist)
+		if err != nil || watchlistID <= 0 {
+			return echo.ErrBadRequest
+		}
+
+		// watchlists are private so filtering by one needs a session
+		session := principal.Session(c)
+		if session == nil {
+			return domainerror.Unauthenticated("log in with osu! to filter by watchlist")
+		}
+		cmd.AccountID, cmd.WatchlistID = session.UserID, watchlistID
+	}
+
+	listResp, err := s.userProvider.List(c.Request().Context(), cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, UserListResponse{
+		Users: listResp.Users,
+		Page:  listResp.Page,
+	})
 }
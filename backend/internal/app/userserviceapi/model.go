@@ -0,0 +1,8 @@
+package userserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type UserListResponse struct {
+	Users []*dto.User `json:"users"`
+	*dto.Page
+}
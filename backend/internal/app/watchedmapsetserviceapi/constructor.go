@@ -0,0 +1,35 @@
+package watchedmapsetserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+	watchedmapsetprovide "playcount-monitor-backend/internal/usecase/watchedmapset/provide"
+)
+
+type watchedMapsetProvider interface {
+	List(ctx context.Context, cmd *watchedmapsetprovide.ListCommand) (*watchedmapsetprovide.ListResponse, error)
+}
+
+type watchedMapsetManager interface {
+	Create(ctx context.Context, id int) (*dto.WatchedMapset, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type ServiceImpl struct {
+	lg                    *log.Logger
+	watchedMapsetProvider watchedMapsetProvider
+	watchedMapsetManager  watchedMapsetManager
+}
+
+func New(
+	lg *log.Logger,
+	watchedMapsetProvider watchedMapsetProvider,
+	watchedMapsetManager watchedMapsetManager,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:                    lg,
+		watchedMapsetProvider: watchedMapsetProvider,
+		watchedMapsetManager:  watchedMapsetManager,
+	}
+}
@@ -0,0 +1,53 @@
+package watchedmapsetserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/pagequery"
+	watchedmapsetprovide "playcount-monitor-backend/internal/usecase/watchedmapset/provide"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := s.watchedMapsetProvider.List(c.Request().Context(), &watchedmapsetprovide.ListCommand{Query: pageQuery})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, WatchedMapsetListResponse{
+		Mapsets: listResp.Mapsets,
+		Page:    listResp.Page,
+	})
+}
+
+func (s *ServiceImpl) Create(c echo.Context) error {
+	req := new(WatchedMapsetRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	watched, err := s.watchedMapsetManager.Create(c.Request().Context(), req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, watched)
+}
+
+func (s *ServiceImpl) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if err := s.watchedMapsetManager.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
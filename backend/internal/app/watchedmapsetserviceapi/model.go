@@ -0,0 +1,12 @@
+package watchedmapsetserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type WatchedMapsetListResponse struct {
+	Mapsets []*dto.WatchedMapset `json:"mapsets"`
+	*dto.Page
+}
+
+type WatchedMapsetRequest struct {
+	ID int `json:"id"`
+}
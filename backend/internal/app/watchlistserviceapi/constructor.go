@@ -0,0 +1,38 @@
+package watchlistserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+	watchlistmanage "playcount-monitor-backend/internal/usecase/watchlist/manage"
+)
+
+type watchlistProvider interface {
+	List(ctx context.Context, accountID int) ([]*dto.Watchlist, error)
+	Get(ctx context.Context, accountID, id int) (*dto.Watchlist, error)
+}
+
+type watchlistManager interface {
+	Create(ctx context.Context, accountID int, cmd *watchlistmanage.CreateCommand) (*dto.Watchlist, error)
+	Delete(ctx context.Context, accountID, id int) error
+	PutEntry(ctx context.Context, accountID, id int, cmd *watchlistmanage.EntryCommand) (*dto.Watchlist, error)
+	DeleteEntry(ctx context.Context, accountID, id int, entityType string, entityID int) error
+}
+
+type ServiceImpl struct {
+	lg                *log.Logger
+	watchlistProvider watchlistProvider
+	watchlistManager  watchlistManager
+}
+
+func New(
+	lg *log.Logger,
+	watchlistProvider watchlistProvider,
+	watchlistManager watchlistManager,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:                lg,
+		watchlistProvider: watchlistProvider,
+		watchlistManager:  watchlistManager,
+	}
+}
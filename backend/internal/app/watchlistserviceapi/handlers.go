@@ -0,0 +1,105 @@
+package watchlistserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/principal"
+	watchlistmanage "playcount-monitor-backend/internal/usecase/watchlist/manage"
+	"strconv"
+)
+
+// routes are behind session middleware, so session is always there
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	watchlists, err := s.watchlistProvider.List(c.Request().Context(), principal.Session(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, watchlists)
+}
+
+func (s *ServiceImpl) Get(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	watchlist, err := s.watchlistProvider.Get(c.Request().Context(), principal.Session(c).UserID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, watchlist)
+}
+
+func (s *ServiceImpl) Create(c echo.Context) error {
+	req := new(WatchlistRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	watchlist, err := s.watchlistManager.Create(c.Request().Context(), principal.Session(c).UserID, &watchlistmanage.CreateCommand{
+		Name: req.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, watchlist)
+}
+
+func (s *ServiceImpl) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if err := s.watchlistManager.Delete(c.Request().Context(), principal.Session(c).UserID, id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *ServiceImpl) PutEntry(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	req := new(EntryRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	watchlist, err := s.watchlistManager.PutEntry(c.Request().Context(), principal.Session(c).UserID, id, &watchlistmanage.EntryCommand{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Note:       req.Note,
+		Tags:       req.Tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, watchlist)
+}
+
+func (s *ServiceImpl) DeleteEntry(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+	entityID, err := strconv.Atoi(c.Param("entity_id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err = s.watchlistManager.DeleteEntry(c.Request().Context(), principal.Session(c).UserID, id, c.Param("type"), entityID)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,12 @@
+package watchlistserviceapi
+
+type WatchlistRequest struct {
+	Name string `json:"name"`
+}
+
+type EntryRequest struct {
+	EntityType string   `json:"entity_type"`
+	EntityID   int      `json:"entity_id"`
+	Note       string   `json:"note"`
+	Tags       []string `json:"tags"`
+}
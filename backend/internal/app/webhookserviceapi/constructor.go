@@ -0,0 +1,38 @@
+package webhookserviceapi
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/dto"
+	webhookmanage "playcount-monitor-backend/internal/usecase/webhook/manage"
+	webhookprovide "playcount-monitor-backend/internal/usecase/webhook/provide"
+)
+
+type webhookManager interface {
+	Create(ctx context.Context, cmd *webhookmanage.Command) (*dto.WebhookSubscription, error)
+	Update(ctx context.Context, id int, cmd *webhookmanage.Command) (*dto.WebhookSubscription, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type webhookProvider interface {
+	List(ctx context.Context) ([]*dto.WebhookSubscription, error)
+	ListDeliveries(ctx context.Context, cmd *webhookprovide.ListDeliveriesCommand) (*webhookprovide.ListDeliveriesResponse, error)
+}
+
+type ServiceImpl struct {
+	lg              *log.Logger
+	webhookManager  webhookManager
+	webhookProvider webhookProvider
+}
+
+func New(
+	lg *log.Logger,
+	webhookManager webhookManager,
+	webhookProvider webhookProvider,
+) *ServiceImpl {
+	return &ServiceImpl{
+		lg:              lg,
+		webhookManager:  webhookManager,
+		webhookProvider: webhookProvider,
+	}
+}
@@ -0,0 +1,100 @@
+package webhookserviceapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/pagequery"
+	webhookmanage "playcount-monitor-backend/internal/usecase/webhook/manage"
+	webhookprovide "playcount-monitor-backend/internal/usecase/webhook/provide"
+	"strconv"
+)
+
+func (s *ServiceImpl) List(c echo.Context) error {
+	subscriptions, err := s.webhookProvider.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+func (s *ServiceImpl) Create(c echo.Context) error {
+	req := new(SubscriptionRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	subscription, err := s.webhookManager.Create(c.Request().Context(), mapSubscriptionRequestToCommand(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+func (s *ServiceImpl) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	req := new(SubscriptionRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	subscription, err := s.webhookManager.Update(c.Request().Context(), id, mapSubscriptionRequestToCommand(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+func (s *ServiceImpl) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if err := s.webhookManager.Delete(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *ServiceImpl) ListDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	pageQuery, err := pagequery.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := s.webhookProvider.ListDeliveries(c.Request().Context(), &webhookprovide.ListDeliveriesCommand{
+		SubscriptionID: id,
+		Query:          pageQuery,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, DeliveryListResponse{
+		Deliveries: listResp.Deliveries,
+		Page:       listResp.Page,
+	})
+}
+
+func mapSubscriptionRequestToCommand(req *SubscriptionRequest) *webhookmanage.Command {
+	return &webhookmanage.Command{
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+		Kind:      req.Kind,
+		Templates: req.Templates,
+	}
+}
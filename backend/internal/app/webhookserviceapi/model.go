@@ -0,0 +1,16 @@
+package webhookserviceapi
+
+import "playcount-monitor-backend/internal/dto"
+
+type SubscriptionRequest struct {
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret"`
+	Events    []string          `json:"events"`
+	Kind      string            `json:"kind"`
+	Templates map[string]string `json:"templates"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []*dto.WebhookDelivery `json:"deliveries"`
+	*dto.Page
+}
@@ -22,15 +22,89 @@ type Config struct {
 	TrackingTimeout  time.Duration `env:"TRACKING_TIMEOUT" envDefault:"30m"`
 	TrackingInterval time.Duration `env:"TRACKING_INTERVAL" envDefault:"24h"`
 
+	// how often mapset comment counts are refetched from api, depends on mapset status
+	CommentsRefreshGraveyard time.Duration `env:"COMMENTS_REFRESH_GRAVEYARD" envDefault:"168h"`
+	CommentsRefreshPending   time.Duration `env:"COMMENTS_REFRESH_PENDING" envDefault:"24h"`
+	CommentsRefreshRanked    time.Duration `env:"COMMENTS_REFRESH_RANKED" envDefault:"72h"`
+
+	// trending mapsets are ranked by playcount gain over each of these periods, in days
+	TrendingPeriods []int `env:"TRENDING_PERIODS" envSeparator:"," envDefault:"1,7,14"`
+
+	// latest daily playcount delta is flagged when it is ANOMALY_THRESHOLD robust deviations
+	// and at least ANOMALY_MIN_DELTA plays away from median of previous ANOMALY_BASELINE_DAYS deltas
+	AnomalyThreshold    float64 `env:"ANOMALY_THRESHOLD" envDefault:"5"`
+	AnomalyMinDelta     int     `env:"ANOMALY_MIN_DELTA" envDefault:"50"`
+	AnomalyBaselineDays int     `env:"ANOMALY_BASELINE_DAYS" envDefault:"7"`
+
+	// milestone thresholds, crossing one between two tracking runs is stored as milestone event
+	MilestoneUserPlaycounts   []int `env:"MILESTONE_USER_PLAYCOUNTS" envSeparator:"," envDefault:"100000,1000000,10000000"`
+	MilestoneMapsetPlaycounts []int `env:"MILESTONE_MAPSET_PLAYCOUNTS" envSeparator:"," envDefault:"100000,1000000"`
+	MilestoneFavourites       []int `env:"MILESTONE_FAVOURITES" envSeparator:"," envDefault:"100,1000,10000"`
+	MilestoneMapCounts        []int `env:"MILESTONE_MAP_COUNTS" envSeparator:"," envDefault:"10,50,100"`
+
+	// webhook deliveries are retried with exponential backoff starting at WEBHOOK_RETRY_BACKOFF,
+	// Retry-After asked by receiver is waited out up to WEBHOOK_MAX_RETRY_AFTER
+	WebhookMaxAttempts   int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"5"`
+	WebhookRetryBackoff  time.Duration `env:"WEBHOOK_RETRY_BACKOFF" envDefault:"2s"`
+	WebhookMaxRetryAfter time.Duration `env:"WEBHOOK_MAX_RETRY_AFTER" envDefault:"1m"`
+	WebhookTimeout       time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// events of a tracking run are delivered to WEBHOOK_CONCURRENCY subscriptions at once,
+	// deliveries still running after WEBHOOK_NOTIFY_TIMEOUT are given up
+	WebhookConcurrency   int           `env:"WEBHOOK_CONCURRENCY" envDefault:"4"`
+	WebhookNotifyTimeout time.Duration `env:"WEBHOOK_NOTIFY_TIMEOUT" envDefault:"5m"`
+
+	// discord allows around 30 messages a minute per channel webhook
+	DiscordMinInterval time.Duration `env:"DISCORD_MIN_INTERVAL" envDefault:"2s"`
+
+	// digests are sent through this smtp server, authentication is skipped when username is empty
+	SMTPHost     string `env:"SMTP_HOST" envDefault:"localhost"`
+	SMTPPort     int    `env:"SMTP_PORT" envDefault:"25"`
+	SMTPUsername string `env:"SMTP_USERNAME" envDefault:""`
+	SMTPPassword string `env:"SMTP_PASSWORD" envDefault:""`
+	SMTPFrom     string `env:"SMTP_FROM" envDefault:"playcount-monitor@localhost"`
+
+	// how often digest subscriptions are checked for being due, and how many top gaining maps a digest lists
+	DigestCheckInterval time.Duration `env:"DIGEST_CHECK_INTERVAL" envDefault:"1h"`
+	DigestTopMapsets    int           `env:"DIGEST_TOP_MAPSETS" envDefault:"10"`
+
+	// write endpoints need an api key with editor role, key management and audit log need admin role,
+	// reads need viewer role only when AUTH_REQUIRE_VIEWER is set. ADMIN_API_KEY is stored as admin key on start
+	AdminAPIKey       string `env:"ADMIN_API_KEY" envDefault:""`
+	AuthRequireViewer bool   `env:"AUTH_REQUIRE_VIEWER" envDefault:"false"`
+
+	// results of read use cases are cached in memory until the next tracking run, CACHE_SIZE of zero disables cache.
+	// completed runs are looked for every CACHE_CHECK_INTERVAL, clients reuse responses for HTTP_CACHE_MAX_AGE
+	// and revalidate them with ETag after that
+	CacheSize          int           `env:"CACHE_SIZE" envDefault:"1000"`
+	CacheCheckInterval time.Duration `env:"CACHE_CHECK_INTERVAL" envDefault:"1m"`
+	HTTPCacheMaxAge    time.Duration `env:"HTTP_CACHE_MAX_AGE" envDefault:"60s"`
+
 	CleaningTimeout  time.Duration `env:"CLEANING_TIMEOUT" envDefault:"30m"`
 	CleaningInterval time.Duration `env:"CLEANING_INTERVAL" envDefault:"24h"`
 
+	// backup archives are written to BACKUP_DIR every BACKUP_INTERVAL, only the newest BACKUP_RETENTION
+	// of them are kept, zero keeps all
+	BackupDir       string        `env:"BACKUP_DIR" envDefault:"backups"`
+	BackupInterval  time.Duration `env:"BACKUP_INTERVAL" envDefault:"24h"`
+	BackupTimeout   time.Duration `env:"BACKUP_TIMEOUT" envDefault:"30m"`
+	BackupRetention int           `env:"BACKUP_RETENTION" envDefault:"7"`
+
 	OsuAPIClientID     string `env:"OSU_API_CLIENT_ID" envDefault:""`
 	OsuAPIClientSecret string `env:"OSU_API_CLIENT_SECRET" envDefault:""`
 
 	OsuAPIHost   string `env:"OSU_API_HOST" envDefault:"https://osu.ppy.sh/api/v2"`
 	OsuOAuthHost string `env:"OSU_OAUTH_HOST" envDefault:"https://osu.ppy.sh/oauth/token"`
 
+	// login with osu! uses authorization code flow of the same oauth client, OSU_OAUTH_REDIRECT_URL has to be
+	// registered as its callback url. tokens are stored encrypted with TOKEN_ENCRYPTION_KEY, login is disabled without it
+	OsuOAuthAuthorizeURL string        `env:"OSU_OAUTH_AUTHORIZE_URL" envDefault:"https://osu.ppy.sh/oauth/authorize"`
+	OsuOAuthRedirectURL  string        `env:"OSU_OAUTH_REDIRECT_URL" envDefault:"http://localhost:8080/api/auth/osu/callback"`
+	TokenEncryptionKey   string        `env:"TOKEN_ENCRYPTION_KEY" envDefault:""`
+	SessionTTL           time.Duration `env:"SESSION_TTL" envDefault:"720h"`
+	SessionCookieSecure  bool          `env:"SESSION_COOKIE_SECURE" envDefault:"true"`
+	// where browser is sent after login callback
+	LoginRedirectURL string `env:"LOGIN_REDIRECT_URL" envDefault:"/"`
+
 	RunIntegrationTest bool `env:"RUN_INTEGRATION_TEST" envDefault:"false"`
 
 	IntegrationTestPgDSN  string `env:"INTEGRATION_TEST_PG_DSN" envDefault:"postgresql://db:5467/db?user=db&password=db"`
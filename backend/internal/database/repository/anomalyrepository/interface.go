@@ -0,0 +1,17 @@
+package anomalyrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, anomalies ...*model.Anomaly) ([]*model.Anomaly, error)
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.AnomalyFilter,
+		page model.PageRequest,
+	) ([]*model.Anomaly, *model.Page, error)
+}
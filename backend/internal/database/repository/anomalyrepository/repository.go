@@ -0,0 +1,95 @@
+package anomalyrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"sort"
+	"strings"
+)
+
+const anomaliesTableName = "anomalies"
+
+// Create stores anomalies, ones already flagged by previous analysis are skipped.
+// Rows are inserted one by one, batched insert can't tell which rows were skipped on conflict
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, anomalies ...*model.Anomaly) ([]*model.Anomaly, error) {
+	created := make([]*model.Anomaly, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		res := tx.DB().WithContext(ctx).Table(anomaliesTableName).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(anomaly)
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to create anomalies: %w", res.Error)
+		}
+
+		if res.RowsAffected > 0 {
+			created = append(created, anomaly)
+		}
+	}
+
+	return created, nil
+}
+
+// List lists page of anomalies newest first
+func (r *GormRepository) List(
+	ctx context.Context,
+	tx txmanager.Tx,
+	filter model.AnomalyFilter,
+	page model.PageRequest,
+) ([]*model.Anomaly, *model.Page, error) {
+	var anomalies []*model.Anomaly
+	var count int64
+
+	query, values := buildListByFilterQuery(filter)
+	filterGormExpr := gorm.Expr(query, values...)
+	if query == "" {
+		filterGormExpr = gorm.Expr("1 = 1")
+	}
+
+	err := tx.DB().WithContext(ctx).
+		Table(anomaliesTableName).
+		Where(filterGormExpr).
+		Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count anomalies: %w", err)
+	}
+
+	order := keyset.Order{Column: "detected_for", IDColumn: "id", Direction: model.DESC}
+	db, err := keyset.Apply(tx.DB().WithContext(ctx).Table(anomaliesTableName).Where(filterGormExpr), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&anomalies).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list anomalies: %w", err)
+	}
+
+	anomalies, res := keyset.Trim(anomalies, order, page, int(count), func(e *model.Anomaly) (string, int) {
+		return keyset.Time(e.DetectedFor), e.ID
+	})
+
+	return anomalies, res, nil
+}
+
+func buildListByFilterQuery(filter model.AnomalyFilter) (string, []interface{}) {
+	keys := make([]string, 0, len(filter))
+	for column := range filter {
+		keys = append(keys, string(column))
+	}
+
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	values := make([]interface{}, 0, len(keys))
+	for _, column := range keys {
+		conditions = append(conditions, column+" = ?")
+		values = append(values, filter[model.AnomalyFilterField(column)])
+	}
+
+	return strings.Join(conditions, " AND "), values
+}
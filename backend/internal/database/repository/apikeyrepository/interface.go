@@ -0,0 +1,17 @@
+package apikeyrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, key *model.APIKey) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.APIKey, error)
+	GetByHash(ctx context.Context, tx txmanager.Tx, keyHash string) (*model.APIKey, error)
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, tx txmanager.Tx, id int, revokedAt time.Time) error
+	SetLastUsed(ctx context.Context, tx txmanager.Tx, id int, usedAt time.Time) error
+}
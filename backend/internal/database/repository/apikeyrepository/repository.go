@@ -0,0 +1,77 @@
+package apikeyrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+const apiKeysTableName = "api_keys"
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, key *model.APIKey) error {
+	err := tx.DB().WithContext(ctx).Table(apiKeysTableName).Create(key).Error
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) Get(ctx context.Context, tx txmanager.Tx, id int) (*model.APIKey, error) {
+	var key *model.APIKey
+	err := tx.DB().WithContext(ctx).Table(apiKeysTableName).Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key with id %v: %w", id, err)
+	}
+
+	return key, nil
+}
+
+func (r *GormRepository) GetByHash(ctx context.Context, tx txmanager.Tx, keyHash string) (*model.APIKey, error) {
+	var key *model.APIKey
+	err := tx.DB().WithContext(ctx).Table(apiKeysTableName).Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key by hash: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.APIKey, error) {
+	var keys []*model.APIKey
+	err := tx.DB().WithContext(ctx).Table(apiKeysTableName).Order("id").Find(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke marks key revoked, already revoked key keeps its original revocation time
+func (r *GormRepository) Revoke(ctx context.Context, tx txmanager.Tx, id int, revokedAt time.Time) error {
+	err := tx.DB().WithContext(ctx).
+		Table(apiKeysTableName).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key %v: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) SetLastUsed(ctx context.Context, tx txmanager.Tx, id int, usedAt time.Time) error {
+	err := tx.DB().WithContext(ctx).
+		Table(apiKeysTableName).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to set last used for api key %v: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package auditrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, entry *model.AuditEntry) error
+	List(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.AuditEntry, *model.Page, error)
+}
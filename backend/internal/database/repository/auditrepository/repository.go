@@ -0,0 +1,52 @@
+package auditrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const auditLogTableName = "audit_log"
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, entry *model.AuditEntry) error {
+	err := tx.DB().WithContext(ctx).Table(auditLogTableName).Create(entry).Error
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// List lists page of audit entries newest first
+func (r *GormRepository) List(
+	ctx context.Context,
+	tx txmanager.Tx,
+	page model.PageRequest,
+) ([]*model.AuditEntry, *model.Page, error) {
+	var entries []*model.AuditEntry
+	var count int64
+
+	err := tx.DB().WithContext(ctx).Table(auditLogTableName).Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	order := keyset.Order{Column: "created_at", IDColumn: "id", Direction: model.DESC}
+	db, err := keyset.Apply(tx.DB().WithContext(ctx).Table(auditLogTableName), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&entries).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries, res := keyset.Trim(entries, order, page, int(count), func(e *model.AuditEntry) (string, int) {
+		return keyset.Time(e.CreatedAt), e.ID
+	})
+
+	return entries, res, nil
+}
@@ -0,0 +1,17 @@
+package backuprepository
+
+import (
+	"context"
+	"encoding/json"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	SchemaVersion(ctx context.Context, tx txmanager.Tx) (string, error)
+	StreamRows(ctx context.Context, tx txmanager.Tx, table model.BackupTable, fn func(row json.RawMessage) error) error
+	Count(ctx context.Context, tx txmanager.Tx, table model.BackupTable) (int64, error)
+	DeleteAll(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error
+	InsertRows(ctx context.Context, tx txmanager.Tx, table model.BackupTable, rows []json.RawMessage) error
+	ResetSequence(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error
+}
@@ -0,0 +1,151 @@
+// Package backuprepository copies whole tables as json rows, rows are read with row_to_json and written back
+// with jsonb_populate_recordset so that archives don't depend on go models being in sync with columns
+package backuprepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+// migrationsTableName is where sql-migrate records migrations applied by bootstrap, ids are migration file names
+const migrationsTableName = "gorp_migrations"
+
+// keyColumns are primary keys of tables not keyed by serial id, rows are streamed in key order
+var keyColumns = map[model.BackupTable]string{
+	model.BackupTableTrendingMapsets: "period_days, mapset_id",
+	model.BackupTableOsuAccounts:     "user_id",
+}
+
+func keyColumn(table model.BackupTable) string {
+	if column, ok := keyColumns[table]; ok {
+		return column
+	}
+
+	return "id"
+}
+
+// SchemaVersion is name of the latest applied migration, empty when none is applied
+func (r *GormRepository) SchemaVersion(ctx context.Context, tx txmanager.Tx) (string, error) {
+	var versions []string
+	err := tx.DB().WithContext(ctx).Table(migrationsTableName).Order("id desc").Limit(1).Pluck("id", &versions).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return "", nil
+	}
+
+	return versions[0], nil
+}
+
+// StreamRows calls fn with every row of table as json object ordered by primary key, rows are scanned one at a time
+func (r *GormRepository) StreamRows(
+	ctx context.Context,
+	tx txmanager.Tx,
+	table model.BackupTable,
+	fn func(row json.RawMessage) error,
+) error {
+	if !table.IsValid() {
+		return fmt.Errorf("unknown backup table %q", table)
+	}
+
+	rows, err := tx.DB().WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t ORDER BY %s", table, keyColumn(table))).
+		Rows()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) Count(ctx context.Context, tx txmanager.Tx, table model.BackupTable) (int64, error) {
+	if !table.IsValid() {
+		return 0, fmt.Errorf("unknown backup table %q", table)
+	}
+
+	var count int64
+	err := tx.DB().WithContext(ctx).Table(string(table)).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", table, err)
+	}
+
+	return count, nil
+}
+
+func (r *GormRepository) DeleteAll(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error {
+	if !table.IsValid() {
+		return fmt.Errorf("unknown backup table %q", table)
+	}
+
+	err := tx.DB().WithContext(ctx).Exec(fmt.Sprintf("DELETE FROM %s", table)).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// InsertRows inserts json objects as rows of table, keys are column names and missing keys are left null
+func (r *GormRepository) InsertRows(ctx context.Context, tx txmanager.Tx, table model.BackupTable, rows []json.RawMessage) error {
+	if !table.IsValid() {
+		return fmt.Errorf("unknown backup table %q", table)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	batch, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s rows: %w", table, err)
+	}
+
+	err = tx.DB().WithContext(ctx).
+		Exec(fmt.Sprintf("INSERT INTO %[1]s SELECT * FROM jsonb_populate_recordset(NULL::%[1]s, ?::jsonb)", table), string(batch)).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to insert %s rows: %w", table, err)
+	}
+
+	return nil
+}
+
+// ResetSequence moves id sequence of table past restored ids, tables without serial id are left as they are
+func (r *GormRepository) ResetSequence(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error {
+	if !table.IsValid() {
+		return fmt.Errorf("unknown backup table %q", table)
+	}
+	if keyColumn(table) != "id" {
+		return nil
+	}
+
+	// setval is strict, so it does nothing when there is no sequence or no rows
+	err := tx.DB().WithContext(ctx).
+		Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), max(id)) FROM %[1]s", table)).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to reset %s id sequence: %w", table, err)
+	}
+
+	return nil
+}
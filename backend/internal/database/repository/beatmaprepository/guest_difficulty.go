@@ -0,0 +1,46 @@
+package beatmaprepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const guestDifficultiesViewName = "guest_difficulties"
+
+// ListGuestDifficultiesByMapper lists difficulties user mapped on other hosts' mapsets
+func (r *GormRepository) ListGuestDifficultiesByMapper(
+	ctx context.Context,
+	tx txmanager.Tx,
+	mapperID int,
+) ([]*model.GuestDifficulty, error) {
+	var gds []*model.GuestDifficulty
+	err := tx.DB().WithContext(ctx).Table(guestDifficultiesViewName).
+		Where("mapper_id = ?", mapperID).
+		Order("mapset_id DESC, beatmap_id").
+		Find(&gds).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest difficulties by mapper %v: %w", mapperID, err)
+	}
+
+	return gds, nil
+}
+
+// ListGuestDifficultiesForHost lists difficulties other users mapped on user's mapsets
+func (r *GormRepository) ListGuestDifficultiesForHost(
+	ctx context.Context,
+	tx txmanager.Tx,
+	hostID int,
+) ([]*model.GuestDifficulty, error) {
+	var gds []*model.GuestDifficulty
+	err := tx.DB().WithContext(ctx).Table(guestDifficultiesViewName).
+		Where("host_id = ?", hostID).
+		Order("mapset_id DESC, beatmap_id").
+		Find(&gds).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest difficulties for host %v: %w", hostID, err)
+	}
+
+	return gds, nil
+}
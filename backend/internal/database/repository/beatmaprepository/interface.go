@@ -13,4 +13,8 @@ type Interface interface {
 	ListForMapset(ctx context.Context, tx txmanager.Tx, mapsetID int) ([]*model.Beatmap, error)
 	ListForMapsets(ctx context.Context, tx txmanager.Tx, mapsetIDs ...int) ([]*model.Beatmap, error)
 	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+	ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error)
+	Upsert(ctx context.Context, tx txmanager.Tx, beatmaps ...*model.Beatmap) error
+	ListGuestDifficultiesByMapper(ctx context.Context, tx txmanager.Tx, mapperID int) ([]*model.GuestDifficulty, error)
+	ListGuestDifficultiesForHost(ctx context.Context, tx txmanager.Tx, hostID int) ([]*model.GuestDifficulty, error)
 }
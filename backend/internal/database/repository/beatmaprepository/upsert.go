@@ -0,0 +1,69 @@
+package beatmaprepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const upsertBatchSize = 500
+
+// columns overwritten on conflict, created_at is kept and beatmap_stats is merged separately
+var upsertColumns = []string{
+	"mapset_id",
+	"difficulty_rating",
+	"version",
+	"accuracy",
+	"ar",
+	"bpm",
+	"cs",
+	"status",
+	"url",
+	"total_length",
+	"user_id",
+	"last_updated",
+	"last_playcount",
+	"last_passcount",
+	"updated_at",
+}
+
+func (r *GormRepository) ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error) {
+	existing := make([]int, 0, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	err := tx.DB().WithContext(ctx).Table(beatmapsTableName).Where("id IN (?)", ids).Pluck("id", &existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing beatmap ids: %w", err)
+	}
+
+	return existing, nil
+}
+
+// Upsert creates beatmaps or updates existing ones, new stats entries are appended to existing history
+func (r *GormRepository) Upsert(ctx context.Context, tx txmanager.Tx, beatmaps ...*model.Beatmap) error {
+	if len(beatmaps) == 0 {
+		return nil
+	}
+
+	assignments := append(clause.AssignmentColumns(upsertColumns), clause.Assignment{
+		Column: clause.Column{Name: "beatmap_stats"},
+		Value:  gorm.Expr("COALESCE(" + beatmapsTableName + ".beatmap_stats, '{}'::jsonb) || excluded.beatmap_stats"),
+	})
+
+	err := tx.DB().WithContext(ctx).Table(beatmapsTableName).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: assignments,
+		}).
+		CreateInBatches(beatmaps, upsertBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert beatmaps: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package digestrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, subscription *model.DigestSubscription) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.DigestSubscription, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.DigestSubscription, error)
+	SetLastSent(ctx context.Context, tx txmanager.Tx, id int, sentAt time.Time) error
+}
@@ -0,0 +1,62 @@
+package digestrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+const digestSubscriptionsTableName = "digest_subscriptions"
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, subscription *model.DigestSubscription) error {
+	err := tx.DB().WithContext(ctx).Table(digestSubscriptionsTableName).Create(subscription).Error
+	if err != nil {
+		return fmt.Errorf("failed to create digest subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) Get(ctx context.Context, tx txmanager.Tx, id int) (*model.DigestSubscription, error) {
+	var subscription *model.DigestSubscription
+	err := tx.DB().WithContext(ctx).Table(digestSubscriptionsTableName).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscription with id %v: %w", id, err)
+	}
+
+	return subscription, nil
+}
+
+func (r *GormRepository) Delete(ctx context.Context, tx txmanager.Tx, id int) error {
+	err := tx.DB().WithContext(ctx).Table(digestSubscriptionsTableName).Where("id = ?", id).Delete(&model.DigestSubscription{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete digest subscription with id %v: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.DigestSubscription, error) {
+	var subscriptions []*model.DigestSubscription
+	err := tx.DB().WithContext(ctx).Table(digestSubscriptionsTableName).Order("id").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *GormRepository) SetLastSent(ctx context.Context, tx txmanager.Tx, id int, sentAt time.Time) error {
+	err := tx.DB().WithContext(ctx).
+		Table(digestSubscriptionsTableName).
+		Where("id = ?", id).
+		Update("last_sent_at", sentAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to set last sent for digest subscription %v: %w", id, err)
+	}
+
+	return nil
+}
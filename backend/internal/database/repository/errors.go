@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode is postgres SQLSTATE of unique constraint violation
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether insert or update failed on a unique constraint
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,17 @@
+package exportrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	StreamStats(
+		ctx context.Context,
+		tx txmanager.Tx,
+		entity model.StatEntity,
+		filter model.StatPointFilter,
+		fn func(point *model.StatPoint) error,
+	) error
+}
@@ -0,0 +1,88 @@
+// Package exportrepository reads stats history of tracked entities row by row,
+// jsonb history is unnested in database so that exports never hold whole tables in memory
+package exportrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+// statsSources maps every entity to its table and stats history column
+var statsSources = map[model.StatEntity]struct {
+	table  string
+	column string
+}{
+	model.StatEntityUser:    {table: "users", column: "user_stats"},
+	model.StatEntityMapset:  {table: "mapsets", column: "mapset_stats"},
+	model.StatEntityBeatmap: {table: "beatmaps", column: "beatmap_stats"},
+}
+
+// StreamStats calls fn for every metric of every stats entry of entity ordered by id, timestamp and metric,
+// rows are scanned one at a time, fn error stops streaming and is returned as is
+func (r *GormRepository) StreamStats(
+	ctx context.Context,
+	tx txmanager.Tx,
+	entity model.StatEntity,
+	filter model.StatPointFilter,
+	fn func(point *model.StatPoint) error,
+) error {
+	query, args, err := statsQuery(entity, filter)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.DB().WithContext(ctx).Raw(query, args...).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to stream %s stats: %w", entity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		point := &model.StatPoint{Entity: entity}
+		if err := rows.Scan(&point.ID, &point.Timestamp, &point.Metric, &point.Value); err != nil {
+			return fmt.Errorf("failed to scan %s stats: %w", entity, err)
+		}
+		point.Timestamp = point.Timestamp.UTC()
+
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to stream %s stats: %w", entity, err)
+	}
+
+	return nil
+}
+
+// statsQuery unnests stats history, entries are keyed by timestamp and hold metrics keyed by name
+func statsQuery(entity model.StatEntity, filter model.StatPointFilter) (string, []interface{}, error) {
+	source, ok := statsSources[entity]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown stats entity %q", entity)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT t.id, e.key::timestamptz AS ts, m.key AS metric, (m.value)::bigint AS value "+
+			"FROM %s t, jsonb_each(t.%s) e, jsonb_each(e.value) m "+
+			"WHERE jsonb_typeof(m.value) = 'number'",
+		source.table, source.column,
+	)
+
+	var args []interface{}
+	if !filter.From.IsZero() {
+		query += " AND e.key::timestamptz >= ?"
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		query += " AND e.key::timestamptz <= ?"
+		args = append(args, filter.To)
+	}
+
+	query += " ORDER BY t.id, ts, metric"
+
+	return query, args, nil
+}
@@ -0,0 +1,29 @@
+package exportrepository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+func Test_statsQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	query, args, err := statsQuery(model.StatEntityBeatmap, model.StatPointFilter{From: from, To: to})
+	require.NoError(t, err)
+	assert.Contains(t, query, "FROM beatmaps t, jsonb_each(t.beatmap_stats) e")
+	assert.Contains(t, query, "e.key::timestamptz >= ? AND e.key::timestamptz <= ?")
+	assert.Equal(t, []interface{}{from, to}, args)
+
+	query, args, err = statsQuery(model.StatEntityUser, model.StatPointFilter{})
+	require.NoError(t, err)
+	assert.Contains(t, query, "FROM users t, jsonb_each(t.user_stats) e")
+	assert.NotContains(t, query, "?")
+	assert.Empty(t, args)
+
+	_, _, err = statsQuery("clean", model.StatPointFilter{})
+	assert.Error(t, err)
+}
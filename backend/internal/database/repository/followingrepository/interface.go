@@ -9,7 +9,9 @@ import (
 
 type Interface interface {
 	Create(ctx context.Context, tx txmanager.Tx, user *model.Following) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Following, error)
 	List(ctx context.Context, tx txmanager.Tx) ([]*model.Following, error)
+	ListPage(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.Following, *model.Page, error)
 	SetLastFetchedForUser(ctx context.Context, tx txmanager.Tx, username string, lastFetched time.Time) error
 	Delete(ctx context.Context, tx txmanager.Tx, id int) error
 }
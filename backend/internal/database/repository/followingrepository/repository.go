@@ -3,6 +3,7 @@ package followingrepository
 import (
 	"context"
 	"fmt"
+	"playcount-monitor-backend/internal/database/repository/keyset"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"time"
@@ -19,6 +20,16 @@ func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, follow *mo
 	return nil
 }
 
+func (r *GormRepository) Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Following, error) {
+	var follow *model.Following
+	err := tx.DB().WithContext(ctx).Table(followingTableName).Where("id = ?", id).First(&follow).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow with id %v: %w", id, err)
+	}
+
+	return follow, nil
+}
+
 func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.Following, error) {
 	var follows []*model.Following
 	err := tx.DB().WithContext(ctx).Table(followingTableName).Find(&follows).Error
@@ -29,6 +40,34 @@ func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.Fo
 	return follows, nil
 }
 
+// ListPage lists page of follows by username
+func (r *GormRepository) ListPage(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.Following, *model.Page, error) {
+	var follows []*model.Following
+	var count int64
+
+	err := tx.DB().WithContext(ctx).Table(followingTableName).Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count follows: %w", err)
+	}
+
+	order := keyset.Order{Column: "username", IDColumn: "id", Direction: model.ASC}
+	db, err := keyset.Apply(tx.DB().WithContext(ctx).Table(followingTableName), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&follows).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+
+	follows, res := keyset.Trim(follows, order, page, int(count), func(f *model.Following) (string, int) {
+		return f.Username, f.ID
+	})
+
+	return follows, res, nil
+}
+
 func (r *GormRepository) Delete(ctx context.Context, tx txmanager.Tx, id int) error {
 	err := tx.DB().WithContext(ctx).Table(followingTableName).Where("id = ?", id).Delete(&model.Following{}).Error
 	if err != nil {
@@ -0,0 +1,144 @@
+// Package keyset pages lists by position of the last seen row instead of offset, so pages stay
+// cheap deep into list and don't shift when rows are added in front of them
+package keyset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrOrderMismatch is returned when cursor was made for another list order
+var ErrOrderMismatch = errors.New("cursor belongs to another list order")
+
+// Order is list order, rows are ordered by Column and then by unique IDColumn in the same direction
+type Order struct {
+	// Column is sort column or expression, its values must not be null
+	Column    string
+	IDColumn  string
+	Direction model.SortDirection
+	// Args are bound to placeholders in Column
+	Args []interface{}
+}
+
+// Key identifies order in cursors, args are hashed in so that cursor of one search isn't taken for another
+func (o Order) Key() string {
+	key := o.Column + " " + string(o.Direction)
+	if len(o.Args) == 0 {
+		return key
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v", o.Args)))
+
+	return key + " " + hex.EncodeToString(sum[:8])
+}
+
+// Apply orders query and narrows it to page, one extra row is fetched to tell whether there are more
+func Apply(db *gorm.DB, order Order, page model.PageRequest) (*gorm.DB, error) {
+	if page.Cursor != nil && page.Cursor.Order != order.Key() {
+		return nil, ErrOrderMismatch
+	}
+
+	direction := order.Direction
+	if page.Cursor != nil {
+		query, values := Condition(order, page.Cursor)
+		db = db.Where(query, values...)
+		if page.Cursor.Backward {
+			direction = reverse(direction)
+		}
+	} else if page.Offset > 0 {
+		db = db.Offset(page.Offset)
+	}
+
+	return db.
+		Order(clause.OrderBy{Expression: gorm.Expr(
+			fmt.Sprintf("%s %s, %s %s", order.Column, direction, order.IDColumn, direction), order.Args...,
+		)}).
+		Limit(page.Limit + 1), nil
+}
+
+// Condition keeps rows after cursor in list order, or before it for backward cursor
+func Condition(order Order, cursor *model.Cursor) (string, []interface{}) {
+	comparison := "<"
+	if order.Direction == model.ASC {
+		comparison = ">"
+	}
+	if cursor.Backward {
+		comparison = map[string]string{"<": ">", ">": "<"}[comparison]
+	}
+
+	values := append(slices.Clone(order.Args), cursor.Value, cursor.ID)
+
+	return fmt.Sprintf("(%s, %s) %s (?, ?)", order.Column, order.IDColumn, comparison), values
+}
+
+// Trim drops extra row fetched by Apply, puts rows back in list order and makes cursors
+// of neighbouring pages from the first and the last row, key returns sort value and id of row
+func Trim[T any](
+	rows []T,
+	order Order,
+	page model.PageRequest,
+	total int,
+	key func(T) (string, int),
+) ([]T, *model.Page) {
+	more := len(rows) > page.Limit
+	if more {
+		rows = rows[:page.Limit]
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	res := &model.Page{Total: total}
+	if len(rows) == 0 {
+		return rows, res
+	}
+
+	hasNext, hasPrev := more, page.Cursor != nil || page.Offset > 0
+	if backward {
+		// backward cursor is made from the first row of a page, so there is always one after
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		value, id := key(rows[len(rows)-1])
+		res.Next = &model.Cursor{Order: order.Key(), Value: value, ID: id}
+	}
+	if hasPrev {
+		value, id := key(rows[0])
+		res.Prev = &model.Cursor{Order: order.Key(), Value: value, ID: id, Backward: true}
+	}
+
+	return rows, res
+}
+
+func Int(v int) string {
+	return strconv.Itoa(v)
+}
+
+// Float formats value so that it is parsed back by postgres exactly, bitSize is 32 for real columns
+func Float(v float64, bitSize int) string {
+	return strconv.FormatFloat(v, 'g', -1, bitSize)
+}
+
+// Time keeps microseconds postgres stores timestamps with
+func Time(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func reverse(direction model.SortDirection) model.SortDirection {
+	if direction == model.ASC {
+		return model.DESC
+	}
+
+	return model.ASC
+}
@@ -0,0 +1,154 @@
+package keyset
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+type row struct {
+	playcount int
+	id        int
+}
+
+func rowKey(r *row) (string, int) {
+	return Int(r.playcount), r.id
+}
+
+func TestCondition(t *testing.T) {
+	desc := Order{Column: "last_playcount", IDColumn: "id", Direction: model.DESC}
+	asc := Order{Column: "created_at", IDColumn: "id", Direction: model.ASC}
+	rank := Order{Column: "ts_rank_cd(search_vector, to_tsquery('simple', ?))", IDColumn: "id", Direction: model.DESC, Args: []interface{}{"camellia:*"}}
+
+	tt := []struct {
+		name           string
+		order          Order
+		cursor         *model.Cursor
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			name:           "Forward descending",
+			order:          desc,
+			cursor:         &model.Cursor{Value: "100", ID: 7},
+			expectedQuery:  "(last_playcount, id) < (?, ?)",
+			expectedValues: []interface{}{"100", 7},
+		},
+		{
+			name:           "Backward descending",
+			order:          desc,
+			cursor:         &model.Cursor{Value: "100", ID: 7, Backward: true},
+			expectedQuery:  "(last_playcount, id) > (?, ?)",
+			expectedValues: []interface{}{"100", 7},
+		},
+		{
+			name:           "Forward ascending",
+			order:          asc,
+			cursor:         &model.Cursor{Value: "2026-01-02T00:00:00Z", ID: 3},
+			expectedQuery:  "(created_at, id) > (?, ?)",
+			expectedValues: []interface{}{"2026-01-02T00:00:00Z", 3},
+		},
+		{
+			name:           "Expression args go first",
+			order:          rank,
+			cursor:         &model.Cursor{Value: "0.1", ID: 1},
+			expectedQuery:  "(ts_rank_cd(search_vector, to_tsquery('simple', ?)), id) < (?, ?)",
+			expectedValues: []interface{}{"camellia:*", "0.1", 1},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			query, values := Condition(tc.order, tc.cursor)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+
+	// args of order are not modified
+	assert.Equal(t, []interface{}{"camellia:*"}, rank.Args)
+}
+
+func TestTrim(t *testing.T) {
+	order := Order{Column: "last_playcount", IDColumn: "id", Direction: model.DESC}
+	key := order.Key()
+
+	t.Run("First page with more rows", func(t *testing.T) {
+		rows := []*row{{30, 1}, {20, 2}, {10, 3}}
+		res, page := Trim(rows, order, model.PageRequest{Limit: 2}, 5, rowKey)
+
+		assert.Equal(t, []*row{{30, 1}, {20, 2}}, res)
+		assert.Equal(t, &model.Page{
+			Total: 5,
+			Next:  &model.Cursor{Order: key, Value: "20", ID: 2},
+		}, page)
+	})
+
+	t.Run("Last page after cursor", func(t *testing.T) {
+		rows := []*row{{10, 3}}
+		cursor := &model.Cursor{Order: key, Value: "20", ID: 2}
+		res, page := Trim(rows, order, model.PageRequest{Limit: 2, Cursor: cursor}, 3, rowKey)
+
+		assert.Equal(t, []*row{{10, 3}}, res)
+		assert.Equal(t, &model.Page{
+			Total: 3,
+			Prev:  &model.Cursor{Order: key, Value: "10", ID: 3, Backward: true},
+		}, page)
+	})
+
+	t.Run("Backward page is put back in list order", func(t *testing.T) {
+		// fetched in reverse order, nearest to cursor first
+		rows := []*row{{20, 2}, {30, 1}}
+		cursor := &model.Cursor{Order: key, Value: "10", ID: 3, Backward: true}
+		res, page := Trim(rows, order, model.PageRequest{Limit: 2, Cursor: cursor}, 3, rowKey)
+
+		assert.Equal(t, []*row{{30, 1}, {20, 2}}, res)
+		assert.Equal(t, &model.Page{
+			Total: 3,
+			Next:  &model.Cursor{Order: key, Value: "20", ID: 2},
+		}, page)
+	})
+
+	t.Run("Offset page has previous one", func(t *testing.T) {
+		rows := []*row{{10, 3}}
+		_, page := Trim(rows, order, model.PageRequest{Limit: 2, Offset: 2}, 3, rowKey)
+
+		assert.Equal(t, &model.Page{
+			Total: 3,
+			Prev:  &model.Cursor{Order: key, Value: "10", ID: 3, Backward: true},
+		}, page)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		res, page := Trim([]*row{}, order, model.PageRequest{Limit: 2}, 0, rowKey)
+
+		assert.Empty(t, res)
+		assert.Equal(t, &model.Page{}, page)
+	})
+}
+
+func TestOrder_Key(t *testing.T) {
+	rank := func(q string) Order {
+		return Order{Column: "ts_rank_cd(search_vector, to_tsquery('simple', ?))", IDColumn: "id", Direction: model.DESC, Args: []interface{}{q}}
+	}
+
+	assert.Equal(t, "last_playcount DESC", Order{Column: "last_playcount", IDColumn: "id", Direction: model.DESC}.Key())
+	assert.Equal(t, rank("foo:*").Key(), rank("foo:*").Key())
+	assert.NotEqual(t, rank("foo:*").Key(), rank("bar:*").Key())
+
+	// cursor of one search is rejected by another before query is touched
+	cursor := &model.Cursor{Order: rank("foo:*").Key(), Value: "0.1", ID: 1}
+	_, err := Apply(nil, rank("bar:*"), model.PageRequest{Limit: 10, Cursor: cursor})
+	assert.ErrorIs(t, err, ErrOrderMismatch)
+}
+
+func TestTime(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 30, 0, 123456000, time.FixedZone("", 3*60*60))
+	assert.Equal(t, "2026-10-19T09:30:00.123456Z", Time(at))
+}
+
+func TestFloat(t *testing.T) {
+	assert.Equal(t, "0.1", Float(float64(float32(0.1)), 32))
+	assert.Equal(t, "1.5", Float(1.5, 64))
+}
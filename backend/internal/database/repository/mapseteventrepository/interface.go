@@ -0,0 +1,20 @@
+package mapseteventrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, events ...*model.MapsetEvent) error
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MapsetEventFilter,
+		limit int,
+		offset int,
+	) ([]*model.MapsetEvent, int, error)
+	ListForUsersSince(ctx context.Context, tx txmanager.Tx, userIDs []int, since time.Time) ([]*model.MapsetEvent, error)
+}
@@ -0,0 +1,112 @@
+package mapseteventrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"sort"
+	"strings"
+	"time"
+)
+
+const mapsetEventsTableName = "mapset_events"
+
+const createBatchSize = 500
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, events ...*model.MapsetEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	err := tx.DB().WithContext(ctx).Table(mapsetEventsTableName).
+		CreateInBatches(events, createBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to create mapset events: %w", err)
+	}
+
+	return nil
+}
+
+// List lists mapset events newest first
+func (r *GormRepository) List(
+	ctx context.Context,
+	tx txmanager.Tx,
+	filter model.MapsetEventFilter,
+	limit int,
+	offset int,
+) ([]*model.MapsetEvent, int, error) {
+	var events []*model.MapsetEvent
+	var count int64
+
+	query, values := buildListByFilterQuery(filter)
+	filterGormExpr := gorm.Expr(query, values...)
+	if query == "" {
+		filterGormExpr = gorm.Expr("1 = 1")
+	}
+
+	err := tx.DB().WithContext(ctx).
+		Table(mapsetEventsTableName).
+		Where(filterGormExpr).
+		Count(&count).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count mapset events: %w", err)
+	}
+
+	err = tx.DB().WithContext(ctx).
+		Table(mapsetEventsTableName).
+		Where(filterGormExpr).
+		Order("occurred_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list mapset events: %w", err)
+	}
+
+	return events, int(count), nil
+}
+
+// ListForUsersSince lists mapset events of users that occurred after since oldest first,
+// events of every user are listed when userIDs is empty
+func (r *GormRepository) ListForUsersSince(
+	ctx context.Context,
+	tx txmanager.Tx,
+	userIDs []int,
+	since time.Time,
+) ([]*model.MapsetEvent, error) {
+	var events []*model.MapsetEvent
+
+	query := tx.DB().WithContext(ctx).
+		Table(mapsetEventsTableName).
+		Where("occurred_at > ?", since)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN (?)", userIDs)
+	}
+
+	err := query.Order("occurred_at, id").Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mapset events since %v: %w", since, err)
+	}
+
+	return events, nil
+}
+
+func buildListByFilterQuery(filter model.MapsetEventFilter) (string, []interface{}) {
+	keys := make([]string, 0, len(filter))
+	for column := range filter {
+		keys = append(keys, string(column))
+	}
+
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	values := make([]interface{}, 0, len(keys))
+	for _, column := range keys {
+		conditions = append(conditions, column+" = ?")
+		values = append(values, filter[model.MapsetEventFilterField(column)])
+	}
+
+	return strings.Join(conditions, " AND "), values
+}
@@ -0,0 +1,12 @@
+package mapsetlistingrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Attach(ctx context.Context, tx txmanager.Tx, stats model.ListingStats, mapsets ...*model.Mapset) ([]*model.MapsetListing, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, stats model.ListingStats, ids ...int) ([]*model.MapsetListing, error)
+}
@@ -0,0 +1,110 @@
+// Package mapsetlistingrepository is read side of mapset lists, it reads beatmaps of a whole page
+// of mapsets at once instead of one query per mapset
+package mapsetlistingrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const (
+	mapsetsTableName  = "mapsets"
+	beatmapsTableName = "beatmaps"
+)
+
+// Attach reads beatmaps of all mapsets with one query, listings keep order of mapsets.
+// With latest stats beatmaps are read without stats history and history of mapsets is dropped
+func (r *GormRepository) Attach(
+	ctx context.Context,
+	tx txmanager.Tx,
+	stats model.ListingStats,
+	mapsets ...*model.Mapset,
+) ([]*model.MapsetListing, error) {
+	if len(mapsets) == 0 {
+		return []*model.MapsetListing{}, nil
+	}
+
+	ids := make([]int, len(mapsets))
+	for i, m := range mapsets {
+		ids[i] = m.ID
+		if stats == model.ListingStatsLatest {
+			m.MapsetStats = nil
+		}
+	}
+
+	var beatmaps []*model.Beatmap
+	err := withStats(tx.DB().WithContext(ctx).Table(beatmapsTableName), stats, "beatmap_stats").
+		Where("mapset_id IN (?)", ids).
+		Order("mapset_id, id").
+		Find(&beatmaps).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list beatmaps for mapsets %v: %w", ids, err)
+	}
+
+	return group(mapsets, beatmaps), nil
+}
+
+// ListByIDs reads mapsets with given ids along with their beatmaps in two queries, listings keep order of ids
+// and missing mapsets are skipped
+func (r *GormRepository) ListByIDs(
+	ctx context.Context,
+	tx txmanager.Tx,
+	stats model.ListingStats,
+	ids ...int,
+) ([]*model.MapsetListing, error) {
+	if len(ids) == 0 {
+		return []*model.MapsetListing{}, nil
+	}
+
+	var mapsets []*model.Mapset
+	err := withStats(tx.DB().WithContext(ctx).Table(mapsetsTableName), stats, "mapset_stats").
+		Where("id IN (?)", ids).
+		Find(&mapsets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mapsets %v: %w", ids, err)
+	}
+
+	byID := make(map[int]*model.Mapset, len(mapsets))
+	for _, m := range mapsets {
+		byID[m.ID] = m
+	}
+
+	ordered := make([]*model.Mapset, 0, len(mapsets))
+	for _, id := range ids {
+		if m, ok := byID[id]; ok {
+			ordered = append(ordered, m)
+		}
+	}
+
+	return r.Attach(ctx, tx, stats, ordered...)
+}
+
+// withStats leaves jsonb stats history column out of query when only latest stats are read
+func withStats(db *gorm.DB, stats model.ListingStats, historyColumn string) *gorm.DB {
+	if stats == model.ListingStatsLatest {
+		return db.Omit(historyColumn)
+	}
+
+	return db
+}
+
+// group attaches beatmaps to their mapsets, mapsets without beatmaps get an empty list
+func group(mapsets []*model.Mapset, beatmaps []*model.Beatmap) []*model.MapsetListing {
+	byMapset := make(map[int][]*model.Beatmap, len(mapsets))
+	for _, b := range beatmaps {
+		byMapset[b.MapsetID] = append(byMapset[b.MapsetID], b)
+	}
+
+	res := make([]*model.MapsetListing, len(mapsets))
+	for i, m := range mapsets {
+		res[i] = &model.MapsetListing{Mapset: m, Beatmaps: byMapset[m.ID]}
+		if res[i].Beatmaps == nil {
+			res[i].Beatmaps = []*model.Beatmap{}
+		}
+	}
+
+	return res
+}
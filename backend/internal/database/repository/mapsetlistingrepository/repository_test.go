@@ -0,0 +1,24 @@
+package mapsetlistingrepository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+)
+
+func Test_group(t *testing.T) {
+	first, second, empty := &model.Mapset{ID: 2}, &model.Mapset{ID: 1}, &model.Mapset{ID: 3}
+	beatmaps := []*model.Beatmap{
+		{ID: 10, MapsetID: 1},
+		{ID: 11, MapsetID: 1},
+		{ID: 20, MapsetID: 2},
+	}
+
+	listings := group([]*model.Mapset{first, second, empty}, beatmaps)
+
+	assert.Equal(t, []*model.MapsetListing{
+		{Mapset: first, Beatmaps: []*model.Beatmap{beatmaps[2]}},
+		{Mapset: second, Beatmaps: []*model.Beatmap{beatmaps[0], beatmaps[1]}},
+		{Mapset: empty, Beatmaps: []*model.Beatmap{}},
+	}, listings)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
 )
 
 type Interface interface {
@@ -11,25 +12,52 @@ type Interface interface {
 	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Mapset, error)
 	Update(ctx context.Context, tx txmanager.Tx, mapset *model.Mapset) error
 	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+	ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error)
+	Upsert(ctx context.Context, tx txmanager.Tx, mapsets ...*model.Mapset) error
+	MarkRemoved(ctx context.Context, tx txmanager.Tx, status model.MapsetRemovalStatus, removedAt time.Time, ids ...int) error
 	List(ctx context.Context, tx txmanager.Tx) ([]*model.Mapset, error)
 	ListForUser(ctx context.Context, tx txmanager.Tx, userID int) ([]*model.Mapset, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error)
 	ListForUserWithLimitOffset(ctx context.Context, tx txmanager.Tx, userID int, limit int, offset int) ([]*model.Mapset, error)
 	ListStatusesForUser(ctx context.Context, tx txmanager.Tx, userID int) ([]string, error)
-	ListWithFilterSortLimitOffset(
+	ListWithFilterSort(
 		ctx context.Context,
 		tx txmanager.Tx,
 		filter model.MapsetFilter,
 		sort model.MapsetSort,
-		limit int,
-		offset int,
-	) ([]*model.Mapset, int, error)
-	ListForUserWithFilterSortLimitOffset(
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	ListForUserWithFilterSort(
 		ctx context.Context,
 		tx txmanager.Tx,
 		userID int,
 		filter model.MapsetFilter,
 		sort model.MapsetSort,
-		limit int,
-		offset int,
-	) ([]*model.Mapset, int, error)
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	ListGuestForUserWithFilterSort(
+		ctx context.Context,
+		tx txmanager.Tx,
+		userID int,
+		filter model.MapsetFilter,
+		sort model.MapsetSort,
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	Search(
+		ctx context.Context,
+		tx txmanager.Tx,
+		search *model.MapsetSearch,
+		sort model.MapsetSort,
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	SearchFacets(ctx context.Context, tx txmanager.Tx, search *model.MapsetSearch) (model.MapsetFacets, error)
+	ReplaceTrending(ctx context.Context, tx txmanager.Tx, trending ...*model.TrendingMapset) error
+	ListTrending(
+		ctx context.Context,
+		tx txmanager.Tx,
+		periodDays int,
+		filter model.MapsetFilter,
+		sort model.TrendingSortField,
+		page model.PageRequest,
+	) ([]*model.TrendingMapset, *model.Page, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/keyset"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"sort"
@@ -70,6 +71,16 @@ func (r *GormRepository) ListForUser(ctx context.Context, tx txmanager.Tx, userI
 	return mapsets, nil
 }
 
+func (r *GormRepository) ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error) {
+	var mapsets []*model.Mapset
+	err := tx.DB().WithContext(ctx).Table(mapsetsTableName).Where("id IN (?)", ids).Order("id DESC").Find(&mapsets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mapsets %v: %w", ids, err)
+	}
+
+	return mapsets, nil
+}
+
 func (r *GormRepository) ListStatusesForUser(
 	ctx context.Context,
 	tx txmanager.Tx,
@@ -109,61 +120,55 @@ func (r *GormRepository) ListForUserWithLimitOffset(
 	return mapsets, nil
 }
 
-func (r *GormRepository) ListWithFilterSortLimitOffset(
+// ListWithFilterSort lists page of mapsets matching filter in sort order, newest first by default
+func (r *GormRepository) ListWithFilterSort(
 	ctx context.Context,
 	tx txmanager.Tx,
 	filter model.MapsetFilter,
 	sort model.MapsetSort,
-	limit int,
-	offset int,
-) ([]*model.Mapset, int, error) {
-	var mapsets []*model.Mapset
-	var count int64
-
-	query, values := buildListByFilterQuery(filter)
-	filterGormExpr := gorm.Expr(query, values...)
-	if query == "" {
-		filterGormExpr = gorm.Expr("1 = 1")
-	}
-
-	order := buildOrderBySortQuery(sort)
-	if len(strings.TrimSpace(order)) == 0 {
-		order = "created_at DESC"
-	}
-
-	err := tx.DB().WithContext(ctx).
-		Table(mapsetsTableName).
-		Where(filterGormExpr).
-		Count(&count).Error
-
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count mapsets: %w", err)
-	}
-
-	err = tx.DB().WithContext(ctx).
-		Table(mapsetsTableName).
-		Order(order).
-		Where(filterGormExpr).
-		Limit(limit).
-		Offset(offset).
-		Find(&mapsets).Error
-
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list mapsets: %w", err)
-	}
+	page model.PageRequest,
+) ([]*model.Mapset, *model.Page, error) {
+	return r.listPage(ctx, tx, filter, sort, page, func(db *gorm.DB) *gorm.DB {
+		return db
+	})
+}
 
-	return mapsets, int(count), nil
+// ListForUserWithFilterSort is ListWithFilterSort narrowed to mapsets hosted by user
+func (r *GormRepository) ListForUserWithFilterSort(
+	ctx context.Context,
+	tx txmanager.Tx,
+	userID int,
+	filter model.MapsetFilter,
+	sort model.MapsetSort,
+	page model.PageRequest,
+) ([]*model.Mapset, *model.Page, error) {
+	return r.listPage(ctx, tx, filter, sort, page, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	})
 }
 
-func (r *GormRepository) ListForUserWithFilterSortLimitOffset(
+// ListGuestForUserWithFilterSort is ListWithFilterSort narrowed to other hosts' mapsets user mapped guest difficulties for
+func (r *GormRepository) ListGuestForUserWithFilterSort(
 	ctx context.Context,
 	tx txmanager.Tx,
 	userID int,
 	filter model.MapsetFilter,
 	sort model.MapsetSort,
-	limit int,
-	offset int,
-) ([]*model.Mapset, int, error) {
+	page model.PageRequest,
+) ([]*model.Mapset, *model.Page, error) {
+	return r.listPage(ctx, tx, filter, sort, page, func(db *gorm.DB) *gorm.DB {
+		return db.Where("mapsets.id IN (SELECT mapset_id FROM guest_difficulties WHERE mapper_id = ?)", userID)
+	})
+}
+
+func (r *GormRepository) listPage(
+	ctx context.Context,
+	tx txmanager.Tx,
+	filter model.MapsetFilter,
+	sort model.MapsetSort,
+	page model.PageRequest,
+	scope func(db *gorm.DB) *gorm.DB,
+) ([]*model.Mapset, *model.Page, error) {
 	var mapsets []*model.Mapset
 	var count int64
 
@@ -173,35 +178,27 @@ func (r *GormRepository) ListForUserWithFilterSortLimitOffset(
 		filterGormExpr = gorm.Expr("1 = 1")
 	}
 
-	order := buildOrderBySortQuery(sort)
-	if len(strings.TrimSpace(order)) == 0 {
-		order = "created_at DESC"
-	}
-
-	err := tx.DB().WithContext(ctx).
-		Table(mapsetsTableName).
+	err := scope(tx.DB().WithContext(ctx).Table(mapsetsTableName)).
 		Where(filterGormExpr).
-		Where("user_id = ?", userID).
 		Count(&count).Error
-
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count mapsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to count mapsets: %w", err)
 	}
 
-	err = tx.DB().WithContext(ctx).
-		Table(mapsetsTableName).
-		Order(order).
-		Where(filterGormExpr).
-		Where("user_id = ?", userID).
-		Limit(limit).
-		Offset(offset).
-		Find(&mapsets).Error
+	order := buildSortOrder(sort)
+	db, err := keyset.Apply(scope(tx.DB().WithContext(ctx).Table(mapsetsTableName)).Where(filterGormExpr), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	err = db.Find(&mapsets).Error
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list mapsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to list mapsets: %w", err)
 	}
 
-	return mapsets, int(count), nil
+	mapsets, res := keyset.Trim(mapsets, order, page, int(count), mapsetSortKey(sort.Field))
+
+	return mapsets, res, nil
 }
 
 func buildListByFilterQuery(filter model.MapsetFilter) (string, []interface{}) {
@@ -215,21 +212,16 @@ func buildListByFilterQuery(filter model.MapsetFilter) (string, []interface{}) {
 	sort.Strings(keys)
 
 	for i, column := range keys {
-		if column == string(model.MapsetArtistOrTitleOrTagsFields) {
-			columns := []model.MapsetFilterField{model.MapsetArtistField, model.MapsetTitleField, model.MapsetTagsField}
-
-			// search
-			queryBuilder.WriteString("( ")
-			for i, c := range columns {
-				if i == 0 {
-					queryBuilder.WriteString(string(c) + " ILIKE ?")
-				} else {
-					queryBuilder.WriteString(" OR " + string(c) + " ILIKE ?")
-				}
-				values = append(values, "%"+filter[model.MapsetFilterField(column)].(string)+"%")
-			}
-			queryBuilder.WriteString(" )")
-
+		if column == string(model.MapsetSearchFields) {
+			// full-text search over artist, title, creator, tags and difficulty names
+			queryBuilder.WriteString("mapsets.search_vector @@ to_tsquery('simple', ?)")
+			values = append(values, buildPrefixTSQuery(filter[model.MapsetFilterField(column)].(string)))
+		} else if column == string(model.MapsetWatchlistField) {
+			// columns are qualified since trending joins mapsets
+			queryBuilder.WriteString("( mapsets.id IN (SELECT entity_id FROM watchlist_entries WHERE watchlist_id = ? AND entity_type = ?)" +
+				" OR mapsets.user_id IN (SELECT entity_id FROM watchlist_entries WHERE watchlist_id = ? AND entity_type = ?) )")
+			watchlistID := filter[model.MapsetFilterField(column)]
+			values = append(values, watchlistID, string(model.WatchlistMapset), watchlistID, string(model.WatchlistUser))
 		} else {
 			queryBuilder.WriteString(column + " = ?")
 			values = append(values, filter[model.MapsetFilterField(column)])
@@ -242,6 +234,37 @@ func buildListByFilterQuery(filter model.MapsetFilter) (string, []interface{}) {
 	return queryBuilder.String(), values
 }
 
-func buildOrderBySortQuery(sort model.MapsetSort) string {
-	return fmt.Sprintf("%s %s", string(sort.Field), string(sort.Direction))
+// buildSortOrder orders mapsets by sort field and id, newest first when sort is not set
+func buildSortOrder(sort model.MapsetSort) keyset.Order {
+	order := keyset.Order{
+		Column:    "mapsets." + string(sort.Field),
+		IDColumn:  "mapsets.id",
+		Direction: sort.Direction,
+	}
+	if sort.Field == "" {
+		order.Column = "mapsets." + string(model.MapsetCreatedAt)
+	}
+	if order.Direction != model.ASC {
+		order.Direction = model.DESC
+	}
+
+	return order
+}
+
+// mapsetSortKey reads value of sort field, keyset cursors are made of it
+func mapsetSortKey(field model.MapsetSortField) func(m *model.Mapset) (string, int) {
+	return func(m *model.Mapset) (string, int) {
+		switch field {
+		case model.MapsetPlaycount:
+			return keyset.Int(m.LastPlaycount), m.ID
+		case model.MapsetFavs:
+			return keyset.Int(m.LastFavorites), m.ID
+		case model.MapsetComms:
+			return keyset.Int(m.LastComments), m.ID
+		case model.MapsetPasscount:
+			return keyset.Int(m.LastPasscount), m.ID
+		default:
+			return keyset.Time(m.CreatedAt), m.ID
+		}
+	}
 }
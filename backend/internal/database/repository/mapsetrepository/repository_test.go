@@ -2,6 +2,7 @@ package mapsetrepository
 
 import (
 	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/keyset"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"testing"
 )
@@ -24,21 +25,31 @@ func Test_buildListByFilterQuery(t *testing.T) {
 			expectedValues: []interface{}{"Artist", "Tags", "Title"},
 		},
 		{
-			name: "Artist or Title or Tags",
+			name: "Search",
 			filter: model.MapsetFilter{
-				model.MapsetArtistOrTitleOrTagsFields: "Search",
+				model.MapsetSearchFields: "Search",
 			},
-			expectedQuery:  "( artist ILIKE ? OR title ILIKE ? OR tags ILIKE ? )",
-			expectedValues: []interface{}{"%Search%", "%Search%", "%Search%"},
+			expectedQuery:  "mapsets.search_vector @@ to_tsquery('simple', ?)",
+			expectedValues: []interface{}{"search:*"},
 		},
 		{
-			name: "Artist or Title or Tags and Status",
+			name: "Search and Status",
 			filter: model.MapsetFilter{
-				model.MapsetArtistOrTitleOrTagsFields: "Search",
-				model.MapsetStatusField:               "Status",
+				model.MapsetSearchFields: "Search",
+				model.MapsetStatusField:  "Status",
 			},
-			expectedQuery:  "( artist ILIKE ? OR title ILIKE ? OR tags ILIKE ? ) AND status = ?",
-			expectedValues: []interface{}{"%Search%", "%Search%", "%Search%", "Status"},
+			expectedQuery:  "mapsets.search_vector @@ to_tsquery('simple', ?) AND status = ?",
+			expectedValues: []interface{}{"search:*", "Status"},
+		},
+		{
+			name: "Watchlist and Status",
+			filter: model.MapsetFilter{
+				model.MapsetWatchlistField: 3,
+				model.MapsetStatusField:    "ranked",
+			},
+			expectedQuery: "status = ? AND ( mapsets.id IN (SELECT entity_id FROM watchlist_entries WHERE watchlist_id = ? AND entity_type = ?)" +
+				" OR mapsets.user_id IN (SELECT entity_id FROM watchlist_entries WHERE watchlist_id = ? AND entity_type = ?) )",
+			expectedValues: []interface{}{"ranked", 3, "mapset", 3, "user"},
 		},
 	}
 
@@ -50,3 +61,19 @@ func Test_buildListByFilterQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildSortOrder(t *testing.T) {
+	assert.Equal(t,
+		keyset.Order{Column: "mapsets.created_at", IDColumn: "mapsets.id", Direction: model.DESC},
+		buildSortOrder(model.MapsetSort{}),
+	)
+	assert.Equal(t,
+		keyset.Order{Column: "mapsets.last_favorites", IDColumn: "mapsets.id", Direction: model.ASC},
+		buildSortOrder(model.MapsetSort{Field: model.MapsetFavs, Direction: model.ASC}),
+	)
+	// direction defaults to descending
+	assert.Equal(t,
+		keyset.Order{Column: "mapsets.last_playcount", IDColumn: "mapsets.id", Direction: model.DESC},
+		buildSortOrder(model.MapsetSort{Field: model.MapsetPlaycount}),
+	)
+}
@@ -0,0 +1,206 @@
+package mapsetrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"strings"
+	"unicode"
+)
+
+// facetFields are searched in order, they are mapsets columns
+var facetFields = []model.MapsetFacetField{
+	model.MapsetStatusFacet,
+	model.MapsetGenreFacet,
+	model.MapsetLanguageFacet,
+}
+
+// rankedMapset is mapset along with its full-text rank, cursors of relevance order are made of rank
+type rankedMapset struct {
+	model.Mapset `gorm:"embedded"`
+	SearchRank   float32
+}
+
+// Search lists page of mapsets matching full-text query and filters, relevance sort ranks them by query
+func (r *GormRepository) Search(
+	ctx context.Context,
+	tx txmanager.Tx,
+	search *model.MapsetSearch,
+	sort model.MapsetSort,
+	page model.PageRequest,
+) ([]*model.Mapset, *model.Page, error) {
+	var rows []*rankedMapset
+	var count int64
+
+	filterGormExpr := buildSearchExpr(search, "")
+
+	err := tx.DB().WithContext(ctx).
+		Table(mapsetsTableName).
+		Where(filterGormExpr).
+		Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count searched mapsets: %w", err)
+	}
+
+	order, ranked := buildSearchOrder(search, sort)
+	db := tx.DB().WithContext(ctx).Table(mapsetsTableName).Where(filterGormExpr)
+	if ranked {
+		db = db.Select("mapsets.*, "+order.Column+" AS search_rank", order.Args...)
+	}
+
+	db, err = keyset.Apply(db, order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&rows).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search mapsets: %w", err)
+	}
+
+	sortKey := mapsetSortKey(sort.Field)
+	rows, res := keyset.Trim(rows, order, page, int(count), func(m *rankedMapset) (string, int) {
+		if ranked {
+			return keyset.Float(float64(m.SearchRank), 32), m.ID
+		}
+
+		return sortKey(&m.Mapset)
+	})
+
+	mapsets := make([]*model.Mapset, len(rows))
+	for i, row := range rows {
+		mapsets[i] = &row.Mapset
+	}
+
+	return mapsets, res, nil
+}
+
+// SearchFacets counts mapsets matching search per status, genre and language
+func (r *GormRepository) SearchFacets(
+	ctx context.Context,
+	tx txmanager.Tx,
+	search *model.MapsetSearch,
+) (model.MapsetFacets, error) {
+	facets := make(model.MapsetFacets, len(facetFields))
+	for _, field := range facetFields {
+		var counts []*model.FacetCount
+		err := tx.DB().WithContext(ctx).
+			Table(mapsetsTableName).
+			Select(string(field) + " AS value, COUNT(*) AS count").
+			Where(buildSearchExpr(search, field)).
+			Where(string(field) + " IS NOT NULL AND " + string(field) + " <> ''").
+			Group(string(field)).
+			Order("count DESC, value").
+			Find(&counts).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to count mapsets by %s: %w", field, err)
+		}
+
+		facets[field] = counts
+	}
+
+	return facets, nil
+}
+
+func buildSearchExpr(search *model.MapsetSearch, skipFacet model.MapsetFacetField) clause.Expr {
+	query, values := buildSearchQuery(search, skipFacet)
+	if query == "" {
+		return gorm.Expr("1 = 1")
+	}
+
+	return gorm.Expr(query, values...)
+}
+
+// buildSearchQuery builds where clause of search, filter on skipFacet is left out so its facet can be counted
+func buildSearchQuery(search *model.MapsetSearch, skipFacet model.MapsetFacetField) (string, []interface{}) {
+	var conditions []string
+	var values []interface{}
+
+	if tsQuery := buildPrefixTSQuery(search.Query); tsQuery != "" {
+		conditions = append(conditions, "mapsets.search_vector @@ to_tsquery('simple', ?)")
+		values = append(values, tsQuery)
+	}
+
+	for _, in := range []struct {
+		field  model.MapsetFacetField
+		values []string
+	}{
+		{model.MapsetStatusFacet, search.Statuses},
+		{model.MapsetGenreFacet, search.Genres},
+		{model.MapsetLanguageFacet, search.Languages},
+	} {
+		if len(in.values) == 0 || in.field == skipFacet {
+			continue
+		}
+		conditions = append(conditions, "mapsets."+string(in.field)+" IN ?")
+		values = append(values, in.values)
+	}
+
+	conditions, values = appendRangeConditions(conditions, values, "mapsets.bpm", search.BPM)
+	conditions, values = appendRangeConditions(conditions, values, "mapsets.last_playcount", search.Playcount)
+	conditions, values = appendRangeConditions(conditions, values, "mapsets.last_passcount", search.Passcount)
+
+	// beatmap ranges have to hold for the same beatmap
+	var beatmapConditions []string
+	beatmapConditions, values = appendRangeConditions(beatmapConditions, values, "b.difficulty_rating", search.StarRating)
+	beatmapConditions, values = appendRangeConditions(beatmapConditions, values, "b.total_length", search.Length)
+	beatmapConditions, values = appendRangeConditions(beatmapConditions, values, "b.ar", search.AR)
+	beatmapConditions, values = appendRangeConditions(beatmapConditions, values, "b.cs", search.CS)
+	if len(beatmapConditions) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM beatmaps b WHERE b.mapset_id = mapsets.id AND "+
+			strings.Join(beatmapConditions, " AND ")+")")
+	}
+
+	return strings.Join(conditions, " AND "), values
+}
+
+func appendRangeConditions(conditions []string, values []interface{}, column string, r model.Range) ([]string, []interface{}) {
+	if r.Min != nil {
+		conditions = append(conditions, column+" >= ?")
+		values = append(values, *r.Min)
+	}
+	if r.Max != nil {
+		conditions = append(conditions, column+" <= ?")
+		values = append(values, *r.Max)
+	}
+
+	return conditions, values
+}
+
+// buildSearchOrder orders by full-text rank when relevance is asked for or query is set without sort,
+// ranked reports whether order is by rank
+func buildSearchOrder(search *model.MapsetSearch, sort model.MapsetSort) (keyset.Order, bool) {
+	tsQuery := buildPrefixTSQuery(search.Query)
+	if sort.Field == model.MapsetRelevance || (sort.Field == "" && tsQuery != "") {
+		if tsQuery == "" {
+			return buildSortOrder(model.MapsetSort{}), false
+		}
+
+		return keyset.Order{
+			Column:    "ts_rank_cd(mapsets.search_vector, to_tsquery('simple', ?))",
+			IDColumn:  "mapsets.id",
+			Direction: model.DESC,
+			Args:      []interface{}{tsQuery},
+		}, true
+	}
+
+	return buildSortOrder(sort), false
+}
+
+// buildPrefixTSQuery turns text into tsquery matching every word as a prefix, so search keeps matching
+// words as they are typed. only letters and digits are kept, anything else can't break query syntax
+func buildPrefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,106 @@
+package mapsetrepository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+)
+
+func Test_buildPrefixTSQuery(t *testing.T) {
+	assert.Equal(t, "freedom:* & dive:*", buildPrefixTSQuery("Freedom  DiVE"))
+	assert.Equal(t, "earth:* & s:* & atmosphere:*", buildPrefixTSQuery("earth's atmosphere"))
+	assert.Equal(t, "東方:*", buildPrefixTSQuery("東方 & | ! ( ) :*"))
+	assert.Equal(t, "", buildPrefixTSQuery(" ' "))
+}
+
+func Test_buildSearchQuery(t *testing.T) {
+	one, two, five := 1.0, 2.0, 5.0
+
+	tt := []struct {
+		name           string
+		search         *model.MapsetSearch
+		skipFacet      model.MapsetFacetField
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			name:           "Empty",
+			search:         &model.MapsetSearch{},
+			expectedQuery:  "",
+			expectedValues: nil,
+		},
+		{
+			name: "Query and multi-value filters",
+			search: &model.MapsetSearch{
+				Query:    "camellia",
+				Statuses: []string{"ranked", "loved"},
+				Genres:   []string{"Electronic"},
+			},
+			expectedQuery: "mapsets.search_vector @@ to_tsquery('simple', ?) AND mapsets.status IN ? AND mapsets.genre IN ?",
+			expectedValues: []interface{}{
+				"camellia:*", []string{"ranked", "loved"}, []string{"Electronic"},
+			},
+		},
+		{
+			name: "Facet filter is skipped",
+			search: &model.MapsetSearch{
+				Statuses:  []string{"ranked"},
+				Languages: []string{"Japanese"},
+			},
+			skipFacet:      model.MapsetStatusFacet,
+			expectedQuery:  "mapsets.language IN ?",
+			expectedValues: []interface{}{[]string{"Japanese"}},
+		},
+		{
+			name: "Mapset and beatmap ranges",
+			search: &model.MapsetSearch{
+				BPM:        model.Range{Min: &one},
+				StarRating: model.Range{Min: &two, Max: &five},
+				CS:         model.Range{Max: &five},
+			},
+			expectedQuery: "mapsets.bpm >= ? AND EXISTS (SELECT 1 FROM beatmaps b WHERE b.mapset_id = mapsets.id AND " +
+				"b.difficulty_rating >= ? AND b.difficulty_rating <= ? AND b.cs <= ?)",
+			expectedValues: []interface{}{1.0, 2.0, 5.0, 5.0},
+		},
+		{
+			name: "Passcount range reads latest stats column",
+			search: &model.MapsetSearch{
+				Playcount: model.Range{Min: &five},
+				Passcount: model.Range{Min: &one, Max: &two},
+			},
+			expectedQuery:  "mapsets.last_playcount >= ? AND mapsets.last_passcount >= ? AND mapsets.last_passcount <= ?",
+			expectedValues: []interface{}{5.0, 1.0, 2.0},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			query, values := buildSearchQuery(tc.search, tc.skipFacet)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
+
+func Test_buildSearchOrder(t *testing.T) {
+	rank := keyset.Order{
+		Column:    "ts_rank_cd(mapsets.search_vector, to_tsquery('simple', ?))",
+		IDColumn:  "mapsets.id",
+		Direction: model.DESC,
+		Args:      []interface{}{"camellia:*"},
+	}
+	newest := keyset.Order{Column: "mapsets.created_at", IDColumn: "mapsets.id", Direction: model.DESC}
+
+	order, ranked := buildSearchOrder(&model.MapsetSearch{Query: "camellia"}, model.MapsetSort{})
+	assert.True(t, ranked)
+	assert.Equal(t, rank, order)
+
+	order, ranked = buildSearchOrder(&model.MapsetSearch{}, model.MapsetSort{Field: model.MapsetRelevance})
+	assert.False(t, ranked)
+	assert.Equal(t, newest, order)
+
+	order, ranked = buildSearchOrder(&model.MapsetSearch{Query: "camellia"}, model.MapsetSort{Field: model.MapsetPlaycount, Direction: model.ASC})
+	assert.False(t, ranked)
+	assert.Equal(t, keyset.Order{Column: "mapsets.last_playcount", IDColumn: "mapsets.id", Direction: model.ASC}, order)
+}
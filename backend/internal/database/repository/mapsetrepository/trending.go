@@ -0,0 +1,92 @@
+package mapsetrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const trendingMapsetsTableName = "trending_mapsets"
+
+// ReplaceTrending drops previous ranking and stores the new one
+func (r *GormRepository) ReplaceTrending(ctx context.Context, tx txmanager.Tx, trending ...*model.TrendingMapset) error {
+	err := tx.DB().WithContext(ctx).Table(trendingMapsetsTableName).Where("1 = 1").Delete(&model.TrendingMapset{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear trending mapsets: %w", err)
+	}
+
+	if len(trending) == 0 {
+		return nil
+	}
+
+	err = tx.DB().WithContext(ctx).Table(trendingMapsetsTableName).CreateInBatches(trending, upsertBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to create trending mapsets: %w", err)
+	}
+
+	return nil
+}
+
+// ListTrending lists page of ranking for given period, filter is applied to mapsets columns
+func (r *GormRepository) ListTrending(
+	ctx context.Context,
+	tx txmanager.Tx,
+	periodDays int,
+	filter model.MapsetFilter,
+	sort model.TrendingSortField,
+	page model.PageRequest,
+) ([]*model.TrendingMapset, *model.Page, error) {
+	var trending []*model.TrendingMapset
+	var count int64
+
+	query, values := buildListByFilterQuery(filter)
+	filterGormExpr := gorm.Expr(query, values...)
+	if query == "" {
+		filterGormExpr = gorm.Expr("1 = 1")
+	}
+
+	if sort != model.TrendingRelative {
+		sort = model.TrendingAbsolute
+	}
+
+	base := func() *gorm.DB {
+		return tx.DB().WithContext(ctx).
+			Table(trendingMapsetsTableName).
+			Joins("JOIN mapsets ON mapsets.id = trending_mapsets.mapset_id").
+			Where("trending_mapsets.period_days = ?", periodDays).
+			Where(filterGormExpr)
+	}
+
+	err := base().Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count trending mapsets: %w", err)
+	}
+
+	order := keyset.Order{
+		Column:    "trending_mapsets." + string(sort),
+		IDColumn:  "trending_mapsets.mapset_id",
+		Direction: model.DESC,
+	}
+	db, err := keyset.Apply(base().Select("trending_mapsets.*"), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&trending).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list trending mapsets: %w", err)
+	}
+
+	trending, res := keyset.Trim(trending, order, page, int(count), func(t *model.TrendingMapset) (string, int) {
+		if sort == model.TrendingRelative {
+			return keyset.Float(t.RelativeGain, 32), t.MapsetID
+		}
+
+		return keyset.Int(t.PlaycountGain), t.MapsetID
+	})
+
+	return trending, res, nil
+}
@@ -0,0 +1,102 @@
+package mapsetrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+const upsertBatchSize = 500
+
+// columns overwritten on conflict, created_at is kept and mapset_stats is merged separately
+var upsertColumns = []string{
+	"artist",
+	"title",
+	"covers",
+	"status",
+	"last_updated",
+	"user_id",
+	"creator",
+	"language",
+	"genre",
+	"preview_url",
+	"tags",
+	"bpm",
+	"last_playcount",
+	"last_favorites",
+	"last_comments",
+	"last_passcount",
+	"submitted_date",
+	"comments_fetched_at",
+	"removal_status",
+	"removed_at",
+	"updated_at",
+}
+
+func (r *GormRepository) ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error) {
+	existing := make([]int, 0, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	err := tx.DB().WithContext(ctx).Table(mapsetsTableName).Where("id IN (?)", ids).Pluck("id", &existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing mapset ids: %w", err)
+	}
+
+	return existing, nil
+}
+
+// Upsert creates mapsets or updates existing ones, new stats entries are appended to existing history
+func (r *GormRepository) Upsert(ctx context.Context, tx txmanager.Tx, mapsets ...*model.Mapset) error {
+	if len(mapsets) == 0 {
+		return nil
+	}
+
+	assignments := append(clause.AssignmentColumns(upsertColumns), clause.Assignment{
+		Column: clause.Column{Name: "mapset_stats"},
+		Value:  gorm.Expr("COALESCE(" + mapsetsTableName + ".mapset_stats, '{}'::jsonb) || excluded.mapset_stats"),
+	})
+
+	err := tx.DB().WithContext(ctx).Table(mapsetsTableName).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: assignments,
+		}).
+		CreateInBatches(mapsets, upsertBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert mapsets: %w", err)
+	}
+
+	return nil
+}
+
+// MarkRemoved sets removal status for active mapsets, already removed ones keep their original removal time
+func (r *GormRepository) MarkRemoved(
+	ctx context.Context,
+	tx txmanager.Tx,
+	status model.MapsetRemovalStatus,
+	removedAt time.Time,
+	ids ...int,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := tx.DB().WithContext(ctx).Table(mapsetsTableName).
+		Where("id IN (?)", ids).
+		Where("removal_status = ?", model.MapsetActive).
+		Updates(map[string]interface{}{
+			"removal_status": status,
+			"removed_at":     removedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark mapsets %v as %s: %w", ids, status, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package milestonerepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, milestones ...*model.Milestone) ([]*model.Milestone, error)
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MilestoneFilter,
+		page model.PageRequest,
+	) ([]*model.Milestone, *model.Page, error)
+}
@@ -0,0 +1,95 @@
+package milestonerepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"sort"
+	"strings"
+)
+
+const milestonesTableName = "milestones"
+
+// Create stores milestones, ones already reached before are skipped.
+// Rows are inserted one by one, batched insert can't tell which rows were skipped on conflict
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, milestones ...*model.Milestone) ([]*model.Milestone, error) {
+	created := make([]*model.Milestone, 0, len(milestones))
+	for _, milestone := range milestones {
+		res := tx.DB().WithContext(ctx).Table(milestonesTableName).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(milestone)
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to create milestones: %w", res.Error)
+		}
+
+		if res.RowsAffected > 0 {
+			created = append(created, milestone)
+		}
+	}
+
+	return created, nil
+}
+
+// List lists page of milestones newest first
+func (r *GormRepository) List(
+	ctx context.Context,
+	tx txmanager.Tx,
+	filter model.MilestoneFilter,
+	page model.PageRequest,
+) ([]*model.Milestone, *model.Page, error) {
+	var milestones []*model.Milestone
+	var count int64
+
+	query, values := buildListByFilterQuery(filter)
+	filterGormExpr := gorm.Expr(query, values...)
+	if query == "" {
+		filterGormExpr = gorm.Expr("1 = 1")
+	}
+
+	err := tx.DB().WithContext(ctx).
+		Table(milestonesTableName).
+		Where(filterGormExpr).
+		Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count milestones: %w", err)
+	}
+
+	order := keyset.Order{Column: "reached_at", IDColumn: "id", Direction: model.DESC}
+	db, err := keyset.Apply(tx.DB().WithContext(ctx).Table(milestonesTableName).Where(filterGormExpr), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&milestones).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	milestones, res := keyset.Trim(milestones, order, page, int(count), func(e *model.Milestone) (string, int) {
+		return keyset.Time(e.ReachedAt), e.ID
+	})
+
+	return milestones, res, nil
+}
+
+func buildListByFilterQuery(filter model.MilestoneFilter) (string, []interface{}) {
+	keys := make([]string, 0, len(filter))
+	for column := range filter {
+		keys = append(keys, string(column))
+	}
+
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	values := make([]interface{}, 0, len(keys))
+	for _, column := range keys {
+		conditions = append(conditions, column+" = ?")
+		values = append(values, filter[model.MilestoneFilterField(column)])
+	}
+
+	return strings.Join(conditions, " AND "), values
+}
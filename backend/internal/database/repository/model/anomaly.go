@@ -0,0 +1,58 @@
+package model
+
+import (
+	"playcount-monitor-backend/internal/database/repository"
+	"time"
+)
+
+type AnomalyEntityType string
+
+const (
+	AnomalyMapset  AnomalyEntityType = "mapset"
+	AnomalyBeatmap AnomalyEntityType = "beatmap"
+)
+
+type AnomalySeverity string
+
+const (
+	AnomalyLow    AnomalySeverity = "low"
+	AnomalyMedium AnomalySeverity = "medium"
+	AnomalyHigh   AnomalySeverity = "high"
+)
+
+// Anomaly is a stats delta flagged by analysis after tracking run
+type Anomaly struct {
+	ID          int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	EntityType  string
+	EntityID    int
+	MapsetID    int
+	UserID      int
+	Metric      string
+	Kind        string
+	Severity    string
+	DetectedFor time.Time
+	Value       int
+	Delta       int
+	Baseline    float64
+	Score       float64
+	Context     repository.JSON `gorm:"type:jsonb"` // AnomalyContext struct marshaled as JSON
+	CreatedAt   time.Time
+}
+
+type AnomalyContext struct {
+	RecentDeltas []float64 `json:"recent_deltas"`
+	Title        string    `json:"title"`
+}
+
+// filter
+
+type AnomalyFilterField string
+
+const (
+	AnomalyUserIDField     AnomalyFilterField = "user_id"
+	AnomalyEntityTypeField AnomalyFilterField = "entity_type"
+	AnomalyKindField       AnomalyFilterField = "kind"
+	AnomalySeverityField   AnomalyFilterField = "severity"
+)
+
+type AnomalyFilter map[AnomalyFilterField]interface{}
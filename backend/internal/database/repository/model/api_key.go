@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// roleRanks orders roles, every role is granted everything roles below it are
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Valid reports whether role is one of known roles
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether role is granted access required role has
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// APIKey is a key automation authenticates with, only sha256 of the key is stored
+type APIKey struct {
+	ID         int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Role       string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
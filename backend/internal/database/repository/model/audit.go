@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// AuditEntry is a write request made through api, recorded whether it succeeded or not
+type AuditEntry struct {
+	ID        int  `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	APIKeyID  *int `gorm:"column:api_key_id"`
+	Actor     string
+	Method    string
+	Path      string
+	URI       string `gorm:"column:uri"`
+	Status    int
+	RequestID string
+	CreatedAt time.Time
+}
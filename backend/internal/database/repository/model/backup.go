@@ -0,0 +1,61 @@
+package model
+
+// BackupTable is table copied into backup archives
+type BackupTable string
+
+const (
+	BackupTableUsers                BackupTable = "users"
+	BackupTableMapsets              BackupTable = "mapsets"
+	BackupTableBeatmaps             BackupTable = "beatmaps"
+	BackupTableFollowing            BackupTable = "following"
+	BackupTableTracks               BackupTable = "tracks"
+	BackupTableCleans               BackupTable = "cleans"
+	BackupTableTrendingMapsets      BackupTable = "trending_mapsets"
+	BackupTableAnomalies            BackupTable = "anomalies"
+	BackupTableMilestones           BackupTable = "milestones"
+	BackupTableMapsetEvents         BackupTable = "mapset_events"
+	BackupTableWatchedMapsets       BackupTable = "watched_mapsets"
+	BackupTableWebhookSubscriptions BackupTable = "webhook_subscriptions"
+	BackupTableWebhookDeliveries    BackupTable = "webhook_deliveries"
+	BackupTableDigestSubscriptions  BackupTable = "digest_subscriptions"
+	BackupTableAPIKeys              BackupTable = "api_keys"
+	BackupTableAuditLog             BackupTable = "audit_log"
+	BackupTableOsuAccounts          BackupTable = "osu_accounts"
+	BackupTableWatchlists           BackupTable = "watchlists"
+	BackupTableWatchlistEntries     BackupTable = "watchlist_entries"
+)
+
+// BackupTables lists backed up tables in the order they are restored in, referenced tables go first.
+// sessions are left out on purpose, restoring them would bring back logins signed out since the backup,
+// owners of osu_accounts log in again after restore
+var BackupTables = []BackupTable{
+	BackupTableUsers,
+	BackupTableMapsets,
+	BackupTableBeatmaps,
+	BackupTableFollowing,
+	BackupTableTracks,
+	BackupTableCleans,
+	BackupTableTrendingMapsets,
+	BackupTableAnomalies,
+	BackupTableMilestones,
+	BackupTableMapsetEvents,
+	BackupTableWatchedMapsets,
+	BackupTableWebhookSubscriptions,
+	BackupTableWebhookDeliveries,
+	BackupTableDigestSubscriptions,
+	BackupTableAPIKeys,
+	BackupTableAuditLog,
+	BackupTableOsuAccounts,
+	BackupTableWatchlists,
+	BackupTableWatchlistEntries,
+}
+
+func (t BackupTable) IsValid() bool {
+	for _, table := range BackupTables {
+		if t == table {
+			return true
+		}
+	}
+
+	return false
+}
@@ -20,8 +20,11 @@ type Beatmap struct {
 	UserID           int
 	LastUpdated      time.Time       // last map update
 	BeatmapStats     repository.JSON `gorm:"type:jsonb"` //BeatmapStats struct marshaled as JSON
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// LastPlaycount and LastPasscount mirror the latest BeatmapStats entry
+	LastPlaycount int
+	LastPasscount int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 type BeatmapStats map[time.Time]*BeatmapStatsModel
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"playcount-monitor-backend/internal/database/repository"
+	"time"
+)
+
+type DigestFrequency string
+
+const (
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// DigestSubscription is an email address periodic digest of mappers' activity is sent to
+type DigestSubscription struct {
+	ID         int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	Email      string
+	Frequency  string
+	UserIDs    repository.JSON `gorm:"column:user_ids;type:jsonb"` // []int of mappers, empty list means every tracked mapper
+	LastSentAt *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,14 @@
+package model
+
+// GuestDifficulty is a beatmap mapped by someone other than host of its mapset, read from guest_difficulties view.
+// MapperUsername is empty when mapper is not tracked by us
+type GuestDifficulty struct {
+	BeatmapID        int
+	MapsetID         int
+	Version          string
+	DifficultyRating float64
+	HostID           int
+	HostUsername     string
+	MapperID         int
+	MapperUsername   string
+}
@@ -21,6 +21,17 @@ type Mapset struct {
 	BPM           float64
 	MapsetStats   repository.JSON `gorm:"type:jsonb"` // MapsetStats struct marshaled as JSON
 	LastPlaycount int
+	// LastFavorites and LastComments mirror the latest MapsetStats entry so lists can be sorted by them
+	LastFavorites int
+	LastComments  int
+	// LastPasscount is sum of latest passcounts of mapset's beatmaps
+	LastPasscount int
+	SubmittedDate time.Time
+	// CommentsFetchedAt is the last time comments count was fetched from api
+	CommentsFetchedAt time.Time
+	// RemovalStatus and RemovedAt are set when mapset is no longer listed for its host
+	RemovalStatus string
+	RemovedAt     *time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type MapsetEventKind string
+
+const (
+	MapsetEventNew          MapsetEventKind = "new_mapset"
+	MapsetEventStatusChange MapsetEventKind = "status_change"
+)
+
+// MapsetEvent is a mapset appearing or changing status between two tracking snapshots
+type MapsetEvent struct {
+	ID             int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	MapsetID       int
+	UserID         int
+	Kind           string
+	PreviousStatus string
+	Status         string
+	OccurredAt     time.Time
+	CreatedAt      time.Time
+}
+
+// filter
+
+type MapsetEventFilterField string
+
+const (
+	MapsetEventUserIDField MapsetEventFilterField = "user_id"
+	MapsetEventKindField   MapsetEventFilterField = "kind"
+)
+
+type MapsetEventFilter map[MapsetEventFilterField]interface{}
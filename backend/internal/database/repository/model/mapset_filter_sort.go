@@ -5,11 +5,15 @@ package model
 type MapsetFilterField string
 
 const (
-	MapsetStatusField               MapsetFilterField = "status"
-	MapsetArtistField               MapsetFilterField = "artist"
-	MapsetTitleField                MapsetFilterField = "title"
-	MapsetTagsField                 MapsetFilterField = "tags"
-	MapsetArtistOrTitleOrTagsFields MapsetFilterField = ""
+	MapsetStatusField MapsetFilterField = "status"
+	MapsetArtistField MapsetFilterField = "artist"
+	MapsetTitleField  MapsetFilterField = "title"
+	MapsetTagsField   MapsetFilterField = "tags"
+	MapsetGenreField  MapsetFilterField = "genre"
+	// MapsetSearchFields is full-text search over artist, title, creator, tags and difficulty names
+	MapsetSearchFields MapsetFilterField = ""
+	// MapsetWatchlistField keeps mapsets on watchlist with given id and mapsets of mappers on it
+	MapsetWatchlistField MapsetFilterField = "watchlist"
 )
 
 type MapsetFilter map[MapsetFilterField]interface{}
@@ -23,6 +27,7 @@ const (
 	MapsetCreatedAt MapsetSortField = "created_at"
 	MapsetFavs      MapsetSortField = "last_favorites"
 	MapsetComms     MapsetSortField = "last_comments"
+	MapsetPasscount MapsetSortField = "last_passcount"
 )
 
 type SortDirection string
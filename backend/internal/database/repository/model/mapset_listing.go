@@ -0,0 +1,17 @@
+package model
+
+// MapsetListing is read model of mapset lists, mapset along with all of its beatmaps
+type MapsetListing struct {
+	Mapset   *Mapset
+	Beatmaps []*Beatmap
+}
+
+// ListingStats picks which stats listings are read with
+type ListingStats string
+
+const (
+	// ListingStatsHistory reads stats history along with latest stats columns
+	ListingStatsHistory ListingStats = "history"
+	// ListingStatsLatest reads latest stats columns only, jsonb history is left out
+	ListingStatsLatest ListingStats = "latest"
+)
@@ -0,0 +1,10 @@
+package model
+
+// MapsetRemovalStatus is set when mapset disappears from its host's api listing, empty for active mapsets
+type MapsetRemovalStatus string
+
+const (
+	MapsetActive      MapsetRemovalStatus = ""
+	MapsetDeleted     MapsetRemovalStatus = "deleted"
+	MapsetTransferred MapsetRemovalStatus = "transferred"
+)
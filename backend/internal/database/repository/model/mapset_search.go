@@ -0,0 +1,51 @@
+package model
+
+// Range is inclusive, nil bound is open
+type Range struct {
+	Min *float64
+	Max *float64
+}
+
+func (r Range) IsSet() bool {
+	return r.Min != nil || r.Max != nil
+}
+
+// MapsetSearch is full-text query with filters, mapset matches beatmap ranges
+// when one of its beatmaps is within all of them
+type MapsetSearch struct {
+	Query     string
+	Statuses  []string
+	Genres    []string
+	Languages []string
+
+	// mapset ranges
+	BPM       Range
+	Playcount Range
+	Passcount Range
+
+	// beatmap ranges
+	StarRating Range
+	Length     Range
+	AR         Range
+	CS         Range
+}
+
+// MapsetRelevance sorts by full-text rank of search query
+const MapsetRelevance MapsetSortField = "relevance"
+
+type MapsetFacetField string
+
+const (
+	MapsetStatusFacet   MapsetFacetField = "status"
+	MapsetGenreFacet    MapsetFacetField = "genre"
+	MapsetLanguageFacet MapsetFacetField = "language"
+)
+
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// MapsetFacets counts matching mapsets per value, counts of a field ignore filter on that field
+// so they show how many mapsets selecting another value would add
+type MapsetFacets map[MapsetFacetField][]*FacetCount
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type MilestoneEntityType string
+
+const (
+	MilestoneUser   MilestoneEntityType = "user"
+	MilestoneMapset MilestoneEntityType = "mapset"
+)
+
+type MilestoneKind string
+
+const (
+	MilestonePlaycount   MilestoneKind = "play_count"
+	MilestoneFavourites  MilestoneKind = "favourite_count"
+	MilestoneMapCount    MilestoneKind = "map_count"
+	MilestoneFirstRanked MilestoneKind = "first_ranked"
+)
+
+// Milestone is a threshold crossed by user or mapset between two tracking snapshots
+type Milestone struct {
+	ID         int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	EntityType string
+	EntityID   int
+	UserID     int
+	Kind       string
+	Threshold  int
+	Value      int
+	ReachedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// filter
+
+type MilestoneFilterField string
+
+const (
+	MilestoneUserIDField     MilestoneFilterField = "user_id"
+	MilestoneEntityTypeField MilestoneFilterField = "entity_type"
+	MilestoneKindField       MilestoneFilterField = "kind"
+)
+
+type MilestoneFilter map[MilestoneFilterField]interface{}
@@ -0,0 +1,26 @@
+package model
+
+// Cursor is keyset position in list, page starts right after row with Value and ID,
+// or ends right before it when Backward is set
+type Cursor struct {
+	// Order is key of list order cursor was made for, cursor can't be applied to another order
+	Order string `json:"o"`
+	// Value is sort column value of the row as text, postgres casts it to column type
+	Value    string `json:"v"`
+	ID       int    `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// PageRequest asks for Limit rows from Cursor, Offset is used only without cursor
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+}
+
+// Page is total count of list along with cursors of neighbouring pages, nil when there is nothing that way
+type Page struct {
+	Total int
+	Next  *Cursor
+	Prev  *Cursor
+}
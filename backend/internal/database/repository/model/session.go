@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// OsuAccount is a mapper who logged in with osu!, tokens are kept encrypted
+// so the api can be called on their behalf
+type OsuAccount struct {
+	UserID                int `gorm:"primaryKey;autoIncrement:false"`
+	Username              string
+	AccessTokenEncrypted  string
+	RefreshTokenEncrypted string
+	TokenExpiresAt        time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// Session is a login of osu! account, only sha256 of session cookie is stored
+type Session struct {
+	TokenHash string `gorm:"primaryKey"`
+	UserID    int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// StatEntity is kind of tracked entity whose stats history is exported
+type StatEntity string
+
+const (
+	StatEntityUser    StatEntity = "user"
+	StatEntityMapset  StatEntity = "mapset"
+	StatEntityBeatmap StatEntity = "beatmap"
+)
+
+// StatEntities lists every exported entity in export order
+var StatEntities = []StatEntity{StatEntityUser, StatEntityMapset, StatEntityBeatmap}
+
+func (e StatEntity) IsValid() bool {
+	switch e {
+	case StatEntityUser, StatEntityMapset, StatEntityBeatmap:
+		return true
+	}
+
+	return false
+}
+
+// StatPoint is one metric of one stats history entry, stats history in tidy long format
+type StatPoint struct {
+	Entity    StatEntity
+	ID        int
+	Timestamp time.Time
+	Metric    string
+	Value     int64
+}
+
+// StatPointFilter narrows exported history, zero From or To leaves that side open
+type StatPointFilter struct {
+	From time.Time
+	To   time.Time
+}
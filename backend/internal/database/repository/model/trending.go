@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// TrendingMapset is mapset's playcount gain over the last PeriodDays, precomputed after each tracking run
+type TrendingMapset struct {
+	PeriodDays    int
+	MapsetID      int
+	PlaycountGain int
+	// RelativeGain is PlaycountGain compared to what mapset gains over the same period on average during its lifetime
+	RelativeGain float64
+	ComputedAt   time.Time
+}
+
+type TrendingSortField string
+
+const (
+	TrendingAbsolute TrendingSortField = "playcount_gain"
+	TrendingRelative TrendingSortField = "relative_gain"
+)
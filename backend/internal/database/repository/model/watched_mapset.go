@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// WatchedMapset is a mapset tracked on its own, without following its host
+type WatchedMapset struct {
+	ID          int
+	CreatedAt   time.Time
+	LastFetched *time.Time
+}
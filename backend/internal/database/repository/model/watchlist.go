@@ -0,0 +1,34 @@
+package model
+
+import (
+	"playcount-monitor-backend/internal/database/repository"
+	"time"
+)
+
+type WatchlistEntityType string
+
+const (
+	WatchlistUser   WatchlistEntityType = "user"
+	WatchlistMapset WatchlistEntityType = "mapset"
+)
+
+// Watchlist is a private list of mappers and mapsets of osu! account
+type Watchlist struct {
+	ID        int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	AccountID int
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WatchlistEntry is a watched mapper or mapset with owner's note and tags
+type WatchlistEntry struct {
+	ID          int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	WatchlistID int
+	EntityType  string
+	EntityID    int
+	Note        string
+	Tags        repository.JSON `gorm:"type:jsonb"` // []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
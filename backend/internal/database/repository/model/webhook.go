@@ -0,0 +1,32 @@
+package model
+
+import (
+	"playcount-monitor-backend/internal/database/repository"
+	"time"
+)
+
+// WebhookSubscription is a registered http endpoint tracking events are posted to
+type WebhookSubscription struct {
+	ID        int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	URL       string
+	Secret    string
+	Events    repository.JSON `gorm:"type:jsonb"` // []string of event types, empty list matches every event
+	Kind      string
+	Templates repository.JSON `gorm:"type:jsonb"` // map[string]string of event type to message template
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is a delivery log entry, one per event posted to subscription
+type WebhookDelivery struct {
+	ID             int `gorm:"PRIMARY_KEY;AUTO_INCREMENT;NOT NULL"`
+	SubscriptionID int
+	EventType      string
+	Payload        repository.JSON `gorm:"type:jsonb"`
+	Attempts       int
+	StatusCode     int
+	Success        bool
+	Error          string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
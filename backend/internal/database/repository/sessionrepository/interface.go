@@ -0,0 +1,19 @@
+package sessionrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type Interface interface {
+	UpsertAccount(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error
+	GetAccount(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error)
+	GetAccountForUpdate(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error)
+	UpdateAccountTokens(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error
+	CreateSession(ctx context.Context, tx txmanager.Tx, session *model.Session) error
+	GetSession(ctx context.Context, tx txmanager.Tx, tokenHash string) (*model.Session, error)
+	DeleteSession(ctx context.Context, tx txmanager.Tx, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, tx txmanager.Tx, now time.Time) error
+}
@@ -0,0 +1,117 @@
+package sessionrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+const (
+	osuAccountsTableName = "osu_accounts"
+	sessionsTableName    = "sessions"
+)
+
+// UpsertAccount stores account on first login and replaces its username and tokens on next ones
+func (r *GormRepository) UpsertAccount(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error {
+	err := tx.DB().WithContext(ctx).
+		Table(osuAccountsTableName).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"username",
+				"access_token_encrypted",
+				"refresh_token_encrypted",
+				"token_expires_at",
+				"updated_at",
+			}),
+		}).
+		Create(account).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert osu account %v: %w", account.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) GetAccount(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error) {
+	var account *model.OsuAccount
+	err := tx.DB().WithContext(ctx).Table(osuAccountsTableName).Where("user_id = ?", userID).First(&account).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get osu account %v: %w", userID, err)
+	}
+
+	return account, nil
+}
+
+// GetAccountForUpdate locks account row until transaction ends, so concurrent requests don't
+// refresh tokens at the same time and invalidate each other's refresh token
+func (r *GormRepository) GetAccountForUpdate(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error) {
+	var account *model.OsuAccount
+	err := tx.DB().WithContext(ctx).
+		Table(osuAccountsTableName).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&account).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get osu account %v for update: %w", userID, err)
+	}
+
+	return account, nil
+}
+
+func (r *GormRepository) UpdateAccountTokens(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error {
+	err := tx.DB().WithContext(ctx).
+		Table(osuAccountsTableName).
+		Where("user_id = ?", account.UserID).
+		Updates(map[string]interface{}{
+			"access_token_encrypted":  account.AccessTokenEncrypted,
+			"refresh_token_encrypted": account.RefreshTokenEncrypted,
+			"token_expires_at":        account.TokenExpiresAt,
+			"updated_at":              account.UpdatedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update tokens of osu account %v: %w", account.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) CreateSession(ctx context.Context, tx txmanager.Tx, session *model.Session) error {
+	err := tx.DB().WithContext(ctx).Table(sessionsTableName).Create(session).Error
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) GetSession(ctx context.Context, tx txmanager.Tx, tokenHash string) (*model.Session, error) {
+	var session *model.Session
+	err := tx.DB().WithContext(ctx).Table(sessionsTableName).Where("token_hash = ?", tokenHash).First(&session).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *GormRepository) DeleteSession(ctx context.Context, tx txmanager.Tx, tokenHash string) error {
+	err := tx.DB().WithContext(ctx).Table(sessionsTableName).Where("token_hash = ?", tokenHash).Delete(&model.Session{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) DeleteExpiredSessions(ctx context.Context, tx txmanager.Tx, now time.Time) error {
+	err := tx.DB().WithContext(ctx).Table(sessionsTableName).Where("expires_at <= ?", now).Delete(&model.Session{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	return nil
+}
@@ -12,5 +12,7 @@ type Interface interface {
 	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.User, error)
 	GetByName(ctx context.Context, tx txmanager.Tx, name string) (*model.User, error)
 	List(ctx context.Context, tx txmanager.Tx) ([]*model.User, error)
+	ListPage(ctx context.Context, tx txmanager.Tx, watchlistID int, page model.PageRequest) ([]*model.User, *model.Page, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.User, error)
 	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
 }
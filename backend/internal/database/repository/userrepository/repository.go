@@ -3,6 +3,8 @@ package userrepository
 import (
 	"context"
 	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/keyset"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 )
@@ -66,3 +68,57 @@ func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.Us
 
 	return users, nil
 }
+
+// ListPage lists page of users by username, non-zero watchlistID narrows it to stored users who are
+// on watchlist, watched users not fetched yet are left out
+func (r *GormRepository) ListPage(
+	ctx context.Context,
+	tx txmanager.Tx,
+	watchlistID int,
+	page model.PageRequest,
+) ([]*model.User, *model.Page, error) {
+	var users []*model.User
+	var count int64
+
+	base := func() *gorm.DB {
+		db := tx.DB().WithContext(ctx).Table(usersTableName)
+		if watchlistID != 0 {
+			db = db.Where("id IN (SELECT entity_id FROM watchlist_entries WHERE watchlist_id = ? AND entity_type = ?)",
+				watchlistID, string(model.WatchlistUser))
+		}
+
+		return db
+	}
+
+	err := base().Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	order := keyset.Order{Column: "username", IDColumn: "id", Direction: model.ASC}
+	db, err := keyset.Apply(base(), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&users).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users, res := keyset.Trim(users, order, page, int(count), func(u *model.User) (string, int) {
+		return u.Username, u.ID
+	})
+
+	return users, res, nil
+}
+
+func (r *GormRepository) ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.User, error) {
+	var users []*model.User
+	err := tx.DB().WithContext(ctx).Table(usersTableName).Where("id IN (?)", ids).Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users %v: %w", ids, err)
+	}
+
+	return users, nil
+}
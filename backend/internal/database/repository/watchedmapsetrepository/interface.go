@@ -0,0 +1,16 @@
+package watchedmapsetrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, watched *model.WatchedMapset) error
+	List(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.WatchedMapset, *model.Page, error)
+	ListIDs(ctx context.Context, tx txmanager.Tx) ([]int, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+	SetLastFetched(ctx context.Context, tx txmanager.Tx, id int, lastFetched time.Time) error
+}
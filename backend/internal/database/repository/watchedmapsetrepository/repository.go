@@ -0,0 +1,81 @@
+package watchedmapsetrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+const watchedMapsetsTableName = "watched_mapsets"
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, watched *model.WatchedMapset) error {
+	err := tx.DB().WithContext(ctx).Table(watchedMapsetsTableName).Create(watched).Error
+	if err != nil {
+		return fmt.Errorf("failed to create watched mapset %v: %w", watched.ID, err)
+	}
+
+	return nil
+}
+
+// List lists page of watched mapsets, most recently watched first
+func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.WatchedMapset, *model.Page, error) {
+	var watched []*model.WatchedMapset
+	var count int64
+
+	err := tx.DB().WithContext(ctx).Table(watchedMapsetsTableName).Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count watched mapsets: %w", err)
+	}
+
+	order := keyset.Order{Column: "created_at", IDColumn: "id", Direction: model.DESC}
+	db, err := keyset.Apply(tx.DB().WithContext(ctx).Table(watchedMapsetsTableName), order, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&watched).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list watched mapsets: %w", err)
+	}
+
+	watched, res := keyset.Trim(watched, order, page, int(count), func(w *model.WatchedMapset) (string, int) {
+		return keyset.Time(w.CreatedAt), w.ID
+	})
+
+	return watched, res, nil
+}
+
+func (r *GormRepository) ListIDs(ctx context.Context, tx txmanager.Tx) ([]int, error) {
+	var ids []int
+	err := tx.DB().WithContext(ctx).Table(watchedMapsetsTableName).Order("id").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched mapset ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Delete stops watching mapset, reports whether it was watched
+func (r *GormRepository) Delete(ctx context.Context, tx txmanager.Tx, id int) (bool, error) {
+	res := tx.DB().WithContext(ctx).Table(watchedMapsetsTableName).Where("id = ?", id).Delete(&model.WatchedMapset{})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to delete watched mapset %v: %w", id, res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *GormRepository) SetLastFetched(ctx context.Context, tx txmanager.Tx, id int, lastFetched time.Time) error {
+	err := tx.DB().WithContext(ctx).
+		Table(watchedMapsetsTableName).
+		Where("id = ?", id).
+		Update("last_fetched", lastFetched).Error
+	if err != nil {
+		return fmt.Errorf("failed to set last fetched for watched mapset %v: %w", id, err)
+	}
+
+	return nil
+}
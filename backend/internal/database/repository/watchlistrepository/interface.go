@@ -0,0 +1,18 @@
+package watchlistrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, watchlist *model.Watchlist) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error)
+	ListForAccount(ctx context.Context, tx txmanager.Tx, accountID int) ([]*model.Watchlist, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+	UpsertEntry(ctx context.Context, tx txmanager.Tx, entry *model.WatchlistEntry) error
+	DeleteEntry(ctx context.Context, tx txmanager.Tx, watchlistID int, entityType model.WatchlistEntityType, entityID int) (bool, error)
+	ListEntries(ctx context.Context, tx txmanager.Tx, watchlistIDs ...int) ([]*model.WatchlistEntry, error)
+	ListWatchedIDs(ctx context.Context, tx txmanager.Tx, entityType model.WatchlistEntityType) ([]int, error)
+}
@@ -0,0 +1,126 @@
+package watchlistrepository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm/clause"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const (
+	watchlistsTableName       = "watchlists"
+	watchlistEntriesTableName = "watchlist_entries"
+)
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, watchlist *model.Watchlist) error {
+	err := tx.DB().WithContext(ctx).Table(watchlistsTableName).Create(watchlist).Error
+	if err != nil {
+		return fmt.Errorf("failed to create watchlist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error) {
+	var watchlist *model.Watchlist
+	err := tx.DB().WithContext(ctx).Table(watchlistsTableName).Where("id = ?", id).First(&watchlist).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist with id %v: %w", id, err)
+	}
+
+	return watchlist, nil
+}
+
+func (r *GormRepository) ListForAccount(ctx context.Context, tx txmanager.Tx, accountID int) ([]*model.Watchlist, error) {
+	var watchlists []*model.Watchlist
+	err := tx.DB().WithContext(ctx).
+		Table(watchlistsTableName).
+		Where("account_id = ?", accountID).
+		Order("id").
+		Find(&watchlists).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchlists of account %v: %w", accountID, err)
+	}
+
+	return watchlists, nil
+}
+
+// Delete deletes watchlist, its entries are deleted by cascade
+func (r *GormRepository) Delete(ctx context.Context, tx txmanager.Tx, id int) error {
+	err := tx.DB().WithContext(ctx).Table(watchlistsTableName).Where("id = ?", id).Delete(&model.Watchlist{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist with id %v: %w", id, err)
+	}
+
+	return nil
+}
+
+// UpsertEntry adds entity to watchlist or replaces note and tags of already watched one
+func (r *GormRepository) UpsertEntry(ctx context.Context, tx txmanager.Tx, entry *model.WatchlistEntry) error {
+	err := tx.DB().WithContext(ctx).
+		Table(watchlistEntriesTableName).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "watchlist_id"}, {Name: "entity_type"}, {Name: "entity_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"note", "tags", "updated_at"}),
+		}).
+		Create(entry).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert entry of watchlist %v: %w", entry.WatchlistID, err)
+	}
+
+	return nil
+}
+
+// DeleteEntry reports whether entity was on the watchlist
+func (r *GormRepository) DeleteEntry(
+	ctx context.Context,
+	tx txmanager.Tx,
+	watchlistID int,
+	entityType model.WatchlistEntityType,
+	entityID int,
+) (bool, error) {
+	res := tx.DB().WithContext(ctx).
+		Table(watchlistEntriesTableName).
+		Where("watchlist_id = ? AND entity_type = ? AND entity_id = ?", watchlistID, string(entityType), entityID).
+		Delete(&model.WatchlistEntry{})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to delete %s %v from watchlist %v: %w", entityType, entityID, watchlistID, res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *GormRepository) ListEntries(ctx context.Context, tx txmanager.Tx, watchlistIDs ...int) ([]*model.WatchlistEntry, error) {
+	var entries []*model.WatchlistEntry
+	if len(watchlistIDs) == 0 {
+		return entries, nil
+	}
+
+	err := tx.DB().WithContext(ctx).
+		Table(watchlistEntriesTableName).
+		Where("watchlist_id IN (?)", watchlistIDs).
+		Order("watchlist_id, id").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries of watchlists %v: %w", watchlistIDs, err)
+	}
+
+	return entries, nil
+}
+
+// ListWatchedIDs lists entities of type on any watchlist, each only once
+func (r *GormRepository) ListWatchedIDs(ctx context.Context, tx txmanager.Tx, entityType model.WatchlistEntityType) ([]int, error) {
+	var ids []int
+	err := tx.DB().WithContext(ctx).
+		Table(watchlistEntriesTableName).
+		Distinct("entity_id").
+		Where("entity_type = ?", string(entityType)).
+		Order("entity_id").
+		Pluck("entity_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched %s ids: %w", entityType, err)
+	}
+
+	return ids, nil
+}
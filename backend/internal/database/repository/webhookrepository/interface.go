@@ -0,0 +1,22 @@
+package webhookrepository
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type Interface interface {
+	Create(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.WebhookSubscription, error)
+	Update(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.WebhookSubscription, error)
+	CreateDeliveries(ctx context.Context, tx txmanager.Tx, deliveries ...*model.WebhookDelivery) error
+	ListDeliveries(
+		ctx context.Context,
+		tx txmanager.Tx,
+		subscriptionID int,
+		page model.PageRequest,
+	) ([]*model.WebhookDelivery, *model.Page, error)
+}
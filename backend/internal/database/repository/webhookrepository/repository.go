@@ -0,0 +1,117 @@
+package webhookrepository
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+const (
+	subscriptionsTableName = "webhook_subscriptions"
+	deliveriesTableName    = "webhook_deliveries"
+)
+
+const createBatchSize = 500
+
+func (r *GormRepository) Create(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error {
+	err := tx.DB().WithContext(ctx).Table(subscriptionsTableName).Create(subscription).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) Get(ctx context.Context, tx txmanager.Tx, id int) (*model.WebhookSubscription, error) {
+	var subscription *model.WebhookSubscription
+	err := tx.DB().WithContext(ctx).Table(subscriptionsTableName).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription with id %v: %w", id, err)
+	}
+
+	return subscription, nil
+}
+
+func (r *GormRepository) Update(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error {
+	err := tx.DB().WithContext(ctx).Table(subscriptionsTableName).Save(subscription).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription with id %v: %w", subscription.ID, err)
+	}
+
+	return nil
+}
+
+// Delete removes subscription, its delivery log is removed by cascade
+func (r *GormRepository) Delete(ctx context.Context, tx txmanager.Tx, id int) error {
+	err := tx.DB().WithContext(ctx).Table(subscriptionsTableName).Where("id = ?", id).Delete(&model.WebhookSubscription{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription with id %v: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *GormRepository) List(ctx context.Context, tx txmanager.Tx) ([]*model.WebhookSubscription, error) {
+	var subscriptions []*model.WebhookSubscription
+	err := tx.DB().WithContext(ctx).Table(subscriptionsTableName).Order("id").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *GormRepository) CreateDeliveries(ctx context.Context, tx txmanager.Tx, deliveries ...*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := tx.DB().WithContext(ctx).Table(deliveriesTableName).CreateInBatches(deliveries, createBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries lists page of delivery log of subscription newest first
+func (r *GormRepository) ListDeliveries(
+	ctx context.Context,
+	tx txmanager.Tx,
+	subscriptionID int,
+	page model.PageRequest,
+) ([]*model.WebhookDelivery, *model.Page, error) {
+	var deliveries []*model.WebhookDelivery
+	var count int64
+
+	err := tx.DB().WithContext(ctx).
+		Table(deliveriesTableName).
+		Where("subscription_id = ?", subscriptionID).
+		Count(&count).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	order := keyset.Order{Column: "created_at", IDColumn: "id", Direction: model.DESC}
+	db, err := keyset.Apply(
+		tx.DB().WithContext(ctx).Table(deliveriesTableName).Where("subscription_id = ?", subscriptionID),
+		order,
+		page,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Find(&deliveries).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries, res := keyset.Trim(deliveries, order, page, int(count), func(d *model.WebhookDelivery) (string, int) {
+		return keyset.Time(d.CreatedAt), d.ID
+	})
+
+	return deliveries, res, nil
+}
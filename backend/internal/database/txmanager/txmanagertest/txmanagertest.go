@@ -0,0 +1,28 @@
+// Package txmanagertest has transaction manager for tests of use cases backed by fake stores
+package txmanagertest
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+// TxManager runs effectors without database, tx passed to them is nil.
+// ReadWrite runs effector Retries more times before the last attempt as if their commits failed,
+// so that tests can check state collected by effectors isn't doubled by retried transactions
+type TxManager struct {
+	Retries int
+}
+
+func (m TxManager) ReadWrite(ctx context.Context, effector txmanager.Effector, _ ...txmanager.TxConfigurator) error {
+	for i := 0; i < m.Retries; i++ {
+		if err := effector(ctx, nil); err != nil {
+			return err
+		}
+	}
+
+	return effector(ctx, nil)
+}
+
+func (m TxManager) ReadOnly(ctx context.Context, effector txmanager.Effector, _ ...txmanager.TxConfigurator) error {
+	return effector(ctx, nil)
+}
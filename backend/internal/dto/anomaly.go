@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Anomaly struct {
+	ID          int             `json:"id"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    int             `json:"entity_id"`
+	MapsetID    int             `json:"mapset_id"`
+	UserID      int             `json:"user_id"`
+	Metric      string          `json:"metric"`
+	Kind        string          `json:"kind"`
+	Severity    string          `json:"severity"`
+	DetectedFor time.Time       `json:"detected_for"`
+	Value       int             `json:"value"`
+	Delta       int             `json:"delta"`
+	Baseline    float64         `json:"baseline"`
+	Score       float64         `json:"score"`
+	Context     json.RawMessage `json:"context"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type APIKey struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+	Role   string `json:"role"`
+	// Key is returned only when key is created
+	Key        string     `json:"key,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
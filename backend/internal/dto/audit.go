@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type AuditEntry struct {
+	ID        int       `json:"id"`
+	APIKeyID  *int      `json:"api_key_id"`
+	Actor     string    `json:"actor"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	URI       string    `json:"uri"`
+	Status    int       `json:"status"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type DigestSubscription struct {
+	ID        int    `json:"id"`
+	Email     string `json:"email"`
+	Frequency string `json:"frequency"`
+	// UserIDs are mappers digest is about, empty list means every tracked mapper
+	UserIDs    []int      `json:"user_ids"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type Feed struct {
+	ID    string
+	Title string
+	// Updated is time of the latest entry, zero for empty feed
+	Updated time.Time
+	Entries []*FeedEntry
+}
+
+type FeedEntry struct {
+	ID       string
+	Title    string
+	Summary  string
+	URL      string
+	Author   string
+	ImageURL string
+	Updated  time.Time
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type Forecast struct {
+	Model       string        `json:"model"` // linear or exponential
+	Projections []*Projection `json:"projections"`
+	// NextMilestone is the next round number counter is expected to reach, nil when it is not growing
+	NextMilestone *ForecastMilestone `json:"next_milestone"`
+}
+
+type Projection struct {
+	Days  int       `json:"days"`
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	// Lower and Upper bound 95% prediction interval
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+type ForecastMilestone struct {
+	Value float64   `json:"value"`
+	ETA   time.Time `json:"eta"`
+}
@@ -6,19 +6,46 @@ import (
 )
 
 type Mapset struct {
-	Id          int               `json:"id"`
-	Artist      string            `json:"artist"`
-	Title       string            `json:"title"`
-	Covers      map[string]string `json:"covers"`
-	Status      string            `json:"status"`
-	Genre       string            `json:"genre"`
-	Language    string            `json:"language"`
-	LastUpdated time.Time         `json:"last_updated"`
-	UserId      int               `json:"user_id"`
-	PreviewUrl  string            `json:"preview_url"`
-	Tags        string            `json:"tags"`
-	MapsetStats model.MapsetStats `json:"mapset_stats"`
-	Bpm         float64           `json:"bpm"`
-	Creator     string            `json:"creator"`
-	Beatmaps    []*Beatmap        `json:"beatmaps"`
+	Id          int                `json:"id"`
+	Artist      string             `json:"artist"`
+	Title       string             `json:"title"`
+	Covers      map[string]string  `json:"covers"`
+	Status      string             `json:"status"`
+	Genre       string             `json:"genre"`
+	Language    string             `json:"language"`
+	LastUpdated time.Time          `json:"last_updated"`
+	UserId      int                `json:"user_id"`
+	PreviewUrl  string             `json:"preview_url"`
+	Tags        string             `json:"tags"`
+	MapsetStats model.MapsetStats  `json:"mapset_stats"`
+	LatestStats *MapsetLatestStats `json:"latest_stats"`
+	Bpm         float64            `json:"bpm"`
+	Creator     string             `json:"creator"`
+	Beatmaps    []*Beatmap         `json:"beatmaps"`
+
+	RemovalStatus string     `json:"removal_status,omitempty"`
+	RemovedAt     *time.Time `json:"removed_at,omitempty"`
+
+	// Forecast is filled for single mapset requests only
+	Forecast map[string]*Forecast `json:"forecast,omitempty"`
+}
+
+// MapsetLatestStats are precomputed latest stats, they are served even when stats history is left out
+type MapsetLatestStats struct {
+	Playcount int `json:"play_count"`
+	Favorites int `json:"favourite_count"`
+	Comments  int `json:"comments_count"`
+	Passcount int `json:"pass_count"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// MapsetFacets counts searched mapsets per value, counts of a field ignore filter on that field
+type MapsetFacets struct {
+	Status   []*FacetCount `json:"status"`
+	Genre    []*FacetCount `json:"genre"`
+	Language []*FacetCount `json:"language"`
 }
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type Milestone struct {
+	ID         int       `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int       `json:"entity_id"`
+	UserID     int       `json:"user_id"`
+	Kind       string    `json:"kind"`
+	Threshold  int       `json:"threshold"`
+	Value      int       `json:"value"`
+	ReachedAt  time.Time `json:"reached_at"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// Page is position of a list page. Next and Prev are opaque cursors of neighbouring pages,
+// CurrentPage and Pages count pages of Limit entries, CurrentPage is zero for pages reached by cursor
+type Page struct {
+	Total       int    `json:"total"`
+	Limit       int    `json:"limit"`
+	Next        string `json:"next,omitempty"`
+	Prev        string `json:"prev,omitempty"`
+	CurrentPage int    `json:"current_page"`
+	Pages       int    `json:"pages"`
+}
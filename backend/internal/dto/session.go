@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Session is a login of osu! user
+type Session struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	// Token is session cookie value, it is set only when session is created
+	Token     string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoginRedirect is where user is sent to log in with osu!, State has to come back with the callback
+type LoginRedirect struct {
+	URL   string
+	State string
+}
+
+// Account is a logged in mapper with their tracking settings
+type Account struct {
+	UserID           int       `json:"user_id"`
+	Username         string    `json:"username"`
+	Following        bool      `json:"following"`
+	SessionExpiresAt time.Time `json:"session_expires_at"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type TrendingMapset struct {
+	*Mapset
+	PeriodDays    int     `json:"period_days"`
+	PlaycountGain int     `json:"playcount_gain"`
+	RelativeGain  float64 `json:"relative_gain"`
+}
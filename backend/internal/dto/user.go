@@ -13,6 +13,9 @@ type User struct {
 	TrackingSince time.Time       `json:"tracking_since"`
 	UserStats     model.UserStats `json:"user_stats"`
 	UserMapCounts *UserMapCounts  `json:"user_map_counts"`
+
+	// Forecast is filled for single user requests only
+	Forecast map[string]*Forecast `json:"forecast,omitempty"`
 }
 
 type UserMapCounts struct {
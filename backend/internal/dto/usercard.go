@@ -3,4 +3,8 @@ package dto
 type UserCard struct {
 	User    *User
 	Mapsets []*Mapset
+	// Anomalies are the latest anomalies flagged on user's mapsets and beatmaps
+	Anomalies []*Anomaly
+	// Milestones are the latest milestones reached by user and their mapsets
+	Milestones []*Milestone
 }
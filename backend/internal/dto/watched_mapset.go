@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type WatchedMapset struct {
+	ID          int        `json:"id"`
+	LastFetched *time.Time `json:"last_fetched"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
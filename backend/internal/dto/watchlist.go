@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type Watchlist struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name"`
+	Entries   []*WatchlistEntry `json:"entries"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type WatchlistEntry struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   int       `json:"entity_id"`
+	Note       string    `json:"note"`
+	Tags       []string  `json:"tags"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/http/principal"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"strings"
+)
+
+const (
+	apiKeyHeader      = "X-API-Key"
+	bearerScheme      = "Bearer "
+	anonymousActor    = "anonymous"
+	unauthorizedRealm = `Bearer realm="playcount-monitor"`
+)
+
+type authenticator interface {
+	Authenticate(ctx context.Context, plaintext string) (*dto.APIKey, error)
+}
+
+type sessionAuthenticator interface {
+	Authenticate(ctx context.Context, plaintext string) (*dto.Session, error)
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, entry *dto.AuditEntry) error
+}
+
+// authenticate resolves api key or session of request, requests without either pass through
+// as anonymous and are rejected by requireRole of routes that need a role. invalid api key is rejected
+// right away while invalid session cookie is ignored, it is usually just an expired login
+func authenticate(keys authenticator, sessions sessionAuthenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if plaintext := requestAPIKey(c.Request()); plaintext != "" {
+				key, err := keys.Authenticate(c.Request().Context(), plaintext)
+				if err != nil {
+					if domainerror.IsKind(err, domainerror.KindUnauthenticated) {
+						c.Response().Header().Set(echo.HeaderWWWAuthenticate, unauthorizedRealm)
+					}
+					return err
+				}
+				principal.SetAPIKey(c, key)
+
+				return next(c)
+			}
+
+			if cookie, err := c.Cookie(principal.SessionCookie); err == nil && cookie.Value != "" {
+				session, err := sessions.Authenticate(c.Request().Context(), cookie.Value)
+				if err != nil && !domainerror.IsKind(err, domainerror.KindUnauthenticated) {
+					return err
+				}
+				if session != nil {
+					principal.SetSession(c, session)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requireRole rejects requests made without role or a higher one
+func requireRole(required model.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, ok := principal.Role(c)
+			if !ok {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, unauthorizedRealm)
+				return domainerror.Unauthenticated("api key with %s role is required", required)
+			}
+			if !role.Allows(required) {
+				return domainerror.Forbidden("%s role is required, request is made with %s role", required, role)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requireSession rejects requests of anyone but users logged in with osu!
+func requireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if principal.Session(c) == nil {
+			return domainerror.Unauthenticated("log in with osu! first")
+		}
+
+		return next(c)
+	}
+}
+
+// audit records every write request with its outcome, failing to record it is only logged
+// since the write itself has already happened
+func audit(lg *log.Logger, recorder auditRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !isWriteMethod(req.Method) {
+				return next(c)
+			}
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				status = mapErrorToProblem(err).Status
+			}
+
+			entry := &dto.AuditEntry{
+				Actor:     anonymousActor,
+				Method:    req.Method,
+				Path:      c.Path(),
+				URI:       req.RequestURI,
+				Status:    status,
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			if key := principal.APIKey(c); key != nil {
+				entry.APIKeyID = &key.ID
+				entry.Actor = key.Name
+			} else if session := principal.Session(c); session != nil {
+				entry.Actor = fmt.Sprintf("osu:%s (%d)", session.Username, session.UserID)
+			}
+
+			if recordErr := recorder.Record(context.WithoutCancel(req.Context()), entry); recordErr != nil {
+				lg.WithField("request_id", entry.RequestID).
+					Errorf("failed to record audit entry of %s %s: %v", req.Method, req.URL.Path, recordErr)
+			}
+
+			return err
+		}
+	}
+}
+
+// requestAPIKey takes key from X-API-Key header or from bearer authorization
+func requestAPIKey(req *http.Request) string {
+	if key := req.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	authorization := req.Header.Get(echo.HeaderAuthorization)
+	if len(authorization) > len(bearerScheme) && strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+		return strings.TrimSpace(authorization[len(bearerScheme):])
+	}
+
+	return ""
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/http/principal"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthenticator struct {
+	keys map[string]*dto.APIKey
+}
+
+func (a *fakeAuthenticator) Authenticate(_ context.Context, plaintext string) (*dto.APIKey, error) {
+	key, ok := a.keys[plaintext]
+	if !ok {
+		return nil, domainerror.Unauthenticated("invalid api key")
+	}
+
+	return key, nil
+}
+
+type fakeSessionAuthenticator struct {
+	sessions map[string]*dto.Session
+}
+
+func (a *fakeSessionAuthenticator) Authenticate(_ context.Context, plaintext string) (*dto.Session, error) {
+	session, ok := a.sessions[plaintext]
+	if !ok {
+		return nil, domainerror.Unauthenticated("session not found")
+	}
+
+	return session, nil
+}
+
+type fakeAuditRecorder struct {
+	entries []*dto.AuditEntry
+}
+
+func (r *fakeAuditRecorder) Record(_ context.Context, entry *dto.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func newAuthTestServer(recorder *fakeAuditRecorder) *echo.Echo {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	auth := &fakeAuthenticator{keys: map[string]*dto.APIKey{
+		"viewer-key": {ID: 1, Name: "dashboard", Prefix: "viewer-k", Role: string(model.RoleViewer)},
+		"editor-key": {ID: 2, Name: "sync script", Prefix: "editor-k", Role: string(model.RoleEditor)},
+		"admin-key":  {ID: 3, Name: "admin", Prefix: "admin-ke", Role: string(model.RoleAdmin)},
+	}}
+
+	sessions := &fakeSessionAuthenticator{sessions: map[string]*dto.Session{
+		"session-token": {UserID: 7, Username: "mapper"},
+	}}
+
+	e := echo.New()
+	e.HTTPErrorHandler = newErrorHandler(lg)
+	e.Use(middleware.RequestID())
+	e.Use(authenticate(auth, sessions))
+	e.Use(audit(lg, recorder))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/public", ok)
+	e.GET("/read", ok, requireRole(model.RoleViewer))
+	e.POST("/write/:id", ok, requireRole(model.RoleEditor))
+	e.DELETE("/admin", ok, requireRole(model.RoleAdmin))
+	e.PUT("/me", ok, requireSession)
+	e.POST("/broken", func(echo.Context) error {
+		return domainerror.NotFound("nothing here")
+	}, requireRole(model.RoleEditor))
+
+	return e
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		value      string
+		session    string
+		wantStatus int
+	}{
+		{name: "public without key", method: http.MethodGet, path: "/public", wantStatus: http.StatusNoContent},
+		{name: "read without key", method: http.MethodGet, path: "/read", wantStatus: http.StatusUnauthorized},
+		{name: "read with viewer key", method: http.MethodGet, path: "/read", header: apiKeyHeader, value: "viewer-key", wantStatus: http.StatusNoContent},
+		{name: "read with higher role", method: http.MethodGet, path: "/read", header: apiKeyHeader, value: "admin-key", wantStatus: http.StatusNoContent},
+		{name: "write with viewer key", method: http.MethodPost, path: "/write/1", header: apiKeyHeader, value: "viewer-key", wantStatus: http.StatusForbidden},
+		{name: "write with bearer editor key", method: http.MethodPost, path: "/write/1", header: echo.HeaderAuthorization, value: "Bearer editor-key", wantStatus: http.StatusNoContent},
+		{name: "admin with editor key", method: http.MethodDelete, path: "/admin", header: apiKeyHeader, value: "editor-key", wantStatus: http.StatusForbidden},
+		{name: "admin with admin key", method: http.MethodDelete, path: "/admin", header: apiKeyHeader, value: "admin-key", wantStatus: http.StatusNoContent},
+		{name: "unknown key on public route", method: http.MethodGet, path: "/public", header: apiKeyHeader, value: "nope", wantStatus: http.StatusUnauthorized},
+		{name: "other authorization scheme", method: http.MethodGet, path: "/read", header: echo.HeaderAuthorization, value: "Basic editor-key", wantStatus: http.StatusUnauthorized},
+		{name: "read with session", method: http.MethodGet, path: "/read", session: "session-token", wantStatus: http.StatusNoContent},
+		{name: "write with session", method: http.MethodPost, path: "/write/1", session: "session-token", wantStatus: http.StatusForbidden},
+		{name: "own settings with session", method: http.MethodPut, path: "/me", session: "session-token", wantStatus: http.StatusNoContent},
+		{name: "own settings with api key", method: http.MethodPut, path: "/me", header: apiKeyHeader, value: "admin-key", wantStatus: http.StatusUnauthorized},
+		{name: "expired session on public route", method: http.MethodGet, path: "/public", session: "expired", wantStatus: http.StatusNoContent},
+		{name: "expired session on read route", method: http.MethodGet, path: "/read", session: "expired", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newAuthTestServer(&fakeAuditRecorder{})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			if tt.session != "" {
+				req.AddCookie(&http.Cookie{Name: principal.SessionCookie, Value: tt.session})
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, problemContentType, rec.Header().Get(echo.HeaderContentType))
+			}
+		})
+	}
+}
+
+func TestAuditRecordsWrites(t *testing.T) {
+	recorder := &fakeAuditRecorder{}
+	e := newAuthTestServer(recorder)
+
+	requests := []struct {
+		method  string
+		target  string
+		key     string
+		session string
+	}{
+		{method: http.MethodGet, target: "/read", key: "viewer-key"},
+		{method: http.MethodPost, target: "/write/7?dry=1", key: "editor-key"},
+		{method: http.MethodPost, target: "/write/8"},
+		{method: http.MethodPost, target: "/broken", key: "admin-key"},
+		{method: http.MethodPut, target: "/me", session: "session-token"},
+	}
+	for _, r := range requests {
+		req := httptest.NewRequest(r.method, r.target, nil)
+		if r.key != "" {
+			req.Header.Set(apiKeyHeader, r.key)
+		}
+		if r.session != "" {
+			req.AddCookie(&http.Cookie{Name: principal.SessionCookie, Value: r.session})
+		}
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, recorder.entries, 4)
+
+	written := recorder.entries[0]
+	require.NotNil(t, written.APIKeyID)
+	assert.Equal(t, 2, *written.APIKeyID)
+	assert.Equal(t, "sync script", written.Actor)
+	assert.Equal(t, http.MethodPost, written.Method)
+	assert.Equal(t, "/write/:id", written.Path)
+	assert.Equal(t, "/write/7?dry=1", written.URI)
+	assert.Equal(t, http.StatusNoContent, written.Status)
+	assert.NotEmpty(t, written.RequestID)
+
+	anonymous := recorder.entries[1]
+	assert.Nil(t, anonymous.APIKeyID)
+	assert.Equal(t, anonymousActor, anonymous.Actor)
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Status)
+
+	failed := recorder.entries[2]
+	assert.Equal(t, "admin", failed.Actor)
+	assert.Equal(t, http.StatusNotFound, failed.Status)
+
+	mapper := recorder.entries[3]
+	assert.Nil(t, mapper.APIKeyID)
+	assert.Equal(t, "osu:mapper (7)", mapper.Actor)
+}
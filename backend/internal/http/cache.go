@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/http/principal"
+	"strings"
+	"time"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+type cacheInvalidator interface {
+	Invalidate()
+}
+
+// invalidateCache purges cached results after successful edits made through api, otherwise
+// reads would not show them until the next tracking run
+func invalidateCache(cache cacheInvalidator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return err
+			}
+			if err == nil && c.Response().Status < http.StatusBadRequest {
+				cache.Invalidate()
+			}
+
+			return err
+		}
+	}
+}
+
+// conditionalGet sends ETag and Cache-Control with successful responses of read routes and answers
+// requests whose If-None-Match holds the same ETag with 304 Not Modified. responses are buffered
+// to be hashed, ETag changes only when body does
+func conditionalGet(maxAge time.Duration, requireViewer bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Method != http.MethodGet {
+				return next(c)
+			}
+
+			res := c.Response()
+			writer := res.Writer
+			recorder := &bodyRecorder{ResponseWriter: writer}
+			res.Writer = recorder
+			err := next(c)
+			res.Writer = writer
+			if err != nil {
+				return err
+			}
+
+			if recorder.status != http.StatusOK {
+				return recorder.flush()
+			}
+
+			sum := sha256.Sum256(recorder.body.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+			// responses depend on who asks for them once viewer role or session is involved
+			visibility := "public"
+			if _, ok := principal.Role(c); ok || requireViewer {
+				visibility = "private"
+			}
+
+			header := writer.Header()
+			header.Set(headerETag, etag)
+			header.Set(echo.HeaderCacheControl, fmt.Sprintf("%s, max-age=%d", visibility, int(maxAge.Seconds())))
+			header.Add(echo.HeaderVary, echo.HeaderCookie)
+			header.Add(echo.HeaderVary, echo.HeaderAuthorization)
+			header.Add(echo.HeaderVary, apiKeyHeader)
+
+			if etagMatches(c.Request().Header.Get(headerIfNoneMatch), etag) {
+				header.Del(echo.HeaderContentType)
+				header.Del(echo.HeaderContentLength)
+				res.Status = http.StatusNotModified
+				writer.WriteHeader(http.StatusNotModified)
+				return nil
+			}
+
+			return recorder.flush()
+		}
+	}
+}
+
+// etagMatches reports whether If-None-Match header lists etag, weak comparison is used as it is for GET
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bodyRecorder holds response back until it is known whether it has to be sent
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bodyRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.body.Write(b)
+}
+
+// flush sends held back response as it is
+func (r *bodyRecorder) flush() error {
+	if r.status == 0 {
+		return nil
+	}
+
+	r.ResponseWriter.WriteHeader(r.status)
+	_, err := r.ResponseWriter.Write(r.body.Bytes())
+
+	return err
+}
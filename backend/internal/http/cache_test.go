@@ -0,0 +1,106 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCacheInvalidator struct {
+	invalidated int
+}
+
+func (i *fakeCacheInvalidator) Invalidate() {
+	i.invalidated++
+}
+
+func TestConditionalGet(t *testing.T) {
+	e := echo.New()
+	body := map[string]int{"play_count": 100}
+	e.GET("/stats", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, body)
+	}, conditionalGet(time.Minute, false))
+	e.GET("/missing", func(c echo.Context) error {
+		return echo.ErrNotFound
+	}, conditionalGet(time.Minute, false))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"play_count":100}`, rec.Body.String())
+	assert.Equal(t, "public, max-age=60", rec.Header().Get(echo.HeaderCacheControl))
+	etag := rec.Header().Get(headerETag)
+	require.NotEmpty(t, etag)
+
+	// same body is not sent again
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set(headerIfNoneMatch, `"stale", W/`+etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get(headerETag))
+
+	// changed body gets another etag
+	body["play_count"] = 101
+	req = httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set(headerIfNoneMatch, etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get(headerETag))
+
+	// handlers answering 304 themselves, e.g. feeds by If-Modified-Since, are passed through
+	e.GET("/feed", func(c echo.Context) error {
+		if c.Request().Header.Get(echo.HeaderIfModifiedSince) != "" {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.Blob(http.StatusOK, "application/atom+xml", []byte("<feed></feed>"))
+	}, conditionalGet(time.Minute, false))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(headerETag))
+
+	req = httptest.NewRequest(http.MethodGet, "/feed", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, "Tue, 02 Jan 2024 10:30:15 GMT")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	// errors are sent without etag
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get(headerETag))
+}
+
+func TestInvalidateCache(t *testing.T) {
+	invalidator := &fakeCacheInvalidator{}
+	e := echo.New()
+	e.Use(invalidateCache(invalidator))
+	e.GET("/list", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.POST("/create", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+	e.POST("/invalid", func(c echo.Context) error { return echo.ErrBadRequest })
+
+	for _, tc := range []struct {
+		method string
+		target string
+	}{
+		{http.MethodGet, "/list"},
+		{http.MethodPost, "/invalid"},
+		{http.MethodPost, "/create"},
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.target, nil))
+	}
+
+	// only successful edit purges cache
+	assert.Equal(t, 1, invalidator.invalidated)
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// problemTypePrefix is followed by domain error kind, plain http errors use about:blank as RFC 7807 suggests
+	problemTypePrefix = "urn:playcount-monitor:problem:"
+	problemTypeBlank  = "about:blank"
+)
+
+// Problem is RFC 7807 body of every error response
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+var domainErrorStatuses = map[domainerror.Kind]int{
+	domainerror.KindNotFound:        http.StatusNotFound,
+	domainerror.KindConflict:        http.StatusConflict,
+	domainerror.KindValidation:      http.StatusBadRequest,
+	domainerror.KindUnavailable:     http.StatusServiceUnavailable,
+	domainerror.KindUnauthenticated: http.StatusUnauthorized,
+	domainerror.KindForbidden:       http.StatusForbidden,
+}
+
+// newErrorHandler renders errors returned by handlers as problems, details of unexpected errors
+// are only logged with request id so they can be found by what client got
+func newErrorHandler(lg *log.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		req := c.Request()
+		problem := mapErrorToProblem(err)
+		problem.Instance = req.URL.Path
+		problem.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+
+		if problem.Status >= http.StatusInternalServerError {
+			lg.WithField("request_id", problem.RequestID).
+				WithField("status", problem.Status).
+				Errorf("%s %s failed: %v", req.Method, req.URL.Path, err)
+		}
+
+		var writeErr error
+		if req.Method == http.MethodHead {
+			writeErr = c.NoContent(problem.Status)
+		} else {
+			c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+			writeErr = c.JSON(problem.Status, problem)
+		}
+		if writeErr != nil {
+			lg.Errorf("failed to write error response: %v", writeErr)
+		}
+	}
+}
+
+func mapErrorToProblem(err error) *Problem {
+	if domainErr, ok := domainerror.As(err); ok {
+		status, ok := domainErrorStatuses[domainErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+
+		return &Problem{
+			Type:   problemTypePrefix + string(domainErr.Kind),
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: domainErr.Detail,
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		problem := &Problem{
+			Type:   problemTypeBlank,
+			Title:  http.StatusText(httpErr.Code),
+			Status: httpErr.Code,
+		}
+		// default messages only repeat the title
+		if message, ok := httpErr.Message.(string); ok && message != problem.Title {
+			problem.Detail = message
+		}
+
+		return problem
+	}
+
+	return &Problem{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+	}
+}
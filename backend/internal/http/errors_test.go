@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		want       Problem
+	}{
+		{
+			name:       "wrapped domain not found",
+			err:        fmt.Errorf("tx failed: %w", domainerror.NotFound("user %v not found", 7)),
+			wantStatus: http.StatusNotFound,
+			want: Problem{
+				Type:   "urn:playcount-monitor:problem:not_found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "user 7 not found",
+			},
+		},
+		{
+			name:       "upstream cause is not shown",
+			err:        domainerror.Unavailable(errors.New("dial tcp: connection refused"), "failed to fetch user %v from osu! api", 7),
+			wantStatus: http.StatusServiceUnavailable,
+			want: Problem{
+				Type:   "urn:playcount-monitor:problem:upstream_unavailable",
+				Title:  "Service Unavailable",
+				Status: http.StatusServiceUnavailable,
+				Detail: "failed to fetch user 7 from osu! api",
+			},
+		},
+		{
+			name:       "conflict",
+			err:        domainerror.Conflict(errors.New("duplicate key"), "user %v is already followed", 7),
+			wantStatus: http.StatusConflict,
+			want: Problem{
+				Type:   "urn:playcount-monitor:problem:conflict",
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "user 7 is already followed",
+			},
+		},
+		{
+			name:       "http error with message",
+			err:        echo.NewHTTPError(http.StatusBadRequest, `invalid query parameter "page"`),
+			wantStatus: http.StatusBadRequest,
+			want: Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: `invalid query parameter "page"`,
+			},
+		},
+		{
+			name:       "default http error",
+			err:        echo.ErrBadRequest,
+			wantStatus: http.StatusBadRequest,
+			want: Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+			},
+		},
+		{
+			name:       "unexpected error is hidden",
+			err:        errors.New("failed to get user with id 7: pq: connection reset"),
+			wantStatus: http.StatusInternalServerError,
+			want: Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lg := log.New()
+			lg.SetOutput(io.Discard)
+
+			e := echo.New()
+			e.HTTPErrorHandler = newErrorHandler(lg)
+			e.Use(middleware.RequestID())
+			e.GET("api/test", func(c echo.Context) error {
+				return tt.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, problemContentType, rec.Header().Get(echo.HeaderContentType))
+
+			var got Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+
+			tt.want.Instance = "/api/test"
+			tt.want.RequestID = "req-1"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestErrorHandler_unknownRoute(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = newErrorHandler(log.New())
+	e.Use(middleware.RequestID())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var got Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "Not Found", got.Title)
+	assert.NotEmpty(t, got.RequestID)
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), got.RequestID)
+}
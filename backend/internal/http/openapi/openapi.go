@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"strings"
+)
+
+// openapi.json describes every route of setupRoutes, routes_test.go fails when they diverge
+//
+//go:embed openapi.json
+var document []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// Document returns OpenAPI document as it is served to clients
+func Document() []byte {
+	return document
+}
+
+// DocsPage returns html page rendering the document
+func DocsPage() []byte {
+	return docsPage
+}
+
+// Load parses and validates the document
+func Load(ctx context.Context) (*openapi3.T, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi document: %w", err)
+	}
+
+	if err := spec.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+
+	return spec, nil
+}
+
+// SpecPath converts echo route path like api/user/:id to OpenAPI path template /api/user/{id}
+func SpecPath(echoPath string) string {
+	segments := strings.Split(strings.TrimPrefix(echoPath, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+// ValidateRequests checks path and query parameters and bodies against operation of the matched route,
+// requests to routes missing from the document are passed through as is
+func ValidateRequests(spec *openapi3.T) echo.MiddlewareFunc {
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := findRoute(spec, c.Request().Method, c.Path())
+			if route == nil {
+				return next(c)
+			}
+
+			names, values := c.ParamNames(), c.ParamValues()
+			pathParams := make(map[string]string, len(names))
+			for i, name := range names {
+				pathParams[name] = values[i]
+			}
+
+			err := openapi3filter.ValidateRequest(c.Request().Context(), &openapi3filter.RequestValidationInput{
+				Request:    c.Request(),
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, validationMessage(err))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func findRoute(spec *openapi3.T, method string, echoPath string) *routers.Route {
+	if echoPath == "" {
+		return nil
+	}
+
+	path := SpecPath(echoPath)
+	pathItem := spec.Paths[path]
+	if pathItem == nil {
+		return nil
+	}
+
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil
+	}
+
+	return &routers.Route{
+		Spec:      spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: operation,
+	}
+}
+
+// validationMessage keeps failing field and reason, default error text dumps the whole schema
+func validationMessage(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	reason := requestErr.Reason
+	var schemaErr *openapi3.SchemaError
+	if errors.As(requestErr.Err, &schemaErr) {
+		reason = schemaErr.Reason
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			reason = fmt.Sprintf("%s: %s", strings.Join(pointer, "."), reason)
+		}
+	} else if requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+
+	switch {
+	case requestErr.Parameter != nil:
+		return fmt.Sprintf("invalid %s parameter %q: %s", requestErr.Parameter.In, requestErr.Parameter.Name, reason)
+	case requestErr.RequestBody != nil:
+		return fmt.Sprintf("invalid request body: %s", reason)
+	default:
+		return reason
+	}
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	spec, err := Load(context.Background())
+	require.NoError(t, err)
+
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	echoBody := func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.Blob(http.StatusCreated, echo.MIMEApplicationJSON, body)
+	}
+
+	e := echo.New()
+	e.Use(ValidateRequests(spec))
+	e.GET("api/user/:id", ok)
+	e.GET("api/user/list", ok)
+	e.GET("api/beatmapset/list", ok)
+	e.GET("api/beatmapset/search", ok)
+	e.GET("api/export/stats", ok)
+	e.GET("api/undocumented", ok)
+	e.POST("api/digests", echoBody)
+
+	return e
+}
+
+func TestValidateRequests(t *testing.T) {
+	e := newTestServer(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{name: "valid path param", method: http.MethodGet, target: "/api/user/7", wantStatus: http.StatusOK},
+		{name: "non numeric path param", method: http.MethodGet, target: "/api/user/abc", wantStatus: http.StatusBadRequest, wantMessage: `invalid path parameter \"id\"`},
+		{name: "static route is not matched as path param", method: http.MethodGet, target: "/api/user/list", wantStatus: http.StatusOK},
+		{name: "valid query", method: http.MethodGet, target: "/api/beatmapset/list?page=2&sort=last_playcount&direction=desc&status=ranked", wantStatus: http.StatusOK},
+		{name: "page below minimum", method: http.MethodGet, target: "/api/beatmapset/list?page=0", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"page\"`},
+		{name: "valid cursor page", method: http.MethodGet, target: "/api/user/list?limit=20&cursor=eyJvIjoidXNlcm5hbWUgQVNDIn0", wantStatus: http.StatusOK},
+		{name: "limit above maximum", method: http.MethodGet, target: "/api/beatmapset/list?limit=500", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"limit\"`},
+		{name: "latest stats listing", method: http.MethodGet, target: "/api/beatmapset/list?stats=latest&sort=last_passcount&direction=desc", wantStatus: http.StatusOK},
+		{name: "unknown stats listing", method: http.MethodGet, target: "/api/beatmapset/search?stats=all", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"stats\"`},
+		{name: "unknown enum value", method: http.MethodGet, target: "/api/beatmapset/list?status=unknown", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"status\"`},
+		{name: "valid search", method: http.MethodGet, target: "/api/beatmapset/search?q=camellia&status=ranked,loved&stars_min=5.5&sort=relevance", wantStatus: http.StatusOK},
+		{name: "unknown enum value in list", method: http.MethodGet, target: "/api/beatmapset/search?status=ranked,unknown", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"status\"`},
+		{name: "non numeric range", method: http.MethodGet, target: "/api/beatmapset/search?bpm_min=fast", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"bpm_min\"`},
+		{name: "valid export", method: http.MethodGet, target: "/api/export/stats?format=parquet&entity=user,beatmap&from=2024-01-01", wantStatus: http.StatusOK},
+		{name: "unknown export format", method: http.MethodGet, target: "/api/export/stats?format=xlsx", wantStatus: http.StatusBadRequest, wantMessage: `invalid query parameter \"format\"`},
+		{name: "route missing from document", method: http.MethodGet, target: "/api/undocumented?page=abc", wantStatus: http.StatusOK},
+		{name: "valid body", method: http.MethodPost, target: "/api/digests", body: `{"email":"a@example.com","frequency":"daily","user_ids":[1]}`, wantStatus: http.StatusCreated},
+		{name: "body field out of enum", method: http.MethodPost, target: "/api/digests", body: `{"email":"a@example.com","frequency":"hourly"}`, wantStatus: http.StatusBadRequest, wantMessage: "invalid request body: frequency"},
+		{name: "missing required body field", method: http.MethodPost, target: "/api/digests", body: `{"frequency":"daily"}`, wantStatus: http.StatusBadRequest, wantMessage: "invalid request body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.target, body)
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantMessage != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantMessage)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				// body is still readable by handler after validation
+				assert.JSONEq(t, tt.body, rec.Body.String())
+			}
+		})
+	}
+}
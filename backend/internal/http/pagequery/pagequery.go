@@ -0,0 +1,40 @@
+// Package pagequery reads paging query params shared by list endpoints
+package pagequery
+
+import (
+	"github.com/labstack/echo/v4"
+	"playcount-monitor-backend/internal/usecase/pagination"
+	"strconv"
+)
+
+// Parse reads page, limit and cursor params, malformed or non-positive numbers are bad request
+func Parse(c echo.Context) (pagination.Query, error) {
+	q := pagination.Query{Page: 1, Cursor: c.QueryParam("cursor")}
+
+	var err error
+	if q.Page, err = positiveParam(c, "page", 1); err != nil {
+		return pagination.Query{}, echo.ErrBadRequest
+	}
+	if q.Limit, err = positiveParam(c, "limit", 0); err != nil {
+		return pagination.Query{}, echo.ErrBadRequest
+	}
+
+	return q, nil
+}
+
+func positiveParam(c echo.Context, name string, fallback int) (int, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, strconv.ErrRange
+	}
+
+	return value, nil
+}
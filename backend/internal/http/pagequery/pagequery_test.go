@@ -0,0 +1,41 @@
+package pagequery
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/usecase/pagination"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name     string
+		query    string
+		expected pagination.Query
+		wantErr  bool
+	}{
+		{name: "Defaults", query: "", expected: pagination.Query{Page: 1}},
+		{name: "All params", query: "?page=3&limit=20&cursor=abc", expected: pagination.Query{Page: 3, Limit: 20, Cursor: "abc"}},
+		{name: "Zero page", query: "?page=0", wantErr: true},
+		{name: "Negative limit", query: "?limit=-5", wantErr: true},
+		{name: "Malformed limit", query: "?limit=ten", wantErr: true},
+	}
+
+	e := echo.New()
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/audit"+tc.query, nil), httptest.NewRecorder())
+
+			q, err := Parse(c)
+			if tc.wantErr {
+				assert.Equal(t, echo.ErrBadRequest, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, q)
+		})
+	}
+}
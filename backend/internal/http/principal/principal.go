@@ -0,0 +1,50 @@
+// Package principal keeps who made the request in echo context, it is set by authentication middleware
+// and read by handlers acting on behalf of logged in user
+package principal
+
+import (
+	"github.com/labstack/echo/v4"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/dto"
+)
+
+// SessionCookie holds session token of user logged in with osu!
+const SessionCookie = "pm_session"
+
+const (
+	apiKeyContextKey  = "api_key"
+	sessionContextKey = "session"
+)
+
+func SetAPIKey(c echo.Context, key *dto.APIKey) {
+	c.Set(apiKeyContextKey, key)
+}
+
+// APIKey is key request was authenticated with, nil when it had none
+func APIKey(c echo.Context) *dto.APIKey {
+	key, _ := c.Get(apiKeyContextKey).(*dto.APIKey)
+	return key
+}
+
+func SetSession(c echo.Context, session *dto.Session) {
+	c.Set(sessionContextKey, session)
+}
+
+// Session is session of logged in user, nil when request has no valid session cookie
+func Session(c echo.Context) *dto.Session {
+	session, _ := c.Get(sessionContextKey).(*dto.Session)
+	return session
+}
+
+// Role is role request is made with, api key role takes precedence over session,
+// which only grants viewer role since mappers manage nothing but their own settings
+func Role(c echo.Context) (model.Role, bool) {
+	if key := APIKey(c); key != nil {
+		return model.Role(key.Role), true
+	}
+	if Session(c) != nil {
+		return model.RoleViewer, true
+	}
+
+	return "", false
+}
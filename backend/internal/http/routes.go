@@ -1,17 +1,95 @@
 package http
 
+import (
+	"github.com/labstack/echo/v4"
+	"playcount-monitor-backend/internal/database/repository/model"
+)
+
 func (s *Server) setupRoutes() {
+	viewer := s.readAccess()
+	editor := requireRole(model.RoleEditor)
+	admin := requireRole(model.RoleAdmin)
+
 	s.server.GET("api/ping", s.ping.Ping)
+	s.server.GET("api/openapi.json", s.docs.GetDocument)
+	s.server.GET("api/docs", s.docs.GetDocs)
+
+	s.server.GET("api/auth/osu/login", s.session.Login)
+	s.server.GET("api/auth/osu/callback", s.session.Callback)
+	s.server.POST("api/auth/logout", s.session.Logout)
+
+	s.server.GET("api/me", s.account.Get, requireSession)
+	s.server.PUT("api/me/following", s.account.Follow, requireSession)
+	s.server.DELETE("api/me/following", s.account.Unfollow, requireSession)
+
+	s.server.GET("api/watchlists", s.watchlist.List, requireSession)
+	s.server.POST("api/watchlists", s.watchlist.Create, requireSession)
+	s.server.GET("api/watchlists/:id", s.watchlist.Get, requireSession)
+	s.server.DELETE("api/watchlists/:id", s.watchlist.Delete, requireSession)
+	s.server.PUT("api/watchlists/:id/entries", s.watchlist.PutEntry, requireSession)
+	s.server.DELETE("api/watchlists/:id/entries/:type/:entity_id", s.watchlist.DeleteEntry, requireSession)
+
+	s.server.GET("api/user/:id", s.user.Get, viewer...)
+	s.server.GET("api/user/list", s.user.List, viewer...)
+
+	s.server.GET("api/following/list", s.following.List, viewer...)
+	s.server.POST("api/following/create", s.following.Create, editor)
+
+	s.server.GET("api/beatmapset/:id", s.mapset.Get, viewer...)
+	s.server.GET("api/beatmapset/list", s.mapset.List, viewer...)
+	s.server.GET("api/beatmapset/list_for_user/:id", s.mapset.ListForUser, viewer...)
+	s.server.GET("api/beatmapset/list_guest_for_user/:id", s.mapset.ListGuestForUser, viewer...)
+	s.server.GET("api/beatmapset/trending", s.mapset.ListTrending, viewer...)
+	s.server.GET("api/beatmapset/search", s.mapset.Search, viewer...)
+
+	s.server.GET("api/watched_mapsets", s.watched.List, viewer...)
+	s.server.POST("api/watched_mapsets", s.watched.Create, editor)
+	s.server.DELETE("api/watched_mapsets/:id", s.watched.Delete, editor)
+
+	s.server.GET("api/user/statistic/:id", s.statistic.GetUserMapStatistics, viewer...)
 
-	s.server.GET("api/user/:id", s.user.Get)
-	s.server.GET("api/user/list", s.user.List)
+	s.server.GET("api/analytics/user/:id", s.analytics.GetForUser, viewer...)
+	s.server.GET("api/analytics/beatmapset/:id", s.analytics.GetForMapset, viewer...)
+	s.server.GET("api/analytics/beatmap/:id", s.analytics.GetForBeatmap, viewer...)
 
-	s.server.GET("api/following/list", s.following.List)
-	s.server.POST("api/following/create", s.following.Create)
+	// exports are streamed, they bypass buffering of conditional get
+	s.server.GET("api/export/stats", s.export.GetStats, s.streamAccess()...)
+
+	s.server.GET("api/anomalies", s.anomaly.List, viewer...)
+	s.server.GET("api/milestones", s.milestone.List, viewer...)
+
+	// webhook and digest subscriptions hold endpoints and emails, so even listing them needs editor role
+	s.server.GET("api/webhooks", s.webhook.List, editor)
+	s.server.POST("api/webhooks", s.webhook.Create, editor)
+	s.server.PUT("api/webhooks/:id", s.webhook.Update, editor)
+	s.server.DELETE("api/webhooks/:id", s.webhook.Delete, editor)
+	s.server.GET("api/webhooks/:id/deliveries", s.webhook.ListDeliveries, editor)
+
+	s.server.GET("api/feed/atom", s.feed.GetGlobal, viewer...)
+	s.server.GET("api/feed/user/:id/atom", s.feed.GetForUser, viewer...)
+
+	s.server.GET("api/digests", s.digest.List, editor)
+	s.server.POST("api/digests", s.digest.Create, editor)
+	s.server.DELETE("api/digests/:id", s.digest.Delete, editor)
+
+	s.server.GET("api/keys", s.apiKey.List, admin)
+	s.server.POST("api/keys", s.apiKey.Create, admin)
+	s.server.DELETE("api/keys/:id", s.apiKey.Revoke, admin)
+
+	s.server.GET("api/audit", s.audit.List, admin)
+}
+
+// readAccess is middleware of read routes, they are public unless viewer role is required by config.
+// their responses are sent with ETag so that clients can revalidate them
+func (s *Server) readAccess() []echo.MiddlewareFunc {
+	return append(s.streamAccess(), conditionalGet(s.cfg.HTTPCacheMaxAge, s.cfg.AuthRequireViewer))
+}
 
-	s.server.GET("api/beatmapset/:id", s.mapset.Get)
-	s.server.GET("api/beatmapset/list", s.mapset.List)
-	s.server.GET("api/beatmapset/list_for_user/:id", s.mapset.ListForUser)
+// streamAccess is readAccess without ETag, for routes whose responses are too large to buffer
+func (s *Server) streamAccess() []echo.MiddlewareFunc {
+	if !s.cfg.AuthRequireViewer {
+		return nil
+	}
 
-	s.server.GET("api/user/statistic/:id", s.statistic.GetUserMapStatistics)
+	return []echo.MiddlewareFunc{requireRole(model.RoleViewer)}
 }
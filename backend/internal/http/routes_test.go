@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/http/openapi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesMatchOpenAPIDocument fails when route is added without describing it in openapi.json or the other way around
+func TestRoutesMatchOpenAPIDocument(t *testing.T) {
+	spec, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+
+	s := &Server{cfg: &config.Config{}, server: echo.New()}
+	s.setupRoutes()
+
+	routed := make(map[string]bool)
+	for _, route := range s.server.Routes() {
+		path := openapi.SpecPath(route.Path)
+		routed[route.Method+" "+path] = true
+
+		pathItem := spec.Paths[path]
+		if !assert.NotNil(t, pathItem, "route %s %s is missing from openapi document", route.Method, path) {
+			continue
+		}
+		assert.NotNil(t, pathItem.GetOperation(route.Method), "route %s %s is missing from openapi document", route.Method, path)
+	}
+
+	for path, pathItem := range spec.Paths {
+		for method := range pathItem.Operations() {
+			assert.True(t, routed[method+" "+path], "operation %s %s is not routed", method, path)
+		}
+	}
+
+	assert.True(t, routed[http.MethodGet+" /api/openapi.json"])
+}
@@ -6,13 +6,28 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/app/accountserviceapi"
+	"playcount-monitor-backend/internal/app/analyticsserviceapi"
+	"playcount-monitor-backend/internal/app/anomalyserviceapi"
+	"playcount-monitor-backend/internal/app/apikeyserviceapi"
+	"playcount-monitor-backend/internal/app/auditserviceapi"
+	"playcount-monitor-backend/internal/app/digestserviceapi"
+	"playcount-monitor-backend/internal/app/exportserviceapi"
+	"playcount-monitor-backend/internal/app/feedserviceapi"
 	"playcount-monitor-backend/internal/app/followingserviceapi"
 	"playcount-monitor-backend/internal/app/mapsetserviceapi"
+	"playcount-monitor-backend/internal/app/milestoneserviceapi"
+	"playcount-monitor-backend/internal/app/openapiserviceapi"
 	"playcount-monitor-backend/internal/app/pingserviceapi"
+	"playcount-monitor-backend/internal/app/sessionserviceapi"
 	"playcount-monitor-backend/internal/app/statisticserviceapi"
 	"playcount-monitor-backend/internal/app/usercardserviseapi"
 	"playcount-monitor-backend/internal/app/userserviceapi"
+	"playcount-monitor-backend/internal/app/watchedmapsetserviceapi"
+	"playcount-monitor-backend/internal/app/watchlistserviceapi"
+	"playcount-monitor-backend/internal/app/webhookserviceapi"
 	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/http/openapi"
 	"playcount-monitor-backend/internal/usecase/factory"
 )
 
@@ -26,6 +41,20 @@ type Server struct {
 	following *followingserviceapi.ServiceImpl
 	mapset    *mapsetserviceapi.ServiceImpl
 	statistic *statisticserviceapi.ServiceImpl
+	analytics *analyticsserviceapi.ServiceImpl
+	anomaly   *anomalyserviceapi.ServiceImpl
+	milestone *milestoneserviceapi.ServiceImpl
+	webhook   *webhookserviceapi.ServiceImpl
+	feed      *feedserviceapi.ServiceImpl
+	digest    *digestserviceapi.ServiceImpl
+	docs      *openapiserviceapi.ServiceImpl
+	apiKey    *apikeyserviceapi.ServiceImpl
+	audit     *auditserviceapi.ServiceImpl
+	session   *sessionserviceapi.ServiceImpl
+	account   *accountserviceapi.ServiceImpl
+	watchlist *watchlistserviceapi.ServiceImpl
+	watched   *watchedmapsetserviceapi.ServiceImpl
+	export    *exportserviceapi.ServiceImpl
 }
 
 func New(
@@ -34,7 +63,18 @@ func New(
 	server := echo.New()
 	server.HideBanner = true
 	server.HidePort = true
+	server.HTTPErrorHandler = newErrorHandler(lg)
+	server.Use(middleware.RequestID())
 	server.Use(middleware.CORS())
+	server.Use(authenticate(f.MakeManageAPIKeyUseCase(), f.MakeManageSessionUseCase()))
+	server.Use(audit(lg, f.MakeCreateAuditUseCase()))
+	server.Use(invalidateCache(f.MakeCacheInvalidator()))
+
+	spec, err := openapi.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	server.Use(openapi.ValidateRequests(spec))
 
 	ping := pingserviceapi.New(lg)
 
@@ -69,6 +109,83 @@ func New(
 		f.MakeProvideStatisticUseCase(),
 	)
 
+	analytics := analyticsserviceapi.New(
+		lg,
+		f.MakeProvideAnalyticsUseCase(),
+	)
+
+	anomaly := anomalyserviceapi.New(
+		lg,
+		f.MakeProvideAnomalyUseCase(),
+	)
+
+	milestone := milestoneserviceapi.New(
+		lg,
+		f.MakeProvideMilestoneUseCase(),
+	)
+
+	webhook := webhookserviceapi.New(
+		lg,
+		f.MakeManageWebhookUseCase(),
+		f.MakeProvideWebhookUseCase(),
+	)
+
+	feed := feedserviceapi.New(
+		lg,
+		f.MakeProvideFeedUseCase(),
+	)
+
+	digest := digestserviceapi.New(
+		lg,
+		f.MakeManageDigestUseCase(),
+		f.MakeProvideDigestUseCase(),
+	)
+
+	docs := openapiserviceapi.New(
+		lg,
+		openapi.Document(),
+		openapi.DocsPage(),
+	)
+
+	apiKey := apikeyserviceapi.New(
+		lg,
+		f.MakeManageAPIKeyUseCase(),
+		f.MakeProvideAPIKeyUseCase(),
+	)
+
+	audit := auditserviceapi.New(
+		lg,
+		f.MakeProvideAuditUseCase(),
+	)
+
+	session := sessionserviceapi.New(
+		cfg,
+		lg,
+		f.MakeManageSessionUseCase(),
+	)
+
+	account := accountserviceapi.New(
+		lg,
+		f.MakeManageAccountUseCase(),
+	)
+
+	watchlist := watchlistserviceapi.New(
+		lg,
+		f.MakeProvideWatchlistUseCase(),
+		f.MakeManageWatchlistUseCase(),
+	)
+
+	watched := watchedmapsetserviceapi.New(
+		lg,
+		f.MakeProvideWatchedMapsetUseCase(),
+		f.MakeManageWatchedMapsetUseCase(),
+	)
+
+	export := exportserviceapi.New(
+		lg,
+		f.MakeProvideExportUseCase(),
+	)
+
 	return &Server{
 		cfg:       cfg,
 		server:    server,
@@ -79,6 +196,20 @@ func New(
 		following: following,
 		mapset:    mapset,
 		statistic: statistic,
+		analytics: analytics,
+		anomaly:   anomaly,
+		milestone: milestone,
+		webhook:   webhook,
+		feed:      feed,
+		digest:    digest,
+		docs:      docs,
+		apiKey:    apiKey,
+		audit:     audit,
+		session:   session,
+		account:   account,
+		watchlist: watchlist,
+		watched:   watched,
+		export:    export,
 	}, nil
 }
 
@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/config"
+)
+
+type (
+	Service struct {
+		cfg *config.Config
+	}
+
+	Interface interface {
+		Send(ctx context.Context, msg *Message) error
+	}
+)
+
+func New(cfg *config.Config) *Service {
+	return &Service{
+		cfg: cfg,
+	}
+}
@@ -0,0 +1,72 @@
+package mailer
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/service/mailer/mailertest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Send(t *testing.T) {
+	srv, err := mailertest.NewServer()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	s := New(&config.Config{
+		SMTPHost: srv.Host(),
+		SMTPPort: srv.Port(),
+		SMTPFrom: "monitor@example.com",
+	})
+
+	err = s.Send(context.Background(), &Message{
+		To:      []string{"mapper@example.com"},
+		Subject: "Daily digest – 2 new maps",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	})
+	require.NoError(t, err)
+
+	mails := srv.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, "monitor@example.com", mails[0].From)
+	assert.Equal(t, []string{"mapper@example.com"}, mails[0].To)
+
+	msg, err := mail.ReadMessage(strings.NewReader(mails[0].Data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Daily digest – 2 new maps", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		// quoted-printable is decoded by multipart reader
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, part.Header.Get("Content-Type")+": "+string(content))
+	}
+
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: plain body",
+		"text/html; charset=utf-8: <p>html body</p>",
+	}, parts)
+}
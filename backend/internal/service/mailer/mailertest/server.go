@@ -0,0 +1,136 @@
+// Package mailertest provides in-process smtp server for tests, it accepts every message without authentication
+package mailertest
+
+import (
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Mail is a message received by Server
+type Mail struct {
+	From string
+	To   []string
+	Data string
+}
+
+type Server struct {
+	listener net.Listener
+
+	mu    sync.Mutex
+	mails []*Mail
+	wg    sync.WaitGroup
+}
+
+// NewServer starts server on random local port
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Mails returns messages received so far
+func (s *Server) Mails() []*Mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Mail(nil), s.mails...)
+}
+
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+// handle speaks minimal subset of smtp that net/smtp client needs
+func (s *Server) handle(conn *textproto.Conn) {
+	mail := &Mail{}
+	reply := func(code int, msg string) bool {
+		return conn.PrintfLine("%s %s", strconv.Itoa(code), msg) == nil
+	}
+
+	if !reply(220, "mailertest ready") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply(250, "mailertest")
+		case "MAIL":
+			mail.From = trimAddress(arg)
+			reply(250, "ok")
+		case "RCPT":
+			mail.To = append(mail.To, trimAddress(arg))
+			reply(250, "ok")
+		case "DATA":
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(conn.DotReader())
+			if err != nil {
+				return
+			}
+			mail.Data = string(data)
+
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+
+			mail = &Mail{}
+			reply(250, "ok")
+		case "RSET", "NOOP":
+			reply(250, "ok")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+func trimAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	return strings.Trim(strings.TrimSpace(addr), "<>")
+}
@@ -0,0 +1,174 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultDiscordTemplates render embed description, subscriptions can override them per event type
+var defaultDiscordTemplates = map[EventType]string{
+	EventNewMapset: `{{.Mapset.Creator}} uploaded a new map **{{.Mapset.Artist}} - {{.Mapset.Title}}**`,
+	EventStatusChange: `{{.Mapset.Creator}}'s map **{{.Mapset.Artist}} - {{.Mapset.Title}}** ` +
+		`just got {{.Mapset.Status}}`,
+	EventMilestone: `{{if eq .Metric "first_ranked"}}{{.Username}} got their first ranked map` +
+		`{{else if .Mapset}}**{{.Mapset.Artist}} - {{.Mapset.Title}}** passed {{short .Threshold}} {{metric .Metric}}` +
+		`{{else}}{{.Username}} passed {{short .Threshold}} {{metric .Metric}}{{end}}`,
+	EventSpike:          `**{{.Mapset.Artist}} - {{.Mapset.Title}}** got {{short .Delta}} plays in a day`,
+	EventTrackingFailed: `Tracking run failed: {{.Error}}`,
+}
+
+var discordColors = map[EventType]int{
+	EventNewMapset:      0x5865f2,
+	EventStatusChange:   0x57f287,
+	EventMilestone:      0xfee75c,
+	EventSpike:          0xeb459e,
+	EventTrackingFailed: 0xed4245,
+}
+
+var discordTemplateFuncs = template.FuncMap{
+	"short":  shortNumber,
+	"metric": metricName,
+}
+
+// cover types in order of preference for embed image
+var discordCoverTypes = []string{"card", "cover", "list"}
+
+type discordMessage struct {
+	Embeds []*discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string          `json:"title,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Description string          `json:"description"`
+	Color       int             `json:"color"`
+	Timestamp   string          `json:"timestamp,omitempty"`
+	Image       *discordImage   `json:"image,omitempty"`
+	Fields      []*discordField `json:"fields,omitempty"`
+}
+
+type discordImage struct {
+	URL string `json:"url"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// ParseDiscordTemplates parses subscription overrides on top of default templates
+func ParseDiscordTemplates(overrides map[string]string) (map[EventType]*template.Template, error) {
+	templates := make(map[EventType]*template.Template, len(defaultDiscordTemplates))
+	for eventType, text := range defaultDiscordTemplates {
+		tmpl, err := template.New(string(eventType)).Funcs(discordTemplateFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse default %s template: %w", eventType, err)
+		}
+		templates[eventType] = tmpl
+	}
+
+	for eventType, text := range overrides {
+		if !IsKnownEventType(eventType) {
+			return nil, fmt.Errorf("template for unknown event %q", eventType)
+		}
+
+		tmpl, err := template.New(eventType).Funcs(discordTemplateFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", eventType, err)
+		}
+		templates[EventType(eventType)] = tmpl
+	}
+
+	return templates, nil
+}
+
+func renderDiscordMessage(templates map[EventType]*template.Template, event *Event) ([]byte, error) {
+	tmpl, ok := templates[event.Type]
+	if !ok {
+		return nil, fmt.Errorf("no template for %s event", event.Type)
+	}
+
+	var description strings.Builder
+	if err := tmpl.Execute(&description, event); err != nil {
+		return nil, fmt.Errorf("failed to execute %s template: %w", event.Type, err)
+	}
+
+	embed := &discordEmbed{
+		Description: description.String(),
+		Color:       discordColors[event.Type],
+		Fields:      discordFields(event),
+	}
+	if !event.OccurredAt.IsZero() {
+		embed.Timestamp = event.OccurredAt.Format(time.RFC3339)
+	}
+	if event.Mapset != nil {
+		embed.Title = event.Mapset.Artist + " - " + event.Mapset.Title
+		embed.URL = event.Mapset.URL
+		for _, coverType := range discordCoverTypes {
+			if cover := event.Mapset.Covers[coverType]; cover != "" {
+				embed.Image = &discordImage{URL: cover}
+				break
+			}
+		}
+	}
+
+	return json.Marshal(&discordMessage{Embeds: []*discordEmbed{embed}})
+}
+
+// discordFields show stats delta behind event
+func discordFields(event *Event) []*discordField {
+	switch event.Type {
+	case EventStatusChange:
+		return []*discordField{
+			{Name: "Status", Value: event.PreviousStatus + " → " + event.Mapset.Status, Inline: true},
+		}
+	case EventMilestone:
+		return []*discordField{
+			{Name: "Milestone", Value: shortNumber(event.Threshold) + " " + metricName(event.Metric), Inline: true},
+			{Name: "Now", Value: strconv.Itoa(event.Value), Inline: true},
+		}
+	case EventSpike:
+		return []*discordField{
+			{Name: "Plays in a day", Value: "+" + strconv.Itoa(event.Delta), Inline: true},
+			{Name: "Total plays", Value: strconv.Itoa(event.Value), Inline: true},
+		}
+	default:
+		return nil
+	}
+}
+
+// shortNumber formats number as 950, 1.5k, 100k, 2.5M
+func shortNumber(n int) string {
+	format := func(value float64, suffix string) string {
+		return strings.TrimSuffix(strconv.FormatFloat(value, 'f', 1, 64), ".0") + suffix
+	}
+
+	switch {
+	case n >= 1_000_000 || n <= -1_000_000:
+		return format(float64(n)/1_000_000, "M")
+	case n >= 1_000 || n <= -1_000:
+		return format(float64(n)/1_000, "k")
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+func metricName(metric string) string {
+	switch metric {
+	case "play_count":
+		return "plays"
+	case "favourite_count":
+		return "favourites"
+	case "map_count":
+		return "maps"
+	case "first_ranked":
+		return "ranked maps"
+	default:
+		return strings.ReplaceAll(metric, "_", " ")
+	}
+}
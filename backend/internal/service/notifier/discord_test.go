@@ -0,0 +1,172 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_shortNumber(t *testing.T) {
+	assert.Equal(t, "950", shortNumber(950))
+	assert.Equal(t, "1.5k", shortNumber(1500))
+	assert.Equal(t, "100k", shortNumber(100000))
+	assert.Equal(t, "2.5M", shortNumber(2500000))
+}
+
+func Test_renderDiscordMessage(t *testing.T) {
+	templates, err := ParseDiscordTemplates(nil)
+	require.NoError(t, err)
+
+	mapset := &Mapset{
+		ID:      1,
+		Creator: "mapper",
+		Artist:  "artist",
+		Title:   "title",
+		Status:  "ranked",
+		Covers:  map[string]string{"cover": "https://assets.ppy.sh/cover.jpg", "card": "https://assets.ppy.sh/card.jpg"},
+		URL:     "https://osu.ppy.sh/beatmapsets/1",
+	}
+
+	tests := []struct {
+		name            string
+		event           *Event
+		wantDescription string
+		wantFields      []*discordField
+	}{
+		{
+			name:            "status change",
+			event:           &Event{Type: EventStatusChange, Mapset: mapset, PreviousStatus: "qualified"},
+			wantDescription: "mapper's map **artist - title** just got ranked",
+			wantFields:      []*discordField{{Name: "Status", Value: "qualified → ranked", Inline: true}},
+		},
+		{
+			name:            "mapset milestone",
+			event:           &Event{Type: EventMilestone, Mapset: mapset, Metric: "play_count", Threshold: 100000, Value: 100042},
+			wantDescription: "**artist - title** passed 100k plays",
+			wantFields: []*discordField{
+				{Name: "Milestone", Value: "100k plays", Inline: true},
+				{Name: "Now", Value: "100042", Inline: true},
+			},
+		},
+		{
+			name:            "first ranked",
+			event:           &Event{Type: EventMilestone, Username: "mapper", Metric: "first_ranked", Threshold: 1, Value: 1},
+			wantDescription: "mapper got their first ranked map",
+			wantFields: []*discordField{
+				{Name: "Milestone", Value: "1 ranked maps", Inline: true},
+				{Name: "Now", Value: "1", Inline: true},
+			},
+		},
+		{
+			name:            "spike",
+			event:           &Event{Type: EventSpike, Mapset: mapset, Value: 52000, Delta: 12000},
+			wantDescription: "**artist - title** got 12k plays in a day",
+			wantFields: []*discordField{
+				{Name: "Plays in a day", Value: "+12000", Inline: true},
+				{Name: "Total plays", Value: "52000", Inline: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := renderDiscordMessage(templates, tt.event)
+			require.NoError(t, err)
+
+			var msg discordMessage
+			require.NoError(t, json.Unmarshal(body, &msg))
+			require.Len(t, msg.Embeds, 1)
+
+			embed := msg.Embeds[0]
+			assert.Equal(t, tt.wantDescription, embed.Description)
+			assert.Equal(t, tt.wantFields, embed.Fields)
+			assert.Equal(t, discordColors[tt.event.Type], embed.Color)
+			if tt.event.Mapset != nil {
+				assert.Equal(t, "artist - title", embed.Title)
+				assert.Equal(t, mapset.URL, embed.URL)
+				assert.Equal(t, "https://assets.ppy.sh/card.jpg", embed.Image.URL)
+			}
+		})
+	}
+}
+
+func TestParseDiscordTemplates(t *testing.T) {
+	templates, err := ParseDiscordTemplates(map[string]string{"spike": "{{.Mapset.Title}} is on fire"})
+	require.NoError(t, err)
+
+	body, err := renderDiscordMessage(templates, &Event{Type: EventSpike, Mapset: &Mapset{Title: "title"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"description":"title is on fire"`)
+
+	_, err = ParseDiscordTemplates(map[string]string{"unknown": "text"})
+	assert.Error(t, err)
+
+	_, err = ParseDiscordTemplates(map[string]string{"spike": "{{.Mapset.Title"})
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifier_Notify_discord(t *testing.T) {
+	rcv := &receiver{statuses: []int{http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{{
+		ID:        1,
+		URL:       srv.URL,
+		Kind:      string(KindDiscord),
+		Templates: repository.JSON(`{"tracking_failed":"oops: {{.Error}}"}`),
+	}}}
+
+	n := newTestNotifier(store)
+	n.limiter = newChannelLimiter(20 * time.Millisecond)
+
+	err := n.Notify(context.Background(),
+		&Event{Type: EventTrackingFailed, Error: "timeout"},
+		&Event{Type: EventTrackingFailed, Error: "timeout again"},
+	)
+	require.NoError(t, err)
+
+	// first message is rate limited once and retried
+	require.Len(t, rcv.requests, 3)
+	require.Len(t, store.deliveries, 2)
+	assert.Equal(t, 2, store.deliveries[0].Attempts)
+	assert.True(t, store.deliveries[0].Success)
+	assert.True(t, store.deliveries[1].Success)
+
+	var msg discordMessage
+	require.NoError(t, json.Unmarshal(rcv.bodies[2], &msg))
+	assert.Equal(t, "oops: timeout again", msg.Embeds[0].Description)
+	assert.JSONEq(t, string(rcv.bodies[2]), string(store.deliveries[1].Payload))
+}
+
+func Test_channelLimiter_Wait(t *testing.T) {
+	limiter := newChannelLimiter(30 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	require.NoError(t, limiter.Wait(ctx, "b"))
+	assert.Less(t, time.Since(start), 30*time.Millisecond, "channels are limited separately")
+
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(cancelled, "a"), context.Canceled)
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	assert.Equal(t, 1500*time.Millisecond, parseRetryAfter("1.5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+}
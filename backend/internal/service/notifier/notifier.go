@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Notifier delivers events collected during tracking run to subscribers
+type Notifier interface {
+	Notify(ctx context.Context, events ...*Event) error
+}
+
+type EventType string
+
+const (
+	EventNewMapset      EventType = "new_mapset"
+	EventStatusChange   EventType = "status_change"
+	EventMilestone      EventType = "milestone"
+	EventTrackingFailed EventType = "tracking_failed"
+	EventSpike          EventType = "spike"
+)
+
+var EventTypes = []EventType{
+	EventNewMapset,
+	EventStatusChange,
+	EventMilestone,
+	EventTrackingFailed,
+	EventSpike,
+}
+
+func IsKnownEventType(eventType string) bool {
+	for _, known := range EventTypes {
+		if string(known) == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SubscriptionKind decides how events are rendered for subscription
+type SubscriptionKind string
+
+const (
+	// KindWebhook posts event as signed json
+	KindWebhook SubscriptionKind = "webhook"
+	// KindDiscord posts event as discord embed rendered from message template
+	KindDiscord SubscriptionKind = "discord"
+)
+
+func IsKnownSubscriptionKind(kind string) bool {
+	return kind == string(KindWebhook) || kind == string(KindDiscord)
+}
+
+// Event is a tracking event, only fields relevant to its type are set
+type Event struct {
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	UserID     int       `json:"user_id,omitempty"`
+	Username   string    `json:"username,omitempty"`
+	Mapset     *Mapset   `json:"mapset,omitempty"`
+	BeatmapID  int       `json:"beatmap_id,omitempty"`
+
+	// PreviousStatus is set for status change, new status is in Mapset
+	PreviousStatus string `json:"previous_status,omitempty"`
+
+	// Metric, Threshold, Value and Delta describe milestone or spike
+	Metric    string `json:"metric,omitempty"`
+	Threshold int    `json:"threshold,omitempty"`
+	Value     int    `json:"value,omitempty"`
+	Delta     int    `json:"delta,omitempty"`
+
+	// Error is set for tracking failure
+	Error string `json:"error,omitempty"`
+}
+
+type Mapset struct {
+	ID      int               `json:"id"`
+	UserID  int               `json:"user_id"`
+	Creator string            `json:"creator"`
+	Artist  string            `json:"artist"`
+	Title   string            `json:"title"`
+	Status  string            `json:"status"`
+	Covers  map[string]string `json:"covers,omitempty"`
+	URL     string            `json:"url"`
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// channelLimiter spaces out requests to the same channel by at least interval
+type channelLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+func newChannelLimiter(interval time.Duration) *channelLimiter {
+	return &channelLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// Wait reserves next free slot of channel and blocks until it comes
+func (l *channelLimiter) Wait(ctx context.Context, channel string) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[channel]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[channel] = at.Add(l.interval)
+	l.mu.Unlock()
+
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
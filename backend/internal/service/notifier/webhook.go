@@ -0,0 +1,332 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// renderer turns event into request body for subscription
+type renderer func(event *Event) ([]byte, error)
+
+const (
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// errInvalidRequest is returned when request to subscription url can't be built, e.g. url is malformed
+var errInvalidRequest = errors.New("failed to create http request")
+
+type webhookStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.WebhookSubscription, error)
+	CreateDeliveries(ctx context.Context, tx txmanager.Tx, deliveries ...*model.WebhookDelivery) error
+}
+
+// WebhookNotifier posts events to every subscription registered for them,
+// as signed json or as discord embeds depending on subscription kind
+type WebhookNotifier struct {
+	cfg        *config.Config
+	lg         *log.Logger
+	txm        txmanager.TxManager
+	webhook    webhookStore
+	httpClient *http.Client
+	limiter    *channelLimiter
+}
+
+func NewWebhook(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	webhook webhookStore,
+	httpClient *http.Client,
+) *WebhookNotifier {
+	return &WebhookNotifier{
+		cfg:        cfg,
+		lg:         lg,
+		txm:        txm,
+		webhook:    webhook,
+		httpClient: httpClient,
+		limiter:    newChannelLimiter(cfg.DiscordMinInterval),
+	}
+}
+
+// Notify delivers events to subscriptions concurrently, events of one subscription go out in order.
+// Outcome of every delivery is stored in delivery log as soon as it is known,
+// failed deliveries are logged and don't fail the call
+func (n *WebhookNotifier) Notify(ctx context.Context, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var subscriptions []*model.WebhookSubscription
+	if err := n.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		subscriptions, err = n.webhook.List(ctx, tx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, max(n.cfg.WebhookConcurrency, 1))
+	for _, subscription := range subscriptions {
+		wg.Add(1)
+		go func(subscription *model.WebhookSubscription) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := n.notifySubscription(ctx, subscription, events); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(subscription)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// notifySubscription delivers subscribed events to single subscription, error is returned only when delivery can't be stored
+func (n *WebhookNotifier) notifySubscription(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+	events []*Event,
+) error {
+	subscribed, err := subscribedEvents(subscription)
+	if err != nil {
+		n.lg.Errorf("skipping webhook subscription %v: %v", subscription.ID, err)
+		return nil
+	}
+
+	render, err := rendererFor(subscription)
+	if err != nil {
+		n.lg.Errorf("skipping webhook subscription %v: %v", subscription.ID, err)
+		return nil
+	}
+
+	for _, event := range events {
+		if len(subscribed) > 0 && !subscribed[event.Type] {
+			continue
+		}
+
+		delivery := n.deliver(ctx, subscription, render, event)
+		if !delivery.Success {
+			n.lg.Warnf("failed to deliver %s event to webhook %v after %v attempts: %s",
+				event.Type, subscription.ID, delivery.Attempts, delivery.Error)
+		}
+
+		if err := n.storeDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeDelivery records delivery even when notify deadline has just passed, so timed out deliveries are logged too
+func (n *WebhookNotifier) storeDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.cfg.WebhookTimeout)
+	defer cancel()
+
+	return n.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return n.webhook.CreateDeliveries(ctx, tx, delivery)
+	})
+}
+
+// deliver posts event retrying network errors, 5xx and 429 responses with exponential backoff,
+// Retry-After of 429 response is waited out when it is longer than backoff, up to configured maximum
+func (n *WebhookNotifier) deliver(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+	render renderer,
+	event *Event,
+) *model.WebhookDelivery {
+	delivery := &model.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventType:      string(event.Type),
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	payload, err := render(event)
+	if err != nil {
+		delivery.Error = fmt.Sprintf("failed to render event: %v", err)
+		return delivery
+	}
+	delivery.Payload = repository.JSON(payload)
+
+	backoff := n.cfg.WebhookRetryBackoff
+	for attempt := 1; attempt <= n.cfg.WebhookMaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+
+		if subscription.Kind == string(KindDiscord) {
+			if err := n.limiter.Wait(ctx, subscription.URL); err != nil {
+				delivery.Error = err.Error()
+				return delivery
+			}
+		}
+
+		statusCode, retryAfter, err := n.post(ctx, subscription, event.Type, payload)
+		delivery.StatusCode = statusCode
+		if err == nil {
+			deliveredAt := time.Now().UTC()
+			delivery.Success = true
+			delivery.Error = ""
+			delivery.DeliveredAt = &deliveredAt
+			return delivery
+		}
+		delivery.Error = err.Error()
+
+		if !isRetryable(statusCode, err) || attempt == n.cfg.WebhookMaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			delivery.Error = ctx.Err().Error()
+			return delivery
+		case <-time.After(max(backoff, min(retryAfter, n.cfg.WebhookMaxRetryAfter))):
+		}
+		backoff *= 2
+	}
+
+	return delivery
+}
+
+func (n *WebhookNotifier) post(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+	eventType EventType,
+	payload []byte,
+) (int, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, n.cfg.WebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"User-Agent":    n.cfg.AppName,
+		EventHeader:     string(eventType),
+		TimestampHeader: timestamp,
+		SignatureHeader: "sha256=" + Sign(subscription.Secret, timestamp, payload),
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to send http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain body so connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("unexpected status code: %v", resp.StatusCode)
+	}
+
+	return resp.StatusCode, 0, nil
+}
+
+// Sign computes hex encoded HMAC-SHA256 of "<timestamp>.<payload>", receivers recompute it
+// with their secret and compare with signature header
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseRetryAfter reads Retry-After given in seconds, discord sends fractional seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func rendererFor(subscription *model.WebhookSubscription) (renderer, error) {
+	if subscription.Kind != string(KindDiscord) {
+		return func(event *Event) ([]byte, error) {
+			return json.Marshal(event)
+		}, nil
+	}
+
+	overrides := make(map[string]string)
+	if len(subscription.Templates) > 0 {
+		if err := json.Unmarshal(subscription.Templates, &overrides); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal templates: %w", err)
+		}
+	}
+
+	templates, err := ParseDiscordTemplates(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(event *Event) ([]byte, error) {
+		return renderDiscordMessage(templates, event)
+	}, nil
+}
+
+// isRetryable tells transient failures apart, request that can't be built fails the same way on every attempt
+func isRetryable(statusCode int, err error) bool {
+	if errors.Is(err, errInvalidRequest) {
+		return false
+	}
+
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+func subscribedEvents(subscription *model.WebhookSubscription) (map[EventType]bool, error) {
+	if len(subscription.Events) == 0 {
+		return nil, nil
+	}
+
+	var events []EventType
+	if err := json.Unmarshal(subscription.Events, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscribed events: %w", err)
+	}
+
+	subscribed := make(map[EventType]bool, len(events))
+	for _, event := range events {
+		subscribed[event] = true
+	}
+
+	return subscribed, nil
+}
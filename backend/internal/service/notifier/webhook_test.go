@@ -0,0 +1,300 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWebhookStore struct {
+	mu            sync.Mutex
+	subscriptions []*model.WebhookSubscription
+	deliveries    []*model.WebhookDelivery
+}
+
+func (s *fakeWebhookStore) List(context.Context, txmanager.Tx) ([]*model.WebhookSubscription, error) {
+	return s.subscriptions, nil
+}
+
+func (s *fakeWebhookStore) CreateDeliveries(_ context.Context, _ txmanager.Tx, deliveries ...*model.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries = append(s.deliveries, deliveries...)
+	return nil
+}
+
+func (s *fakeWebhookStore) stored() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.deliveries)
+}
+
+// receiver is a local webhook endpoint answering with queued status codes, 200 once queue is empty
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestNotifier(store *fakeWebhookStore) *WebhookNotifier {
+	cfg := &config.Config{
+		AppName:              "test",
+		WebhookMaxAttempts:   3,
+		WebhookRetryBackoff:  time.Millisecond,
+		WebhookMaxRetryAfter: 10 * time.Millisecond,
+		WebhookTimeout:       time.Second,
+		WebhookConcurrency:   2,
+	}
+
+	return NewWebhook(cfg, log.New(), txmanagertest.TxManager{}, store, http.DefaultClient)
+}
+
+func TestWebhookNotifier_Notify_signsPayload(t *testing.T) {
+	rcv := &receiver{}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: srv.URL, Secret: "secret"},
+	}}
+
+	event := &Event{Type: EventNewMapset, Mapset: &Mapset{ID: 10, Title: "title"}}
+	require.NoError(t, newTestNotifier(store).Notify(context.Background(), event))
+
+	require.Len(t, rcv.requests, 1)
+	req := rcv.requests[0]
+	assert.Equal(t, string(EventNewMapset), req.Header.Get(EventHeader))
+	assert.Equal(t, "sha256="+Sign("secret", req.Header.Get(TimestampHeader), rcv.bodies[0]), req.Header.Get(SignatureHeader))
+
+	var received Event
+	require.NoError(t, json.Unmarshal(rcv.bodies[0], &received))
+	assert.Equal(t, 10, received.Mapset.ID)
+
+	require.Len(t, store.deliveries, 1)
+	assert.True(t, store.deliveries[0].Success)
+	assert.Equal(t, 1, store.deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, store.deliveries[0].StatusCode)
+	assert.NotNil(t, store.deliveries[0].DeliveredAt)
+}
+
+func TestWebhookNotifier_Notify_retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantSuccess  bool
+		wantStatus   int
+	}{
+		{"server errors are retried", []int{500, 503}, 3, true, 200},
+		{"rate limit is retried", []int{429}, 2, true, 200},
+		{"gives up after max attempts", []int{500, 500, 500, 500}, 3, false, 500},
+		{"client errors are not retried", []int{400}, 1, false, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcv := &receiver{statuses: tt.statuses}
+			srv := httptest.NewServer(rcv)
+			defer srv.Close()
+
+			store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+				{ID: 1, URL: srv.URL, Secret: "secret"},
+			}}
+
+			require.NoError(t, newTestNotifier(store).Notify(context.Background(), &Event{Type: EventSpike}))
+
+			assert.Len(t, rcv.requests, tt.wantAttempts)
+			require.Len(t, store.deliveries, 1)
+			assert.Equal(t, tt.wantAttempts, store.deliveries[0].Attempts)
+			assert.Equal(t, tt.wantSuccess, store.deliveries[0].Success)
+			assert.Equal(t, tt.wantStatus, store.deliveries[0].StatusCode)
+		})
+	}
+}
+
+func TestWebhookNotifier_Notify_filtersEvents(t *testing.T) {
+	rcv := &receiver{}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: srv.URL, Secret: "a", Events: repository.JSON(`["milestone"]`)},
+		{ID: 2, URL: srv.URL, Secret: "b"},
+	}}
+
+	err := newTestNotifier(store).Notify(context.Background(),
+		&Event{Type: EventMilestone},
+		&Event{Type: EventTrackingFailed},
+	)
+	require.NoError(t, err)
+
+	type delivered struct {
+		subscriptionID int
+		eventType      string
+	}
+	var actual []delivered
+	for _, d := range store.deliveries {
+		actual = append(actual, delivered{d.SubscriptionID, d.EventType})
+	}
+
+	// subscriptions are notified concurrently, events of one subscription keep their order
+	assert.ElementsMatch(t, []delivered{
+		{1, "milestone"},
+		{2, "milestone"},
+		{2, "tracking_failed"},
+	}, actual)
+
+	var second []string
+	for _, d := range actual {
+		if d.subscriptionID == 2 {
+			second = append(second, d.eventType)
+		}
+	}
+	assert.Equal(t, []string{"milestone", "tracking_failed"}, second)
+}
+
+func TestWebhookNotifier_Notify_capsRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: srv.URL, Secret: "secret"},
+	}}
+
+	start := time.Now()
+	require.NoError(t, newTestNotifier(store).Notify(context.Background(), &Event{Type: EventSpike}))
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.Len(t, store.deliveries, 1)
+	assert.True(t, store.deliveries[0].Success)
+	assert.Equal(t, 2, store.deliveries[0].Attempts)
+}
+
+func TestWebhookNotifier_Notify_invalidURLIsNotRetried(t *testing.T) {
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: "http://invalid host", Secret: "secret"},
+	}}
+
+	require.NoError(t, newTestNotifier(store).Notify(context.Background(), &Event{Type: EventSpike}))
+
+	require.Len(t, store.deliveries, 1)
+	assert.False(t, store.deliveries[0].Success)
+	assert.Equal(t, 1, store.deliveries[0].Attempts)
+	assert.Zero(t, store.deliveries[0].StatusCode)
+}
+
+func TestWebhookNotifier_Notify_deliversSubscriptionsConcurrently(t *testing.T) {
+	// slow receiver holds its request until the other subscription has been notified
+	fastDone := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case <-fastDone:
+			w.WriteHeader(http.StatusOK)
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer slow.Close()
+
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		close(fastDone)
+	}))
+	defer fast.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: slow.URL, Secret: "a"},
+		{ID: 2, URL: fast.URL, Secret: "b"},
+	}}
+
+	require.NoError(t, newTestNotifier(store).Notify(context.Background(), &Event{Type: EventSpike}))
+
+	require.Len(t, store.deliveries, 2)
+	for _, d := range store.deliveries {
+		assert.True(t, d.Success, "subscription %v", d.SubscriptionID)
+	}
+}
+
+func TestWebhookNotifier_Notify_storesEachDelivery(t *testing.T) {
+	store := &fakeWebhookStore{}
+
+	// second event reaches receiver only after the first delivery is stored
+	var storedBefore []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		storedBefore = append(storedBefore, store.stored())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store.subscriptions = []*model.WebhookSubscription{{ID: 1, URL: srv.URL, Secret: "secret"}}
+
+	err := newTestNotifier(store).Notify(context.Background(), &Event{Type: EventSpike}, &Event{Type: EventMilestone})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{0, 1}, storedBefore)
+	assert.Len(t, store.deliveries, 2)
+}
+
+func TestWebhookNotifier_Notify_storesTimedOutDelivery(t *testing.T) {
+	rcv := &receiver{statuses: []int{500, 500}}
+	srv := httptest.NewServer(rcv)
+	defer srv.Close()
+
+	store := &fakeWebhookStore{subscriptions: []*model.WebhookSubscription{
+		{ID: 1, URL: srv.URL, Secret: "secret"},
+	}}
+
+	n := newTestNotifier(store)
+	n.cfg.WebhookRetryBackoff = time.Hour
+
+	// notify deadline passes while waiting to retry
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, n.Notify(ctx, &Event{Type: EventSpike}))
+
+	require.Len(t, store.deliveries, 1)
+	assert.False(t, store.deliveries[0].Success)
+	assert.Equal(t, context.DeadlineExceeded.Error(), store.deliveries[0].Error)
+}
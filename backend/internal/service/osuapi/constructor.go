@@ -18,7 +18,9 @@ type (
 		GetUser(ctx context.Context, userID string) (*User, error)
 		GetUserMapsets(ctx context.Context, userID string) ([]*Mapset, error)
 		GetUserWithMapsets(ctx context.Context, userID string) (*User, []*MapsetExtended, error)
+		GetMapset(ctx context.Context, mapsetID string) (*Mapset, error)
 		GetMapsetExtended(ctx context.Context, mapsetID string) (*MapsetLangGenre, error)
+		GetMapsetCommentsCount(ctx context.Context, mapsetID string) (int, error)
 		GetOutgoingRequestCount() int
 		ResetOutgoingRequestCount()
 	}
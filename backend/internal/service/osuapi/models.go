@@ -31,6 +31,7 @@ type Mapset struct {
 	Covers         map[string]string `json:"covers"`
 	Status         string            `json:"status"`
 	LastUpdated    time.Time         `json:"last_updated"`
+	SubmittedDate  time.Time         `json:"submitted_date"`
 	UserId         int               `json:"user_id"`
 	PreviewUrl     string            `json:"preview_url"`
 	Tags           string            `json:"tags"`
@@ -60,8 +61,9 @@ type Beatmap struct {
 }
 
 type MapsetExtended struct {
-	CommentsCount int    `json:"comments_count"`
-	Genre         string `json:"genre"`
-	Language      string `json:"language"`
+	CommentsCount     int       `json:"comments_count"`
+	CommentsFetchedAt time.Time `json:"-"`
+	Genre             string    `json:"genre"`
+	Language          string    `json:"language"`
 	*Mapset
 }
@@ -3,12 +3,16 @@ package osuapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"playcount-monitor-backend/internal/bootstrap"
 	"strconv"
 )
 
+// ErrNotFound is returned when api responds with 404, e.g. for deleted mapsets or restricted users
+var ErrNotFound = errors.New("not found in osu api")
+
 type MapsetStatusAPIOption string
 
 const (
@@ -40,16 +44,11 @@ func (s *Service) GetUserWithMapsets(ctx context.Context, userID string) (*User,
 		return nil, nil, err
 	}
 
+	// comments count, genre and language are filled by the caller, it decides which of them need a refetch
 	var mapsetsExtended []*MapsetExtended
 	for _, mapset := range userMapsets {
-		commentCount, err := s.GetMapsetCommentsCount(ctx, strconv.Itoa(mapset.Id))
-		if err != nil {
-			return nil, nil, err
-		}
-
 		mapsetsExtended = append(mapsetsExtended, &MapsetExtended{
-			Mapset:        mapset,
-			CommentsCount: commentCount,
+			Mapset: mapset,
 		})
 	}
 
@@ -93,6 +92,51 @@ func (s *Service) GetMapsetExtended(ctx context.Context, mapsetID string) (*Maps
 	return mapsetExt, nil
 }
 
+func (s *Service) GetMapset(ctx context.Context, mapsetID string) (*Mapset, error) {
+	token, err := s.tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://osu.ppy.sh/api/v2/beatmapsets/123
+	req, err := http.NewRequest("GET", s.cfg.OsuAPIHost+"/beatmapsets/"+mapsetID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Accept":        "application/json",
+		"Authorization": "Bearer " + token,
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("mapset %s: %w", mapsetID, ErrNotFound)
+	}
+	// error bodies of rate limits and outages decode into empty mapset that looks like one of another host
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to get mapset %s: unexpected status code: %v", mapsetID, resp.StatusCode)
+	}
+
+	var mapset *Mapset
+	err = json.NewDecoder(resp.Body).Decode(&mapset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return mapset, nil
+}
+
 func (s *Service) GetMapsetCommentsCount(ctx context.Context, mapsetID string) (int, error) {
 	token, err := s.tokenProvider.GetToken(ctx)
 	if err != nil {
@@ -160,6 +204,10 @@ func (s *Service) GetUser(ctx context.Context, userID string) (*User, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("user %s: %w", userID, ErrNotFound)
+	}
+
 	// Parsing JSON response
 	var user *User
 	err = json.NewDecoder(resp.Body).Decode(&user)
@@ -0,0 +1,46 @@
+package osuapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTokenProvider struct{}
+
+func (fakeTokenProvider) GetToken(context.Context) (string, error) {
+	return "token", nil
+}
+
+// newStubService returns service answering every request with status and body, for tests of api failures
+func newStubService(t *testing.T, status int, body string) *Service {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return New(&config.Config{OsuAPIHost: srv.URL}, fakeTokenProvider{}, srv.Client())
+}
+
+func TestService_GetMapset(t *testing.T) {
+	mapset, err := newStubService(t, http.StatusOK, `{"id":1,"user_id":7}`).GetMapset(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, 7, mapset.UserId)
+
+	_, err = newStubService(t, http.StatusNotFound, `{"error":null}`).GetMapset(context.Background(), "1")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	for _, status := range []int{http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusInternalServerError} {
+		mapset, err := newStubService(t, status, `{"error":"Too Many Attempts."}`).GetMapset(context.Background(), "1")
+		assert.Error(t, err, "status %v", status)
+		assert.False(t, errors.Is(err, ErrNotFound), "status %v", status)
+		assert.Nil(t, mapset, "status %v", status)
+	}
+}
@@ -0,0 +1,29 @@
+package osuoauth
+
+import (
+	"context"
+	"net/http"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/service/osuapi"
+)
+
+type (
+	Service struct {
+		cfg        *config.Config
+		httpClient *http.Client
+	}
+
+	Interface interface {
+		AuthorizeURL(state string) string
+		Exchange(ctx context.Context, code string) (*Token, error)
+		Refresh(ctx context.Context, refreshToken string) (*Token, error)
+		GetMe(ctx context.Context, accessToken string) (*osuapi.User, error)
+	}
+)
+
+func New(cfg *config.Config, httpClient *http.Client) *Service {
+	return &Service{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
@@ -0,0 +1,204 @@
+// Package osuoauthtest provides in-process stand-in of osu! oauth server for tests, it approves every
+// authorization request for a single user and implements authorization code and refresh token grants
+package osuoauthtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"sync"
+	"time"
+)
+
+const (
+	ClientID     = "test-client"
+	ClientSecret = "test-secret"
+)
+
+type issuedToken struct {
+	refreshToken string
+	expiresAt    time.Time
+}
+
+type Server struct {
+	server *httptest.Server
+	user   *osuapi.User
+
+	mu            sync.Mutex
+	tokenTTL      time.Duration
+	codes         map[string]string // code -> redirect uri it was issued for
+	accessTokens  map[string]*issuedToken
+	refreshTokens map[string]bool
+	refreshes     int
+}
+
+// NewServer starts stand-in that logs everyone in as user, access tokens it issues live for an hour
+func NewServer(user *osuapi.User) *Server {
+	s := &Server{
+		user:          user,
+		tokenTTL:      time.Hour,
+		codes:         make(map[string]string),
+		accessTokens:  make(map[string]*issuedToken),
+		refreshTokens: make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", s.authorize)
+	mux.HandleFunc("/oauth/token", s.token)
+	mux.HandleFunc("/api/v2/me", s.me)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+func (s *Server) AuthorizeURL() string {
+	return s.server.URL + "/oauth/authorize"
+}
+
+func (s *Server) TokenURL() string {
+	return s.server.URL + "/oauth/token"
+}
+
+func (s *Server) APIHost() string {
+	return s.server.URL + "/api/v2"
+}
+
+// SetTokenTTL changes lifetime of access tokens issued from now on
+func (s *Server) SetTokenTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokenTTL = ttl
+}
+
+// Refreshes is how many times tokens were refreshed
+func (s *Server) Refreshes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refreshes
+}
+
+// RevokeAll invalidates every issued token as if user revoked access of the app
+func (s *Server) RevokeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessTokens = make(map[string]*issuedToken)
+	s.refreshTokens = make(map[string]bool)
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// authorize approves request right away and redirects back with code, like osu! does once user clicks authorize
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != ClientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = redirectURI.String()
+	s.mu.Unlock()
+
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		http.Error(w, "invalid token request", http.StatusBadRequest)
+		return
+	}
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		redirectURI, ok := s.codes[code]
+		if !ok || redirectURI != r.PostForm.Get("redirect_uri") {
+			writeError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		// codes are single use
+		delete(s.codes, code)
+	case "refresh_token":
+		refreshToken := r.PostForm.Get("refresh_token")
+		if !s.refreshTokens[refreshToken] {
+			writeError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		// refresh tokens are rotated
+		delete(s.refreshTokens, refreshToken)
+		s.refreshes++
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	accessToken, refreshToken := randomString(), randomString()
+	s.accessTokens[accessToken] = &issuedToken{refreshToken: refreshToken, expiresAt: time.Now().Add(s.tokenTTL)}
+	s.refreshTokens[refreshToken] = true
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"token_type":    "Bearer",
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"expires_in":    int(s.tokenTTL.Seconds()),
+	})
+}
+
+func (s *Server) me(w http.ResponseWriter, r *http.Request) {
+	accessToken := r.Header.Get("Authorization")
+	if len(accessToken) > len("Bearer ") {
+		accessToken = accessToken[len("Bearer "):]
+	}
+
+	s.mu.Lock()
+	issued, ok := s.accessTokens[accessToken]
+	s.mu.Unlock()
+	if !ok || time.Now().After(issued.expiresAt) {
+		http.Error(w, `{"authentication":"basic"}`, http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.user)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
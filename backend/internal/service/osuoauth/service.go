@@ -0,0 +1,134 @@
+package osuoauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"strings"
+	"time"
+)
+
+// scopes lets the app read profile of user who logged in, public is needed for the rest of api v2
+const scopes = "identify public"
+
+// ErrInvalidGrant is returned when osu! rejects authorization code or refresh token,
+// e.g. because it expired or user revoked access, the user has to log in again
+var ErrInvalidGrant = errors.New("osu oauth grant is invalid or expired")
+
+// Token is a user token, ExpiresAt is when access token expires
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// AuthorizeURL is where user is sent to approve access, osu! redirects back to callback with code and state
+func (s *Service) AuthorizeURL(state string) string {
+	values := url.Values{}
+	values.Set("client_id", s.cfg.OsuAPIClientID)
+	values.Set("redirect_uri", s.cfg.OsuOAuthRedirectURL)
+	values.Set("response_type", "code")
+	values.Set("scope", scopes)
+	values.Set("state", state)
+
+	return s.cfg.OsuOAuthAuthorizeURL + "?" + values.Encode()
+}
+
+// Exchange trades authorization code from callback for user token
+func (s *Service) Exchange(ctx context.Context, code string) (*Token, error) {
+	values := url.Values{}
+	values.Set("grant_type", "authorization_code")
+	values.Set("code", code)
+	values.Set("redirect_uri", s.cfg.OsuOAuthRedirectURL)
+
+	return s.requestToken(ctx, values)
+}
+
+// Refresh gets new user token, osu! rotates refresh tokens so the old one stops working
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	values := url.Values{}
+	values.Set("grant_type", "refresh_token")
+	values.Set("refresh_token", refreshToken)
+	values.Set("scope", scopes)
+
+	return s.requestToken(ctx, values)
+}
+
+// GetMe gets user the token belongs to
+func (s *Service) GetMe(ctx context.Context, accessToken string) (*osuapi.User, error) {
+	// https://osu.ppy.sh/api/v2/me
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.OsuAPIHost+"/me", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("osu api rejected user token: %w", ErrInvalidGrant)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of osu api me: %v", resp.StatusCode)
+	}
+
+	var user *osuapi.User
+	err = json.NewDecoder(resp.Body).Decode(&user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) requestToken(ctx context.Context, values url.Values) (*Token, error) {
+	values.Set("client_id", s.cfg.OsuAPIClientID)
+	values.Set("client_secret", s.cfg.OsuAPIClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.OsuOAuthHost, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// rfc 6749 answers invalid_grant with 400, osu! also uses 401 for revoked tokens
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("osu oauth responded %v: %w", resp.StatusCode, ErrInvalidGrant)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of osu oauth token: %v", resp.StatusCode)
+	}
+
+	var data struct {
+		AccessToken  string        `json:"access_token"`
+		RefreshToken string        `json:"refresh_token"`
+		Expires      time.Duration `json:"expires_in"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return &Token{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		ExpiresAt:    time.Now().UTC().Add(time.Second * data.Expires),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package osuoauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/service/osuoauth/osuoauthtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8080/api/auth/osu/callback"
+
+func newTestService(stand *osuoauthtest.Server) *Service {
+	return New(&config.Config{
+		OsuAPIClientID:       osuoauthtest.ClientID,
+		OsuAPIClientSecret:   osuoauthtest.ClientSecret,
+		OsuAPIHost:           stand.APIHost(),
+		OsuOAuthHost:         stand.TokenURL(),
+		OsuOAuthAuthorizeURL: stand.AuthorizeURL(),
+		OsuOAuthRedirectURL:  redirectURL,
+	}, &http.Client{})
+}
+
+// authorize follows authorize url like a browser would and returns code and state of the callback
+func authorize(t *testing.T, authorizeURL string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authorizeURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, redirectURL, callback.Scheme+"://"+callback.Host+callback.Path)
+
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	stand := osuoauthtest.NewServer(&osuapi.User{ID: 7, Username: "mapper"})
+	defer stand.Close()
+	s := newTestService(stand)
+	ctx := context.Background()
+
+	code, state := authorize(t, s.AuthorizeURL("some-state"))
+	assert.Equal(t, "some-state", state)
+
+	token, err := s.Exchange(ctx, code)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.NotEmpty(t, token.RefreshToken)
+	assert.False(t, token.ExpiresAt.IsZero())
+
+	me, err := s.GetMe(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, 7, me.ID)
+	assert.Equal(t, "mapper", me.Username)
+
+	_, err = s.Exchange(ctx, code)
+	assert.ErrorIs(t, err, ErrInvalidGrant, "code is single use")
+
+	refreshed, err := s.Refresh(ctx, token.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.RefreshToken, refreshed.RefreshToken)
+
+	_, err = s.Refresh(ctx, token.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidGrant, "refresh token is rotated")
+
+	stand.RevokeAll()
+	_, err = s.GetMe(ctx, refreshed.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
@@ -0,0 +1,47 @@
+package tokencipher
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	c := New("secret")
+
+	first, err := c.Encrypt("refresh-token")
+	require.NoError(t, err)
+	second, err := c.Encrypt("refresh-token")
+	require.NoError(t, err)
+
+	assert.NotContains(t, first, "refresh-token")
+	assert.NotEqual(t, first, second, "nonce should be random")
+
+	plaintext, err := c.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", plaintext)
+}
+
+func TestDecryptRejects(t *testing.T) {
+	c := New("secret")
+
+	ciphertext, err := c.Encrypt("refresh-token")
+	require.NoError(t, err)
+
+	_, err = New("other secret").Decrypt(ciphertext)
+	assert.Error(t, err)
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	require.NoError(t, err)
+	sealed[len(sealed)-1] ^= 1
+	_, err = c.Decrypt(base64.StdEncoding.EncodeToString(sealed))
+	assert.Error(t, err)
+
+	_, err = c.Decrypt("AAAA")
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = c.Decrypt("not base64!")
+	assert.ErrorIs(t, err, ErrMalformed)
+}
@@ -0,0 +1,31 @@
+package tokencipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+)
+
+type (
+	Cipher struct {
+		aead cipher.AEAD
+	}
+
+	Interface interface {
+		Encrypt(plaintext string) (string, error)
+		Decrypt(ciphertext string) (string, error)
+	}
+)
+
+// New derives AES-256 key from secret, so any high entropy string can be configured as the secret
+func New(secret string) *Cipher {
+	key := sha256.Sum256([]byte(secret))
+
+	// key is always 32 bytes long and GCM accepts every AES block cipher, so neither can fail
+	block, _ := aes.NewCipher(key[:])
+	aead, _ := cipher.NewGCM(block)
+
+	return &Cipher{
+		aead: aead,
+	}
+}
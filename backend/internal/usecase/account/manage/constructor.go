@@ -0,0 +1,52 @@
+package accountmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuapi"
+)
+
+type followingStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, follow *model.Following) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Following, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+}
+
+// tokenSource gives osu! access token of logged in user, refreshed when needed
+type tokenSource interface {
+	AccessToken(ctx context.Context, userID int) (string, error)
+}
+
+type profileSource interface {
+	GetMe(ctx context.Context, accessToken string) (*osuapi.User, error)
+}
+
+type UseCase struct {
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	following followingStore
+	tokens    tokenSource
+	profile   profileSource
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	following followingStore,
+	tokens tokenSource,
+	profile profileSource,
+) *UseCase {
+	return &UseCase{
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		following: following,
+		tokens:    tokens,
+		profile:   profile,
+	}
+}
@@ -0,0 +1,94 @@
+package accountmanage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"time"
+)
+
+// Get returns logged in mapper with their tracking settings
+func (uc *UseCase) Get(ctx context.Context, session *dto.Session) (*dto.Account, error) {
+	var following bool
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		following, err = uc.isFollowed(ctx, tx, session.UserID)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapSessionToAccountDTO(session, following), nil
+}
+
+// Follow starts tracking logged in mapper, username is taken from osu! with their own token
+// so it is current even when they were renamed since logging in
+func (uc *UseCase) Follow(ctx context.Context, session *dto.Session) (*dto.Account, error) {
+	accessToken, err := uc.tokens.AccessToken(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := uc.profile.GetMe(ctx, accessToken)
+	if errors.Is(err, osuoauth.ErrInvalidGrant) {
+		return nil, domainerror.Unauthenticated("osu! authorization is invalid or expired, log in again")
+	}
+	if err != nil {
+		return nil, domainerror.Unavailable(err, "failed to get osu! user %v", session.UserID)
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		err := uc.following.Create(ctx, tx, &model.Following{
+			ID:        me.ID,
+			Username:  me.Username,
+			CreatedAt: time.Now().UTC(),
+		})
+		// following is idempotent
+		if repository.IsUniqueViolation(err) {
+			return nil
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	session.Username = me.Username
+
+	return mappers.MapSessionToAccountDTO(session, true), nil
+}
+
+// Unfollow stops tracking logged in mapper, already tracked data is kept
+func (uc *UseCase) Unfollow(ctx context.Context, session *dto.Session) (*dto.Account, error) {
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.following.Delete(ctx, tx, session.UserID)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapSessionToAccountDTO(session, false), nil
+}
+
+func (uc *UseCase) isFollowed(ctx context.Context, tx txmanager.Tx, userID int) (bool, error) {
+	_, err := uc.following.Get(ctx, tx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package analyticsprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type userStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.User, error)
+}
+
+type mapsetStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Mapset, error)
+}
+
+type beatmapStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Beatmap, error)
+}
+
+type UseCase struct {
+	cfg     *config.Config
+	lg      *log.Logger
+	txm     txmanager.TxManager
+	user    userStore
+	mapset  mapsetStore
+	beatmap beatmapStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	user userStore,
+	mapset mapsetStore,
+	beatmap beatmapStore,
+) *UseCase {
+	return &UseCase{
+		cfg:     cfg,
+		lg:      lg,
+		txm:     txm,
+		user:    user,
+		mapset:  mapset,
+		beatmap: beatmap,
+	}
+}
@@ -0,0 +1,117 @@
+package analyticsprovide
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/timeseries"
+	"time"
+)
+
+type Command struct {
+	From        time.Time
+	To          time.Time
+	Granularity timeseries.Granularity
+}
+
+type Analytics struct {
+	ID          int                             `json:"id"`
+	From        time.Time                       `json:"from"`
+	To          time.Time                       `json:"to"`
+	Granularity timeseries.Granularity          `json:"granularity"`
+	Metrics     map[string][]*timeseries.Bucket `json:"metrics"`
+}
+
+func (uc *UseCase) GetForUser(ctx context.Context, id int, cmd *Command) (*Analytics, error) {
+	var stats model.UserStats
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		user, err := uc.user.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("user %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		stats, err = mappers.MapStatsJSONToUserStats(user.UserStats)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return newAnalytics(id, cmd, map[string]timeseries.Series{
+		"play_count":      timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.PlayCount) }),
+		"favourite_count": timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.Favorites) }),
+		"map_count":       timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.MapCount) }),
+		"comments_count":  timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.Comments) }),
+	}), nil
+}
+
+func (uc *UseCase) GetForMapset(ctx context.Context, id int, cmd *Command) (*Analytics, error) {
+	var stats model.MapsetStats
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapset, err := uc.mapset.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("mapset %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		stats, err = mappers.MapStatsJSONToMapsetStats(mapset.MapsetStats)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return newAnalytics(id, cmd, map[string]timeseries.Series{
+		"play_count":      timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Playcount) }),
+		"favourite_count": timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Favorites) }),
+		"comments_count":  timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Comments) }),
+	}), nil
+}
+
+func (uc *UseCase) GetForBeatmap(ctx context.Context, id int, cmd *Command) (*Analytics, error) {
+	var stats model.BeatmapStats
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		beatmap, err := uc.beatmap.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("beatmap %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		stats, err = mappers.MapStatsJSONToBeatmapStats(beatmap.BeatmapStats)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return newAnalytics(id, cmd, map[string]timeseries.Series{
+		"play_count": timeseries.FromMap(stats, func(s *model.BeatmapStatsModel) float64 { return float64(s.Playcount) }),
+		"pass_count": timeseries.FromMap(stats, func(s *model.BeatmapStatsModel) float64 { return float64(s.Passcount) }),
+	}), nil
+}
+
+func newAnalytics(id int, cmd *Command, series map[string]timeseries.Series) *Analytics {
+	metrics := make(map[string][]*timeseries.Bucket, len(series))
+	for name, s := range series {
+		metrics[name] = timeseries.Aggregate(s, cmd.Granularity, cmd.From, cmd.To)
+	}
+
+	return &Analytics{
+		ID:          id,
+		From:        cmd.From,
+		To:          cmd.To,
+		Granularity: cmd.Granularity,
+		Metrics:     metrics,
+	}
+}
@@ -0,0 +1,39 @@
+package anomalyprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type anomalyStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.AnomalyFilter,
+		page model.PageRequest,
+	) ([]*model.Anomaly, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg     *config.Config
+	lg      *log.Logger
+	txm     txmanager.TxManager
+	anomaly anomalyStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	anomaly anomalyStore,
+) *UseCase {
+	return &UseCase{
+		cfg:     cfg,
+		lg:      lg,
+		txm:     txm,
+		anomaly: anomaly,
+	}
+}
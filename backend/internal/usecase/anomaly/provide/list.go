@@ -0,0 +1,48 @@
+package anomalyprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+type ListCommand struct {
+	pagination.Query
+	Filter model.AnomalyFilter
+}
+
+type ListResponse struct {
+	Anomalies []*dto.Anomaly
+	Page      *dto.Page
+}
+
+func (uc *UseCase) List(
+	ctx context.Context,
+	cmd *ListCommand,
+) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var anomalies []*model.Anomaly
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		anomalies, page, err = uc.anomaly.List(ctx, tx, cmd.Filter, pageReq)
+
+		return pagination.Error(err)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return &ListResponse{
+		Anomalies: mappers.MapAnomalyModelsToAnomalyDTOs(anomalies),
+		Page:      mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package apikeymanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+type apiKeyStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, key *model.APIKey) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.APIKey, error)
+	GetByHash(ctx context.Context, tx txmanager.Tx, keyHash string) (*model.APIKey, error)
+	Revoke(ctx context.Context, tx txmanager.Tx, id int, revokedAt time.Time) error
+	SetLastUsed(ctx context.Context, tx txmanager.Tx, id int, usedAt time.Time) error
+}
+
+type UseCase struct {
+	cfg    *config.Config
+	lg     *log.Logger
+	txm    txmanager.TxManager
+	apiKey apiKeyStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	apiKey apiKeyStore,
+) *UseCase {
+	return &UseCase{
+		cfg:    cfg,
+		lg:     lg,
+		txm:    txm,
+		apiKey: apiKey,
+	}
+}
@@ -0,0 +1,168 @@
+package apikeymanage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"strings"
+	"time"
+)
+
+const (
+	// generated keys look like pmk_<64 hex chars>, the marker makes leaked keys easy to grep for
+	keyMarker         = "pmk_"
+	generatedKeyBytes = 32
+	// minKeyLength applies to keys set through config, generated keys are longer
+	minKeyLength = 32
+	prefixLength = 12
+	// last use is only written when stored one is older, so reads don't turn into writes on every request
+	lastUsedResolution = time.Minute
+)
+
+type Command struct {
+	Name string
+	Role string
+}
+
+// Create generates a new key, plaintext key is only returned here and can't be recovered later
+func (uc *UseCase) Create(ctx context.Context, cmd *Command) (*dto.APIKey, error) {
+	cmd.Name = strings.TrimSpace(cmd.Name)
+	if cmd.Name == "" {
+		return nil, domainerror.Validation("invalid api key: name is required")
+	}
+	if !model.Role(cmd.Role).Valid() {
+		return nil, domainerror.Validation(
+			"invalid api key: role must be %q, %q or %q", model.RoleViewer, model.RoleEditor, model.RoleAdmin,
+		)
+	}
+
+	plaintext, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := newKey(cmd.Name, plaintext, model.Role(cmd.Role))
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.apiKey.Create(ctx, tx, key)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	res := mappers.MapAPIKeyModelToAPIKeyDTO(key)
+	res.Key = plaintext
+
+	return res, nil
+}
+
+// Ensure stores key set through config unless it is already stored, so the first admin can be bootstrapped
+func (uc *UseCase) Ensure(ctx context.Context, name string, plaintext string, role model.Role) error {
+	if len(plaintext) < minKeyLength {
+		return fmt.Errorf("api key %q must be at least %d characters long", name, minKeyLength)
+	}
+
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		_, err := uc.apiKey.GetByHash(ctx, tx, HashKey(plaintext))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		err = uc.apiKey.Create(ctx, tx, newKey(name, plaintext, role))
+		if repository.IsUniqueViolation(err) {
+			// created concurrently by another instance
+			return nil
+		}
+
+		return err
+	})
+}
+
+func (uc *UseCase) Revoke(ctx context.Context, id int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		_, err := uc.apiKey.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("api key %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		return uc.apiKey.Revoke(ctx, tx, id, time.Now().UTC())
+	})
+}
+
+// Authenticate finds active key by its plaintext and records its use
+func (uc *UseCase) Authenticate(ctx context.Context, plaintext string) (*dto.APIKey, error) {
+	var key *model.APIKey
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		key, err = uc.apiKey.GetByHash(ctx, tx, HashKey(plaintext))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.Unauthenticated("invalid api key")
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	if key.RevokedAt != nil {
+		return nil, domainerror.Unauthenticated("api key %s is revoked", key.Prefix)
+	}
+
+	now := time.Now().UTC()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		txErr = uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+			return uc.apiKey.SetLastUsed(ctx, tx, key.ID, now)
+		})
+		if txErr != nil {
+			// failing to record use shouldn't fail the request
+			uc.lg.Errorf("failed to record use of api key %v: %v", key.ID, txErr)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return mappers.MapAPIKeyModelToAPIKeyDTO(key), nil
+}
+
+// HashKey is hex encoded sha256 of key, keys are random enough for a fast unsalted hash
+// to be safe, and it lets keys be looked up by hash
+func HashKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func newKey(name string, plaintext string, role model.Role) *model.APIKey {
+	return &model.APIKey{
+		Name:      name,
+		Prefix:    plaintext[:prefixLength],
+		KeyHash:   HashKey(plaintext),
+		Role:      string(role),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, generatedKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	return keyMarker + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,146 @@
+package apikeymanage
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPIKeyStore struct {
+	keys []*model.APIKey
+}
+
+func (s *fakeAPIKeyStore) Create(_ context.Context, _ txmanager.Tx, key *model.APIKey) error {
+	key.ID = len(s.keys) + 1
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *fakeAPIKeyStore) Get(_ context.Context, _ txmanager.Tx, id int) (*model.APIKey, error) {
+	for _, key := range s.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to get api key with id %v: %w", id, gorm.ErrRecordNotFound)
+}
+
+func (s *fakeAPIKeyStore) GetByHash(_ context.Context, _ txmanager.Tx, keyHash string) (*model.APIKey, error) {
+	for _, key := range s.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to get api key by hash: %w", gorm.ErrRecordNotFound)
+}
+
+func (s *fakeAPIKeyStore) Revoke(_ context.Context, _ txmanager.Tx, id int, revokedAt time.Time) error {
+	key, err := s.Get(context.Background(), nil, id)
+	if err != nil {
+		return err
+	}
+	key.RevokedAt = &revokedAt
+	return nil
+}
+
+func (s *fakeAPIKeyStore) SetLastUsed(_ context.Context, _ txmanager.Tx, id int, usedAt time.Time) error {
+	key, err := s.Get(context.Background(), nil, id)
+	if err != nil {
+		return err
+	}
+	key.LastUsedAt = &usedAt
+	return nil
+}
+
+func newTestUseCase(store *fakeAPIKeyStore) *UseCase {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	return New(&config.Config{}, lg, txmanagertest.TxManager{}, store)
+}
+
+func TestCreateStoresOnlyHash(t *testing.T) {
+	store := &fakeAPIKeyStore{}
+	uc := newTestUseCase(store)
+
+	created, err := uc.Create(context.Background(), &Command{Name: " sync script ", Role: string(model.RoleEditor)})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(created.Key, keyMarker))
+	assert.Len(t, created.Key, len(keyMarker)+2*generatedKeyBytes)
+	assert.Equal(t, "sync script", created.Name)
+	assert.Equal(t, created.Key[:prefixLength], created.Prefix)
+
+	require.Len(t, store.keys, 1)
+	assert.Equal(t, HashKey(created.Key), store.keys[0].KeyHash)
+	assert.NotContains(t, store.keys[0].KeyHash, created.Key[len(keyMarker):])
+
+	other, err := uc.Create(context.Background(), &Command{Name: "other", Role: string(model.RoleViewer)})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Key, other.Key)
+}
+
+func TestCreateValidates(t *testing.T) {
+	uc := newTestUseCase(&fakeAPIKeyStore{})
+
+	_, err := uc.Create(context.Background(), &Command{Name: "", Role: string(model.RoleAdmin)})
+	assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+
+	_, err = uc.Create(context.Background(), &Command{Name: "root", Role: "superuser"})
+	assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+}
+
+func TestAuthenticate(t *testing.T) {
+	store := &fakeAPIKeyStore{}
+	uc := newTestUseCase(store)
+	ctx := context.Background()
+
+	created, err := uc.Create(ctx, &Command{Name: "dashboard", Role: string(model.RoleViewer)})
+	require.NoError(t, err)
+
+	key, err := uc.Authenticate(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, key.ID)
+	assert.Equal(t, string(model.RoleViewer), key.Role)
+	assert.Empty(t, key.Key)
+	require.NotNil(t, store.keys[0].LastUsedAt)
+
+	_, err = uc.Authenticate(ctx, created.Key+"x")
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+
+	require.NoError(t, uc.Revoke(ctx, created.ID))
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+
+	err = uc.Revoke(ctx, 42)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+}
+
+func TestEnsureIsIdempotent(t *testing.T) {
+	store := &fakeAPIKeyStore{}
+	uc := newTestUseCase(store)
+	ctx := context.Background()
+	plaintext := strings.Repeat("k", minKeyLength)
+
+	require.NoError(t, uc.Ensure(ctx, "bootstrap admin", plaintext, model.RoleAdmin))
+	require.NoError(t, uc.Ensure(ctx, "bootstrap admin", plaintext, model.RoleAdmin))
+	require.Len(t, store.keys, 1)
+	assert.Equal(t, string(model.RoleAdmin), store.keys[0].Role)
+
+	assert.Error(t, uc.Ensure(ctx, "bootstrap admin", "short", model.RoleAdmin))
+}
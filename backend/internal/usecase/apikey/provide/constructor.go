@@ -0,0 +1,34 @@
+package apikeyprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type apiKeyStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.APIKey, error)
+}
+
+type UseCase struct {
+	cfg    *config.Config
+	lg     *log.Logger
+	txm    txmanager.TxManager
+	apiKey apiKeyStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	apiKey apiKeyStore,
+) *UseCase {
+	return &UseCase{
+		cfg:    cfg,
+		lg:     lg,
+		txm:    txm,
+		apiKey: apiKey,
+	}
+}
@@ -0,0 +1,25 @@
+package apikeyprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+)
+
+func (uc *UseCase) List(ctx context.Context) ([]*dto.APIKey, error) {
+	var keys []*model.APIKey
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		keys, err = uc.apiKey.List(ctx, tx)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapAPIKeyModelsToAPIKeyDTOs(keys), nil
+}
@@ -0,0 +1,30 @@
+package auditcreate
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"time"
+)
+
+// Record stores audit entry of a write request, CreatedAt is set when left empty
+func (uc *UseCase) Record(ctx context.Context, entry *dto.AuditEntry) error {
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.audit.Create(ctx, tx, &model.AuditEntry{
+			APIKeyID:  entry.APIKeyID,
+			Actor:     entry.Actor,
+			Method:    entry.Method,
+			Path:      entry.Path,
+			URI:       entry.URI,
+			Status:    entry.Status,
+			RequestID: entry.RequestID,
+			CreatedAt: createdAt,
+		})
+	})
+}
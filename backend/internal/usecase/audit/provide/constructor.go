@@ -0,0 +1,34 @@
+package auditprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type auditStore interface {
+	List(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.AuditEntry, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg   *config.Config
+	lg    *log.Logger
+	txm   txmanager.TxManager
+	audit auditStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	audit auditStore,
+) *UseCase {
+	return &UseCase{
+		cfg:   cfg,
+		lg:    lg,
+		txm:   txm,
+		audit: audit,
+	}
+}
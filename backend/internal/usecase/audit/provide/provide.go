@@ -0,0 +1,44 @@
+package auditprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+type ListCommand struct {
+	pagination.Query
+}
+
+type ListResponse struct {
+	Entries []*dto.AuditEntry
+	Page    *dto.Page
+}
+
+func (uc *UseCase) List(ctx context.Context, cmd *ListCommand) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*model.AuditEntry
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		entries, page, err = uc.audit.List(ctx, tx, pageReq)
+
+		return pagination.Error(err)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return &ListResponse{
+		Entries: mappers.MapAuditEntryModelsToAuditEntryDTOs(entries),
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
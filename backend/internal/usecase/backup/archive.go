@@ -0,0 +1,65 @@
+package backup
+
+import (
+	"errors"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"strings"
+	"time"
+)
+
+// Archive is a zip with one json lines file per table, a row per line as object keyed by column names,
+// and manifest.json describing them. Manifest is written last since it holds row counts
+const (
+	archiveFormat = "playcount-monitor-backup"
+	// ArchiveVersion changes along with layout of archives or set of tables in them,
+	// restore reads archives of this version only
+	ArchiveVersion = 2
+
+	manifestName      = "manifest.json"
+	archivePrefix     = "backup-"
+	archiveExtension  = ".zip"
+	archiveTimeLayout = "20060102T150405Z"
+)
+
+var (
+	ErrUnsupportedArchive = errors.New("unsupported backup archive")
+	ErrSchemaMismatch     = errors.New("schema version of backup archive doesn't match database")
+	ErrDatabaseNotEmpty   = errors.New("database is not empty")
+)
+
+type Manifest struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	// SchemaVersion is the latest migration applied to backed up database
+	SchemaVersion string           `json:"schema_version"`
+	CreatedAt     time.Time        `json:"created_at"`
+	Tables        []*ManifestTable `json:"tables"`
+}
+
+type ManifestTable struct {
+	Name model.BackupTable `json:"name"`
+	File string            `json:"file"`
+	Rows int64             `json:"rows"`
+}
+
+func tableFileName(table model.BackupTable) string {
+	return string(table) + ".jsonl"
+}
+
+// archiveName sorts in order archives are created in
+func archiveName(createdAt time.Time) string {
+	return archivePrefix + createdAt.UTC().Format(archiveTimeLayout) + archiveExtension
+}
+
+func parseArchiveName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, archivePrefix) || !strings.HasSuffix(name, archiveExtension) {
+		return time.Time{}, false
+	}
+
+	createdAt, err := time.Parse(archiveTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, archivePrefix), archiveExtension))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return createdAt, true
+}
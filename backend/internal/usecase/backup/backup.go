@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+// Create writes archive of database to BackupDir and prunes archives beyond retention, it returns archive path.
+// Archive is written under temporary name first so that a failed backup never looks like a whole one
+func (uc *UseCase) Create(ctx context.Context) (string, error) {
+	if err := os.MkdirAll(uc.cfg.BackupDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create backup dir: %w", err)
+	}
+
+	createdAt := time.Now().UTC()
+	path := filepath.Join(uc.cfg.BackupDir, archiveName(createdAt))
+	tmpPath := path + ".tmp"
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup archive: %w", err)
+	}
+
+	_, err = uc.Write(ctx, f, createdAt)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close backup archive: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to rename backup archive: %w", err)
+	}
+
+	if err := uc.Prune(); err != nil {
+		return path, err
+	}
+
+	return path, nil
+}
+
+// Write writes archive to w, tables are read in one transaction so archive is a consistent snapshot
+func (uc *UseCase) Write(ctx context.Context, w io.Writer, createdAt time.Time) (*Manifest, error) {
+	zw := zip.NewWriter(w)
+
+	manifest := &Manifest{
+		Format:    archiveFormat,
+		Version:   ArchiveVersion,
+		CreatedAt: createdAt.UTC(),
+	}
+
+	err := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		version, err := uc.store.SchemaVersion(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if version == "" {
+			return fmt.Errorf("failed to get schema version: no migrations are applied")
+		}
+		manifest.SchemaVersion = version
+
+		for _, table := range model.BackupTables {
+			entry, err := writeTable(ctx, tx, uc.store, zw, table)
+			if err != nil {
+				return err
+			}
+			manifest.Tables = append(manifest.Tables, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mw, err := zw.Create(manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish backup archive: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func writeTable(
+	ctx context.Context,
+	tx txmanager.Tx,
+	store store,
+	zw *zip.Writer,
+	table model.BackupTable,
+) (*ManifestTable, error) {
+	entry := &ManifestTable{Name: table, File: tableFileName(table)}
+
+	fw, err := zw.Create(entry.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s to backup archive: %w", table, err)
+	}
+
+	err = store.StreamRows(ctx, tx, table, func(row json.RawMessage) error {
+		if _, err := fw.Write(row); err != nil {
+			return err
+		}
+		if _, err := fw.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+		entry.Rows++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s to backup archive: %w", table, err)
+	}
+
+	return entry, nil
+}
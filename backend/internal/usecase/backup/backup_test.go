@@ -0,0 +1,197 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"strconv"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	version string
+	tables  map[model.BackupTable][]json.RawMessage
+	resets  []model.BackupTable
+}
+
+func newFakeStore(version string) *fakeStore {
+	return &fakeStore{version: version, tables: make(map[model.BackupTable][]json.RawMessage)}
+}
+
+func (s *fakeStore) SchemaVersion(context.Context, txmanager.Tx) (string, error) {
+	return s.version, nil
+}
+
+func (s *fakeStore) StreamRows(_ context.Context, _ txmanager.Tx, table model.BackupTable, fn func(row json.RawMessage) error) error {
+	for _, row := range s.tables[table] {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *fakeStore) Count(_ context.Context, _ txmanager.Tx, table model.BackupTable) (int64, error) {
+	return int64(len(s.tables[table])), nil
+}
+
+func (s *fakeStore) DeleteAll(_ context.Context, _ txmanager.Tx, table model.BackupTable) error {
+	delete(s.tables, table)
+	return nil
+}
+
+func (s *fakeStore) InsertRows(_ context.Context, _ txmanager.Tx, table model.BackupTable, rows []json.RawMessage) error {
+	s.tables[table] = append(s.tables[table], rows...)
+	return nil
+}
+
+func (s *fakeStore) ResetSequence(_ context.Context, _ txmanager.Tx, table model.BackupTable) error {
+	s.resets = append(s.resets, table)
+	return nil
+}
+
+const testSchemaVersion = "20261104-add-last-passcount-columns.sql"
+
+func newTestUseCase(cfg *config.Config, store store) *UseCase {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	return New(cfg, lg, txmanagertest.TxManager{}, store)
+}
+
+func writeTestArchive(t *testing.T) []byte {
+	source := newFakeStore(testSchemaVersion)
+	source.tables[model.BackupTableUsers] = []json.RawMessage{
+		json.RawMessage(`{"id":1,"username":"a","user_stats":{"2024-01-01T00:00:00Z":{"play_count":1}}}`),
+		json.RawMessage(`{"id":2,"username":"b"}`),
+	}
+	source.tables[model.BackupTableMapsets] = []json.RawMessage{json.RawMessage(`{"id":10,"user_id":1}`)}
+	source.tables[model.BackupTableTracks] = []json.RawMessage{json.RawMessage(`{"id":5,"tracked_at":"2024-01-02T00:00:00"}`)}
+
+	var buf bytes.Buffer
+	manifest, err := newTestUseCase(&config.Config{}, source).Write(context.Background(), &buf, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, testSchemaVersion, manifest.SchemaVersion)
+	assert.Equal(t, int64(2), manifest.Tables[0].Rows)
+
+	return buf.Bytes()
+}
+
+func restoreArchive(uc *UseCase, archive []byte) (*Manifest, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.restore(context.Background(), zr)
+}
+
+func TestUseCase_WriteAndRestore(t *testing.T) {
+	archive := writeTestArchive(t)
+
+	// migrations seed runs and followed user, restore replaces their rows
+	target := newFakeStore(testSchemaVersion)
+	target.tables[model.BackupTableTracks] = []json.RawMessage{json.RawMessage(`{"id":1}`)}
+	target.tables[model.BackupTableFollowing] = []json.RawMessage{json.RawMessage(`{"id":7192129}`)}
+
+	manifest, err := restoreArchive(newTestUseCase(&config.Config{}, target), archive)
+	require.NoError(t, err)
+	assert.Len(t, manifest.Tables, len(model.BackupTables))
+
+	require.Len(t, target.tables[model.BackupTableUsers], 2)
+	assert.JSONEq(t, `{"id":1,"username":"a","user_stats":{"2024-01-01T00:00:00Z":{"play_count":1}}}`, string(target.tables[model.BackupTableUsers][0]))
+	assert.Len(t, target.tables[model.BackupTableMapsets], 1)
+	require.Len(t, target.tables[model.BackupTableTracks], 1)
+	assert.JSONEq(t, `{"id":5,"tracked_at":"2024-01-02T00:00:00"}`, string(target.tables[model.BackupTableTracks][0]))
+	assert.Empty(t, target.tables[model.BackupTableFollowing])
+	assert.Equal(t, model.BackupTables, target.resets)
+}
+
+func TestUseCase_Restore_Rejected(t *testing.T) {
+	archive := writeTestArchive(t)
+
+	t.Run("schema mismatch", func(t *testing.T) {
+		target := newFakeStore("20261103-add-mapsets-search-vector.sql")
+		_, err := restoreArchive(newTestUseCase(&config.Config{}, target), archive)
+		assert.True(t, errors.Is(err, ErrSchemaMismatch))
+		assert.Empty(t, target.tables)
+	})
+
+	t.Run("database not empty", func(t *testing.T) {
+		for _, table := range model.BackupTables {
+			if seededTables[table] {
+				continue
+			}
+
+			target := newFakeStore(testSchemaVersion)
+			target.tables[table] = []json.RawMessage{json.RawMessage(`{"id":1}`)}
+			_, err := restoreArchive(newTestUseCase(&config.Config{}, target), archive)
+			assert.True(t, errors.Is(err, ErrDatabaseNotEmpty), "table %s", table)
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create(manifestName)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"format":"playcount-monitor-backup","version":` + strconv.Itoa(ArchiveVersion+1) + `,"schema_version":"` + testSchemaVersion + `"}`))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		_, err = restoreArchive(newTestUseCase(&config.Config{}, newFakeStore(testSchemaVersion)), buf.Bytes())
+		assert.True(t, errors.Is(err, ErrUnsupportedArchive))
+	})
+}
+
+func TestUseCase_CreateAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	uc := newTestUseCase(&config.Config{BackupDir: dir, BackupRetention: 2}, newFakeStore(testSchemaVersion))
+
+	old := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	for _, createdAt := range old {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, archiveName(createdAt)), nil, 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644))
+
+	path, err := uc.Create(context.Background())
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{archiveName(old[1]), filepath.Base(path), "notes.txt"}, names)
+
+	last, err := uc.LastCreatedAt()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), last, time.Minute)
+}
+
+func TestUseCase_LastCreatedAt_NoDir(t *testing.T) {
+	uc := newTestUseCase(&config.Config{BackupDir: filepath.Join(t.TempDir(), "missing")}, newFakeStore(testSchemaVersion))
+
+	last, err := uc.LastCreatedAt()
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+}
@@ -0,0 +1,40 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type store interface {
+	SchemaVersion(ctx context.Context, tx txmanager.Tx) (string, error)
+	StreamRows(ctx context.Context, tx txmanager.Tx, table model.BackupTable, fn func(row json.RawMessage) error) error
+	Count(ctx context.Context, tx txmanager.Tx, table model.BackupTable) (int64, error)
+	DeleteAll(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error
+	InsertRows(ctx context.Context, tx txmanager.Tx, table model.BackupTable, rows []json.RawMessage) error
+	ResetSequence(ctx context.Context, tx txmanager.Tx, table model.BackupTable) error
+}
+
+type UseCase struct {
+	cfg   *config.Config
+	lg    *log.Logger
+	txm   txmanager.TxManager
+	store store
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	store store,
+) *UseCase {
+	return &UseCase{
+		cfg:   cfg,
+		lg:    lg,
+		txm:   txm,
+		store: store,
+	}
+}
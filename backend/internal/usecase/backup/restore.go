@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+// restoreBatchSize is how many rows are inserted with one statement
+const restoreBatchSize = 500
+
+// seededTables are filled by migrations with the first tracking and cleaning run and the default followed user,
+// so restore replaces their rows instead of requiring them to be empty
+var seededTables = map[model.BackupTable]bool{
+	model.BackupTableFollowing: true,
+	model.BackupTableTracks:    true,
+	model.BackupTableCleans:    true,
+}
+
+// Restore loads archive at path into database in one transaction. Database has to be migrated to
+// the same schema version as the archive and every backed up table but seeded ones has to be empty
+func (uc *UseCase) Restore(ctx context.Context, path string) (*Manifest, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedArchive, err)
+	}
+	defer zr.Close()
+
+	return uc.restore(ctx, &zr.Reader)
+}
+
+func (uc *UseCase) restore(ctx context.Context, zr *zip.Reader) (*Manifest, error) {
+	manifest, files, err := readManifest(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		version, err := uc.store.SchemaVersion(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if version != manifest.SchemaVersion {
+			return fmt.Errorf("%w: archive has %q, database has %q", ErrSchemaMismatch, manifest.SchemaVersion, version)
+		}
+
+		for _, table := range model.BackupTables {
+			if seededTables[table] {
+				continue
+			}
+
+			count, err := uc.store.Count(ctx, tx, table)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: %s has %d rows", ErrDatabaseNotEmpty, table, count)
+			}
+		}
+
+		for _, entry := range manifest.Tables {
+			if seededTables[entry.Name] {
+				if err := uc.store.DeleteAll(ctx, tx, entry.Name); err != nil {
+					return err
+				}
+			}
+
+			if err := loadTable(ctx, tx, uc.store, files[entry.File], entry); err != nil {
+				return err
+			}
+
+			if err := uc.store.ResetSequence(ctx, tx, entry.Name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// readManifest validates that archive is of supported version, lists every backed up table in restore order
+// and holds their files
+func readManifest(zr *zip.Reader) (*Manifest, map[string]*zip.File, error) {
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	mf, ok := files[manifestName]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s is missing", ErrUnsupportedArchive, manifestName)
+	}
+
+	r, err := mf.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read backup manifest: %w", err)
+	}
+	defer r.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid manifest: %v", ErrUnsupportedArchive, err)
+	}
+
+	if manifest.Format != archiveFormat {
+		return nil, nil, fmt.Errorf("%w: format %q", ErrUnsupportedArchive, manifest.Format)
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, nil, fmt.Errorf("%w: version %d, expected %d", ErrUnsupportedArchive, manifest.Version, ArchiveVersion)
+	}
+
+	if len(manifest.Tables) != len(model.BackupTables) {
+		return nil, nil, fmt.Errorf("%w: %d tables, expected %d", ErrUnsupportedArchive, len(manifest.Tables), len(model.BackupTables))
+	}
+	for i, entry := range manifest.Tables {
+		if entry.Name != model.BackupTables[i] {
+			return nil, nil, fmt.Errorf("%w: table %q, expected %q", ErrUnsupportedArchive, entry.Name, model.BackupTables[i])
+		}
+		if _, ok := files[entry.File]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s is missing", ErrUnsupportedArchive, entry.File)
+		}
+	}
+
+	return &manifest, files, nil
+}
+
+// loadTable inserts rows in batches, zip checks checksum of file once it is read to the end
+func loadTable(ctx context.Context, tx txmanager.Tx, store store, f *zip.File, entry *ManifestTable) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", entry.File, err)
+	}
+	defer r.Close()
+
+	var loaded int64
+	batch := make([]json.RawMessage, 0, restoreBatchSize)
+	flush := func() error {
+		if err := store.InsertRows(ctx, tx, entry.Name, batch); err != nil {
+			return err
+		}
+		loaded += int64(len(batch))
+		batch = batch[:0]
+
+		return nil
+	}
+
+	dec := json.NewDecoder(r)
+	for {
+		var row json.RawMessage
+		err := dec.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.File, err)
+		}
+
+		batch = append(batch, row)
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if loaded != entry.Rows {
+		return fmt.Errorf("%w: %s has %d rows, manifest lists %d", ErrUnsupportedArchive, entry.File, loaded, entry.Rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+type archiveFile struct {
+	name      string
+	createdAt time.Time
+}
+
+// Prune removes archives in BackupDir beyond the newest BackupRetention ones, files not named as archives are left alone
+func (uc *UseCase) Prune() error {
+	if uc.cfg.BackupRetention <= 0 {
+		return nil
+	}
+
+	archives, err := listArchives(uc.cfg.BackupDir)
+	if err != nil {
+		return err
+	}
+
+	if len(archives) <= uc.cfg.BackupRetention {
+		return nil
+	}
+
+	for _, archive := range archives[uc.cfg.BackupRetention:] {
+		if err := os.Remove(filepath.Join(uc.cfg.BackupDir, archive.name)); err != nil {
+			return fmt.Errorf("failed to remove backup archive: %w", err)
+		}
+		uc.lg.Infof("removed backup archive %s beyond retention", archive.name)
+	}
+
+	return nil
+}
+
+// LastCreatedAt is creation time of the newest archive in BackupDir, zero when there is none
+func (uc *UseCase) LastCreatedAt() (time.Time, error) {
+	archives, err := listArchives(uc.cfg.BackupDir)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(archives) == 0 {
+		return time.Time{}, nil
+	}
+
+	return archives[0].createdAt, nil
+}
+
+// listArchives lists archives newest first, missing dir has no archives
+func listArchives(dir string) ([]*archiveFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup archives: %w", err)
+	}
+
+	var archives []*archiveFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if createdAt, ok := parseArchiveName(entry.Name()); ok {
+			archives = append(archives, &archiveFile{name: entry.Name(), createdAt: createdAt})
+		}
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].createdAt.After(archives[j].createdAt)
+	})
+
+	return archives, nil
+}
@@ -0,0 +1,57 @@
+// Package cache keeps results of read use cases between tracking runs, data they are computed from
+// changes at most once per run apart from edits made through api
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Interface is store of cached results, in-memory LRU is used by default and can be replaced
+// with a store shared between instances
+type Interface interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+	// Purge drops every cached result
+	Purge()
+	// Generation is number of purges done so far, results loaded across a purge may be stale
+	Generation() uint64
+}
+
+// Fetch returns result cached under key or loads and caches it, errors are not cached.
+// Results are shared between requests so they must not be modified. nil cache disables caching
+func Fetch[T any](c Interface, key string, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+
+	if cached, ok := c.Get(key); ok {
+		if res, ok := cached.(T); ok {
+			return res, nil
+		}
+	}
+
+	generation := c.Generation()
+	res, err := load()
+	if err != nil {
+		return res, err
+	}
+	// cache was purged while loading, result may be read before the purged change so it is not kept
+	if c.Generation() == generation {
+		c.Set(key, res)
+	}
+
+	return res, nil
+}
+
+// Key is cache key of use case method called with args, args are marshaled as JSON so that
+// pointers and maps make the same key for the same values
+func Key(method string, args ...interface{}) string {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		// args are plain commands, falling back to their printed form still keeps keys apart
+		return method + fmt.Sprintf("%+v", args)
+	}
+
+	return method + string(encoded)
+}
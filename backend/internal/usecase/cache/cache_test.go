@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	lru := NewLRU(2)
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+
+	// reading a makes b the least recently used one
+	_, ok := lru.Get("a")
+	assert.True(t, ok)
+	lru.Set("c", 3)
+
+	_, ok = lru.Get("b")
+	assert.False(t, ok)
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, lru.Len())
+
+	lru.Purge()
+	assert.Equal(t, 0, lru.Len())
+	_, ok = lru.Get("c")
+	assert.False(t, ok)
+}
+
+func TestFetch(t *testing.T) {
+	lru := NewLRU(10)
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		return 42, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := Fetch(lru, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, res)
+	}
+	assert.Equal(t, 1, loads)
+
+	// errors are not cached
+	_, err := Fetch(lru, "failing", func() (int, error) { return 0, errors.New("db is down") })
+	assert.Error(t, err)
+	_, ok := lru.Get("failing")
+	assert.False(t, ok)
+
+	// nil cache loads every time
+	_, err = Fetch[int](nil, "key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestFetch_PurgeDuringLoad(t *testing.T) {
+	lru := NewLRU(10)
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		if loads == 1 {
+			// tracking run completes while result is loaded from data before it
+			lru.Purge()
+		}
+		return loads, nil
+	}
+
+	res, err := Fetch(lru, "key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res)
+	assert.Equal(t, 0, lru.Len())
+
+	res, err = Fetch(lru, "key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res)
+
+	// result loaded without purge is cached
+	res, err = Fetch(lru, "key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res)
+	assert.Equal(t, 2, loads)
+}
+
+func TestKey(t *testing.T) {
+	one, other := 1.0, 1.0
+	type command struct {
+		Min    *float64
+		Filter map[string]interface{}
+	}
+
+	// pointers and maps with equal values make equal keys
+	assert.Equal(t,
+		Key("List", &command{Min: &one, Filter: map[string]interface{}{"status": "ranked", "genre": "Rock"}}),
+		Key("List", &command{Min: &other, Filter: map[string]interface{}{"genre": "Rock", "status": "ranked"}}),
+	)
+	assert.NotEqual(t, Key("List", 1), Key("Search", 1))
+	assert.Equal(t, `Get[7,true]`, Key("Get", 7, true))
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"sync"
+	"time"
+)
+
+type trackStore interface {
+	GetLastTrack(ctx context.Context, tx txmanager.Tx) (*model.Track, error)
+}
+
+// Invalidator purges cache once a tracking run completes, runs are done by tracking worker process
+// and are noticed through track records it stores after each of them
+type Invalidator struct {
+	lg    *log.Logger
+	txm   txmanager.TxManager
+	track trackStore
+	cache Interface
+
+	mu          sync.Mutex
+	checked     bool
+	lastTracked time.Time
+}
+
+func NewInvalidator(
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	track trackStore,
+	cache Interface,
+) *Invalidator {
+	return &Invalidator{
+		lg:    lg,
+		txm:   txm,
+		track: track,
+		cache: cache,
+	}
+}
+
+// Check purges cache when there is a track record newer than the one seen last time,
+// the first check purges too since results may have been cached before it
+func (i *Invalidator) Check(ctx context.Context) error {
+	if i.cache == nil {
+		return nil
+	}
+
+	var trackedAt time.Time
+	err := i.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		track, err := i.track.GetLastTrack(ctx, tx)
+		if err != nil {
+			return err
+		}
+		trackedAt = track.TrackedAt
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.checked && !trackedAt.After(i.lastTracked) {
+		return nil
+	}
+
+	i.checked, i.lastTracked = true, trackedAt
+	i.cache.Purge()
+	i.lg.Infof("cache purged after tracking run of %v", trackedAt)
+
+	return nil
+}
+
+// Invalidate purges cache right away, it is called after edits made through api
+func (i *Invalidator) Invalidate() {
+	if i.cache != nil {
+		i.cache.Purge()
+	}
+}
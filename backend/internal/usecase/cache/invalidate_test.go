@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"testing"
+	"time"
+)
+
+type fakeTrackStore struct {
+	last *model.Track
+}
+
+func (s *fakeTrackStore) GetLastTrack(_ context.Context, _ txmanager.Tx) (*model.Track, error) {
+	return s.last, nil
+}
+
+func TestInvalidator_Check(t *testing.T) {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	lru := NewLRU(10)
+	tracks := &fakeTrackStore{last: &model.Track{TrackedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}}
+	invalidator := NewInvalidator(lg, txmanagertest.TxManager{}, tracks, lru)
+
+	// results cached before the first check may be of any run
+	lru.Set("key", 1)
+	assert.NoError(t, invalidator.Check(context.Background()))
+	assert.Equal(t, 0, lru.Len())
+
+	// same run keeps cache
+	lru.Set("key", 1)
+	assert.NoError(t, invalidator.Check(context.Background()))
+	assert.Equal(t, 1, lru.Len())
+
+	// completed run purges it
+	tracks.last = &model.Track{TrackedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, invalidator.Check(context.Background()))
+	assert.Equal(t, 0, lru.Len())
+}
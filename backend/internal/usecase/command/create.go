@@ -18,23 +18,25 @@ type CreateUserCommand struct {
 }
 
 type CreateMapsetCommand struct {
-	Id             int                     `json:"id"`
-	Artist         string                  `json:"artist"`
-	Title          string                  `json:"title"`
-	Covers         map[string]string       `json:"covers"`
-	Status         string                  `json:"status"`
-	LastUpdated    time.Time               `json:"last_updated"`
-	UserId         int                     `json:"user_id"`
-	PreviewUrl     string                  `json:"preview_url"`
-	Tags           string                  `json:"tags"`
-	PlayCount      int                     `json:"play_count"`
-	FavouriteCount int                     `json:"favourite_count"`
-	CommentsCount  int                     `json:"comments_count"`
-	Bpm            float64                 `json:"bpm"`
-	Creator        string                  `json:"creator"`
-	Language       string                  `json:"language"`
-	Genre          string                  `json:"genre"`
-	Beatmaps       []*CreateBeatmapCommand `json:"beatmaps"`
+	Id                int                     `json:"id"`
+	Artist            string                  `json:"artist"`
+	Title             string                  `json:"title"`
+	Covers            map[string]string       `json:"covers"`
+	Status            string                  `json:"status"`
+	LastUpdated       time.Time               `json:"last_updated"`
+	SubmittedDate     time.Time               `json:"submitted_date"`
+	UserId            int                     `json:"user_id"`
+	PreviewUrl        string                  `json:"preview_url"`
+	Tags              string                  `json:"tags"`
+	PlayCount         int                     `json:"play_count"`
+	FavouriteCount    int                     `json:"favourite_count"`
+	CommentsCount     int                     `json:"comments_count"`
+	CommentsFetchedAt time.Time               `json:"comments_fetched_at"`
+	Bpm               float64                 `json:"bpm"`
+	Creator           string                  `json:"creator"`
+	Language          string                  `json:"language"`
+	Genre             string                  `json:"genre"`
+	Beatmaps          []*CreateBeatmapCommand `json:"beatmaps"`
 }
 
 type CreateBeatmapCommand struct {
@@ -0,0 +1,36 @@
+package digestmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type digestStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, subscription *model.DigestSubscription) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.DigestSubscription, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+}
+
+type UseCase struct {
+	cfg    *config.Config
+	lg     *log.Logger
+	txm    txmanager.TxManager
+	digest digestStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	digest digestStore,
+) *UseCase {
+	return &UseCase{
+		cfg:    cfg,
+		lg:     lg,
+		txm:    txm,
+		digest: digest,
+	}
+}
@@ -0,0 +1,91 @@
+package digestmanage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"net/mail"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"time"
+)
+
+// Command describes subscription, empty UserIDs subscribe to every tracked mapper
+type Command struct {
+	Email     string
+	Frequency string
+	UserIDs   []int
+}
+
+func (uc *UseCase) Create(ctx context.Context, cmd *Command) (*dto.DigestSubscription, error) {
+	if err := validate(cmd); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := mappers.MapDigestUserIDsToUserIDsJSON(cmd.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &model.DigestSubscription{
+		Email:     cmd.Email,
+		Frequency: cmd.Frequency,
+		UserIDs:   userIDs,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		err := uc.digest.Create(ctx, tx, subscription)
+		if repository.IsUniqueViolation(err) {
+			return domainerror.Conflict(err, "%s is already subscribed to digest", subscription.Email)
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapDigestSubscriptionModelToDigestSubscriptionDTO(subscription)
+}
+
+func (uc *UseCase) Delete(ctx context.Context, id int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		_, err := uc.digest.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("digest subscription %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		return uc.digest.Delete(ctx, tx, id)
+	})
+}
+
+// validate checks command and normalizes its email address
+func validate(cmd *Command) error {
+	address, err := mail.ParseAddress(cmd.Email)
+	if err != nil {
+		return domainerror.Validation("invalid digest subscription email: %v", err)
+	}
+	cmd.Email = address.Address
+
+	switch model.DigestFrequency(cmd.Frequency) {
+	case model.DigestDaily, model.DigestWeekly:
+	default:
+		return domainerror.Validation("invalid digest subscription: frequency must be %q or %q", model.DigestDaily, model.DigestWeekly)
+	}
+
+	for _, userID := range cmd.UserIDs {
+		if userID <= 0 {
+			return domainerror.Validation("invalid digest subscription user id %v", userID)
+		}
+	}
+
+	return nil
+}
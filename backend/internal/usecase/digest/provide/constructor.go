@@ -0,0 +1,34 @@
+package digestprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type digestStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.DigestSubscription, error)
+}
+
+type UseCase struct {
+	cfg    *config.Config
+	lg     *log.Logger
+	txm    txmanager.TxManager
+	digest digestStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	digest digestStore,
+) *UseCase {
+	return &UseCase{
+		cfg:    cfg,
+		lg:     lg,
+		txm:    txm,
+		digest: digest,
+	}
+}
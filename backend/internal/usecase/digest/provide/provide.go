@@ -0,0 +1,25 @@
+package digestprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+)
+
+func (uc *UseCase) List(ctx context.Context) ([]*dto.DigestSubscription, error) {
+	var subscriptions []*model.DigestSubscription
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		subscriptions, err = uc.digest.List(ctx, tx)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapDigestSubscriptionModelsToDigestSubscriptionDTOs(subscriptions)
+}
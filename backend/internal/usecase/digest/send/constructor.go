@@ -0,0 +1,55 @@
+package digestsend
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/mailer"
+	"time"
+)
+
+type digestStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.DigestSubscription, error)
+	SetLastSent(ctx context.Context, tx txmanager.Tx, id int, sentAt time.Time) error
+}
+
+type mapsetStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.Mapset, error)
+	ListForUser(ctx context.Context, tx txmanager.Tx, userID int) ([]*model.Mapset, error)
+}
+
+type mapsetEventStore interface {
+	ListForUsersSince(ctx context.Context, tx txmanager.Tx, userIDs []int, since time.Time) ([]*model.MapsetEvent, error)
+}
+
+type UseCase struct {
+	cfg         *config.Config
+	lg          *log.Logger
+	txm         txmanager.TxManager
+	digest      digestStore
+	mapset      mapsetStore
+	mapsetEvent mapsetEventStore
+	mailer      mailer.Interface
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	digest digestStore,
+	mapset mapsetStore,
+	mapsetEvent mapsetEventStore,
+	mailer mailer.Interface,
+) *UseCase {
+	return &UseCase{
+		cfg:         cfg,
+		lg:          lg,
+		txm:         txm,
+		digest:      digest,
+		mapset:      mapset,
+		mapsetEvent: mapsetEvent,
+		mailer:      mailer,
+	}
+}
@@ -0,0 +1,145 @@
+package digestsend
+
+import (
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/timeseries"
+	"sort"
+	"time"
+)
+
+const mapsetURLFormat = "https://osu.ppy.sh/beatmapsets/%v"
+
+// Digest summarizes activity of mappers between From and To
+type Digest struct {
+	Frequency     string
+	From          time.Time
+	To            time.Time
+	TopMapsets    []*MapsetGain
+	NewMapsets    []*MapsetChange
+	StatusChanges []*MapsetChange
+}
+
+type MapsetGain struct {
+	Title     string
+	Creator   string
+	URL       string
+	Playcount int
+	Gain      int
+}
+
+type MapsetChange struct {
+	Title          string
+	Creator        string
+	URL            string
+	PreviousStatus string
+	Status         string
+	OccurredAt     time.Time
+}
+
+func (d *Digest) IsEmpty() bool {
+	return len(d.TopMapsets) == 0 && len(d.NewMapsets) == 0 && len(d.StatusChanges) == 0
+}
+
+// buildDigest ranks mapsets by playcount gained since from and lists mapset events,
+// events of mapsets that are not in mapsets are skipped
+func buildDigest(
+	frequency string,
+	mapsets []*model.Mapset,
+	events []*model.MapsetEvent,
+	from time.Time,
+	to time.Time,
+	topN int,
+) (*Digest, error) {
+	digest := &Digest{
+		Frequency: frequency,
+		From:      from,
+		To:        to,
+	}
+
+	mapsetsByID := make(map[int]*model.Mapset, len(mapsets))
+	for _, mapset := range mapsets {
+		mapsetsByID[mapset.ID] = mapset
+		if mapset.RemovalStatus != string(model.MapsetActive) {
+			continue
+		}
+
+		stats, err := mappers.MapStatsJSONToMapsetStats(mapset.MapsetStats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map stats of mapset %v: %w", mapset.ID, err)
+		}
+
+		series := timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Playcount) })
+		gain, ok := gainSince(series, from)
+		if !ok || gain <= 0 {
+			continue
+		}
+
+		digest.TopMapsets = append(digest.TopMapsets, &MapsetGain{
+			Title:     mapsetTitle(mapset),
+			Creator:   mapset.Creator,
+			URL:       fmt.Sprintf(mapsetURLFormat, mapset.ID),
+			Playcount: mapset.LastPlaycount,
+			Gain:      gain,
+		})
+	}
+
+	sort.SliceStable(digest.TopMapsets, func(i, j int) bool {
+		return digest.TopMapsets[i].Gain > digest.TopMapsets[j].Gain
+	})
+	if len(digest.TopMapsets) > topN {
+		digest.TopMapsets = digest.TopMapsets[:topN]
+	}
+
+	for _, event := range events {
+		mapset, ok := mapsetsByID[event.MapsetID]
+		if !ok {
+			continue
+		}
+
+		change := &MapsetChange{
+			Title:          mapsetTitle(mapset),
+			Creator:        mapset.Creator,
+			URL:            fmt.Sprintf(mapsetURLFormat, mapset.ID),
+			PreviousStatus: event.PreviousStatus,
+			Status:         event.Status,
+			OccurredAt:     event.OccurredAt,
+		}
+
+		switch event.Kind {
+		case string(model.MapsetEventNew):
+			digest.NewMapsets = append(digest.NewMapsets, change)
+		case string(model.MapsetEventStatusChange):
+			digest.StatusChanges = append(digest.StatusChanges, change)
+		}
+	}
+
+	return digest, nil
+}
+
+// gainSince is the growth between the last point at or before since and the latest point,
+// the earliest point is the baseline when whole series is newer than since
+func gainSince(series timeseries.Series, since time.Time) (int, bool) {
+	if len(series) < 2 {
+		return 0, false
+	}
+
+	baseline := 0
+	for i, point := range series {
+		if point.Time.After(since) {
+			break
+		}
+		baseline = i
+	}
+
+	if baseline == len(series)-1 {
+		return 0, false
+	}
+
+	return int(series[len(series)-1].Value - series[baseline].Value), true
+}
+
+func mapsetTitle(mapset *model.Mapset) string {
+	return mapset.Artist + " - " + mapset.Title
+}
@@ -0,0 +1,122 @@
+package digestsend
+
+import (
+	"encoding/json"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var digestNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func testMapset(t *testing.T, id int, playcounts map[time.Time]int) *model.Mapset {
+	t.Helper()
+
+	stats := make(model.MapsetStats, len(playcounts))
+	last := 0
+	var lastTime time.Time
+	for at, playcount := range playcounts {
+		stats[at] = &model.MapsetStatsModel{Playcount: playcount}
+		if at.After(lastTime) {
+			lastTime, last = at, playcount
+		}
+	}
+
+	statsJSON, err := json.Marshal(stats)
+	require.NoError(t, err)
+
+	return &model.Mapset{
+		ID:            id,
+		Artist:        "Artist",
+		Title:         "Title " + string(rune('A'+id)),
+		Creator:       "mapper",
+		MapsetStats:   statsJSON,
+		LastPlaycount: last,
+		RemovalStatus: string(model.MapsetActive),
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	from := digestNow.Add(-24 * time.Hour)
+
+	mapsets := []*model.Mapset{
+		testMapset(t, 1, map[time.Time]int{
+			from.Add(-time.Hour): 100,
+			from.Add(time.Hour):  150,
+			digestNow:            300,
+		}),
+		testMapset(t, 2, map[time.Time]int{
+			from.Add(-48 * time.Hour): 10,
+			from.Add(-time.Hour):      20,
+			digestNow:                 520,
+		}),
+		// no growth
+		testMapset(t, 3, map[time.Time]int{
+			from.Add(-time.Hour): 40,
+			digestNow:            40,
+		}),
+		// uploaded within the window, earliest point is the baseline
+		testMapset(t, 4, map[time.Time]int{
+			from.Add(2 * time.Hour): 0,
+			digestNow:               50,
+		}),
+	}
+	removed := testMapset(t, 5, map[time.Time]int{
+		from.Add(-time.Hour): 0,
+		digestNow:            1000,
+	})
+	removed.RemovalStatus = string(model.MapsetDeleted)
+	mapsets = append(mapsets, removed)
+
+	events := []*model.MapsetEvent{
+		{MapsetID: 4, Kind: string(model.MapsetEventNew), Status: "pending", OccurredAt: from.Add(2 * time.Hour)},
+		{MapsetID: 1, Kind: string(model.MapsetEventStatusChange), PreviousStatus: "pending", Status: "ranked", OccurredAt: digestNow},
+		// not one of chosen mappers' mapsets
+		{MapsetID: 42, Kind: string(model.MapsetEventNew), Status: "graveyard", OccurredAt: digestNow},
+	}
+
+	digest, err := buildDigest("daily", mapsets, events, from, digestNow, 2)
+	require.NoError(t, err)
+
+	require.Len(t, digest.TopMapsets, 2)
+	assert.Equal(t, 500, digest.TopMapsets[0].Gain)
+	assert.Equal(t, 520, digest.TopMapsets[0].Playcount)
+	assert.Equal(t, "https://osu.ppy.sh/beatmapsets/2", digest.TopMapsets[0].URL)
+	assert.Equal(t, 200, digest.TopMapsets[1].Gain)
+
+	require.Len(t, digest.NewMapsets, 1)
+	assert.Equal(t, "https://osu.ppy.sh/beatmapsets/4", digest.NewMapsets[0].URL)
+
+	require.Len(t, digest.StatusChanges, 1)
+	assert.Equal(t, "pending", digest.StatusChanges[0].PreviousStatus)
+	assert.Equal(t, "ranked", digest.StatusChanges[0].Status)
+}
+
+func TestRender(t *testing.T) {
+	digest := &Digest{
+		Frequency: "weekly",
+		From:      digestNow.Add(-7 * 24 * time.Hour),
+		To:        digestNow,
+		TopMapsets: []*MapsetGain{
+			{Title: "Artist - <Title>", Creator: "mapper", URL: "https://osu.ppy.sh/beatmapsets/1", Playcount: 300, Gain: 200},
+		},
+		StatusChanges: []*MapsetChange{
+			{Title: "Artist - Other", Creator: "mapper", URL: "https://osu.ppy.sh/beatmapsets/2", PreviousStatus: "pending", Status: "ranked", OccurredAt: digestNow},
+		},
+	}
+
+	text, html, err := render(digest)
+	require.NoError(t, err)
+
+	assert.Contains(t, text, "Your weekly playcount digest for 2026-10-19")
+	assert.Contains(t, text, "+200 Artist - <Title> by mapper (300 plays)")
+	assert.Contains(t, text, "pending -> ranked")
+	assert.NotContains(t, text, "New uploads")
+
+	assert.Contains(t, html, `<a href="https://osu.ppy.sh/beatmapsets/1">Artist - &lt;Title&gt;</a>`)
+	assert.Contains(t, html, "Status changes")
+	assert.NotContains(t, html, "New uploads")
+}
@@ -0,0 +1,51 @@
+package digestsend
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var templateFuncs = map[string]any{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+}
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(templateFuncs).ParseFS(templatesFS, "templates/digest.html.tmpl"))
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(templateFuncs).ParseFS(templatesFS, "templates/digest.txt.tmpl"))
+)
+
+type templateData struct {
+	Subject string
+	Digest  *Digest
+}
+
+func subject(digest *Digest) string {
+	return fmt.Sprintf("Your %s playcount digest for %s", digest.Frequency, digest.To.UTC().Format("2006-01-02"))
+}
+
+// render returns plain text and html bodies of the digest
+func render(digest *Digest) (string, string, error) {
+	data := templateData{
+		Subject: subject(digest),
+		Digest:  digest,
+	}
+
+	var text bytes.Buffer
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return "", "", fmt.Errorf("failed to render text digest: %w", err)
+	}
+
+	var html bytes.Buffer
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return "", "", fmt.Errorf("failed to render html digest: %w", err)
+	}
+
+	return text.String(), html.String(), nil
+}
@@ -0,0 +1,138 @@
+package digestsend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/mailer"
+	"time"
+)
+
+var periods = map[model.DigestFrequency]time.Duration{
+	model.DigestDaily:  24 * time.Hour,
+	model.DigestWeekly: 7 * 24 * time.Hour,
+}
+
+// Send mails a digest to every subscription whose period has passed since the last one,
+// a failing subscription does not stop the others
+func (uc *UseCase) Send(ctx context.Context) error {
+	var subscriptions []*model.DigestSubscription
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		subscriptions, err = uc.digest.List(ctx, tx)
+		return err
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	now := time.Now().UTC()
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		period, ok := periods[model.DigestFrequency(subscription.Frequency)]
+		if !ok {
+			errs = append(errs, fmt.Errorf("digest subscription %v: unknown frequency %q", subscription.ID, subscription.Frequency))
+			continue
+		}
+
+		if !uc.isDue(subscription, period, now) {
+			continue
+		}
+
+		if err := uc.sendOne(ctx, subscription, period, now); err != nil {
+			errs = append(errs, fmt.Errorf("digest subscription %v: %w", subscription.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isDue tolerates half of check interval so digests do not drift later by one check every period
+func (uc *UseCase) isDue(subscription *model.DigestSubscription, period time.Duration, now time.Time) bool {
+	if subscription.LastSentAt == nil {
+		return true
+	}
+
+	return now.Sub(*subscription.LastSentAt) >= period-uc.cfg.DigestCheckInterval/2
+}
+
+func (uc *UseCase) sendOne(
+	ctx context.Context,
+	subscription *model.DigestSubscription,
+	period time.Duration,
+	now time.Time,
+) error {
+	var userIDs []int
+	if err := json.Unmarshal(subscription.UserIDs, &userIDs); err != nil {
+		return fmt.Errorf("failed to unmarshal user ids: %w", err)
+	}
+
+	from := now.Add(-period)
+	if subscription.LastSentAt != nil {
+		from = *subscription.LastSentAt
+	}
+
+	var digest *Digest
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapsets, err := uc.listMapsets(ctx, tx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		events, err := uc.mapsetEvent.ListForUsersSince(ctx, tx, userIDs, from)
+		if err != nil {
+			return err
+		}
+
+		digest, err = buildDigest(subscription.Frequency, mapsets, events, from, now, uc.cfg.DigestTopMapsets)
+		return err
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	if digest.IsEmpty() {
+		uc.lg.Infof("digest for subscription %v is empty, nothing to send", subscription.ID)
+	} else {
+		text, html, err := render(digest)
+		if err != nil {
+			return err
+		}
+
+		err = uc.mailer.Send(ctx, &mailer.Message{
+			To:      []string{subscription.Email},
+			Subject: subject(digest),
+			Text:    text,
+			HTML:    html,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.digest.SetLastSent(ctx, tx, subscription.ID, now)
+	})
+}
+
+// listMapsets lists mapsets of chosen mappers, or of every tracked mapper when none are chosen
+func (uc *UseCase) listMapsets(ctx context.Context, tx txmanager.Tx, userIDs []int) ([]*model.Mapset, error) {
+	if len(userIDs) == 0 {
+		return uc.mapset.List(ctx, tx)
+	}
+
+	var mapsets []*model.Mapset
+	for _, userID := range userIDs {
+		userMapsets, err := uc.mapset.ListForUser(ctx, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		mapsets = append(mapsets, userMapsets...)
+	}
+
+	return mapsets, nil
+}
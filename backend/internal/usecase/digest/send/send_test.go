@@ -0,0 +1,108 @@
+package digestsend
+
+import (
+	"context"
+	"encoding/json"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/mailer"
+	"playcount-monitor-backend/internal/service/mailer/mailertest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDigestStore struct {
+	subscriptions []*model.DigestSubscription
+	sent          map[int]time.Time
+}
+
+func (s *fakeDigestStore) List(context.Context, txmanager.Tx) ([]*model.DigestSubscription, error) {
+	return s.subscriptions, nil
+}
+
+func (s *fakeDigestStore) SetLastSent(_ context.Context, _ txmanager.Tx, id int, sentAt time.Time) error {
+	s.sent[id] = sentAt
+	return nil
+}
+
+type fakeMapsetStore struct {
+	mapsets []*model.Mapset
+}
+
+func (s *fakeMapsetStore) List(context.Context, txmanager.Tx) ([]*model.Mapset, error) {
+	return s.mapsets, nil
+}
+
+func (s *fakeMapsetStore) ListForUser(_ context.Context, _ txmanager.Tx, userID int) ([]*model.Mapset, error) {
+	var res []*model.Mapset
+	for _, mapset := range s.mapsets {
+		if mapset.UserID == userID {
+			res = append(res, mapset)
+		}
+	}
+
+	return res, nil
+}
+
+type fakeMapsetEventStore struct{}
+
+func (fakeMapsetEventStore) ListForUsersSince(context.Context, txmanager.Tx, []int, time.Time) ([]*model.MapsetEvent, error) {
+	return nil, nil
+}
+
+func TestUseCase_Send(t *testing.T) {
+	srv, err := mailertest.NewServer()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cfg := &config.Config{
+		SMTPHost:            srv.Host(),
+		SMTPPort:            srv.Port(),
+		SMTPFrom:            "monitor@example.com",
+		DigestCheckInterval: time.Hour,
+		DigestTopMapsets:    10,
+	}
+
+	now := time.Now().UTC()
+	recent := now.Add(-time.Hour)
+	stale := now.Add(-25 * time.Hour)
+
+	userIDs, err := json.Marshal([]int{7})
+	require.NoError(t, err)
+
+	digests := &fakeDigestStore{
+		subscriptions: []*model.DigestSubscription{
+			{ID: 1, Email: "due@example.com", Frequency: string(model.DigestDaily), UserIDs: userIDs, LastSentAt: &stale},
+			{ID: 2, Email: "recent@example.com", Frequency: string(model.DigestDaily), UserIDs: userIDs, LastSentAt: &recent},
+			// other mapper has no activity, nothing is mailed but subscription is marked as sent
+			{ID: 3, Email: "quiet@example.com", Frequency: string(model.DigestWeekly), UserIDs: []byte(`[8]`)},
+		},
+		sent: make(map[int]time.Time),
+	}
+
+	mapset := testMapset(t, 1, map[time.Time]int{
+		now.Add(-30 * time.Hour): 100,
+		now.Add(-time.Minute):    250,
+	})
+	mapset.UserID = 7
+
+	uc := New(cfg, log.New(), txmanagertest.TxManager{}, digests, &fakeMapsetStore{mapsets: []*model.Mapset{mapset}}, fakeMapsetEventStore{}, mailer.New(cfg))
+
+	require.NoError(t, uc.Send(context.Background()))
+
+	mails := srv.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []string{"due@example.com"}, mails[0].To)
+	assert.Contains(t, mails[0].Data, "Subject: Your daily playcount digest")
+	assert.Contains(t, mails[0].Data, "+150 Artist - Title B by mapper")
+
+	assert.Contains(t, digests.sent, 1)
+	assert.NotContains(t, digests.sent, 2)
+	assert.Contains(t, digests.sent, 3)
+}
@@ -0,0 +1,79 @@
+package domainerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies use case failures, http layer maps every kind to its status code
+type Kind string
+
+const (
+	KindNotFound    Kind = "not_found"
+	KindConflict    Kind = "conflict"
+	KindValidation  Kind = "validation"
+	KindUnavailable Kind = "upstream_unavailable"
+	// KindUnauthenticated means credentials are missing or invalid, KindForbidden that they lack a role
+	KindUnauthenticated Kind = "unauthenticated"
+	KindForbidden       Kind = "forbidden"
+)
+
+// Error is a failure caused by the request or by an upstream rather than by a bug,
+// Detail is shown to clients while Err is the cause that only gets logged
+type Error struct {
+	Kind   Kind
+	Detail string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Detail
+	}
+
+	return e.Detail + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(format string, args ...any) *Error {
+	return &Error{Kind: KindNotFound, Detail: fmt.Sprintf(format, args...)}
+}
+
+func Conflict(err error, format string, args ...any) *Error {
+	return &Error{Kind: KindConflict, Detail: fmt.Sprintf(format, args...), Err: err}
+}
+
+func Validation(format string, args ...any) *Error {
+	return &Error{Kind: KindValidation, Detail: fmt.Sprintf(format, args...)}
+}
+
+func Unavailable(err error, format string, args ...any) *Error {
+	return &Error{Kind: KindUnavailable, Detail: fmt.Sprintf(format, args...), Err: err}
+}
+
+func Unauthenticated(format string, args ...any) *Error {
+	return &Error{Kind: KindUnauthenticated, Detail: fmt.Sprintf(format, args...)}
+}
+
+func Forbidden(format string, args ...any) *Error {
+	return &Error{Kind: KindForbidden, Detail: fmt.Sprintf(format, args...)}
+}
+
+// As returns the outermost domain error of err chain
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	return nil, false
+}
+
+// IsKind reports whether err chain holds domain error of kind
+func IsKind(err error, kind Kind) bool {
+	domainErr, ok := As(err)
+	return ok && domainErr.Kind == kind
+}
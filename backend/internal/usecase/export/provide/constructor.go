@@ -0,0 +1,40 @@
+package exportprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type statsStore interface {
+	StreamStats(
+		ctx context.Context,
+		tx txmanager.Tx,
+		entity model.StatEntity,
+		filter model.StatPointFilter,
+		fn func(point *model.StatPoint) error,
+	) error
+}
+
+type UseCase struct {
+	cfg   *config.Config
+	lg    *log.Logger
+	txm   txmanager.TxManager
+	stats statsStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	stats statsStore,
+) *UseCase {
+	return &UseCase{
+		cfg:   cfg,
+		lg:    lg,
+		txm:   txm,
+		stats: stats,
+	}
+}
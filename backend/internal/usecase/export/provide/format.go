@@ -0,0 +1,209 @@
+package exportprovide
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/parquet-go/parquet-go"
+	"io"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"strconv"
+	"time"
+)
+
+// Format is encoding of exported rows
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return true
+	}
+
+	return false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// Extension is file extension of format without leading dot
+func (f Format) Extension() string {
+	if f == FormatNDJSON {
+		return "jsonl"
+	}
+
+	return string(f)
+}
+
+const (
+	// parquetBatchSize is how many rows are handed to parquet writer at once
+	parquetBatchSize = 1024
+	// parquetRowGroupSize caps rows parquet writer buffers before flushing a row group to output
+	parquetRowGroupSize = 128 * 1024
+)
+
+// Row is exported row, field names are column names of every format
+type Row struct {
+	Entity    string    `json:"entity" parquet:"entity,dict"`
+	ID        int64     `json:"id" parquet:"id"`
+	Timestamp time.Time `json:"timestamp" parquet:"timestamp,timestamp(millisecond)"`
+	Metric    string    `json:"metric" parquet:"metric,dict"`
+	Value     int64     `json:"value" parquet:"value"`
+}
+
+var csvHeader = []string{"entity", "id", "timestamp", "metric", "value"}
+
+func newRow(point *model.StatPoint) Row {
+	return Row{
+		Entity:    string(point.Entity),
+		ID:        int64(point.ID),
+		Timestamp: point.Timestamp.UTC(),
+		Metric:    point.Metric,
+		Value:     point.Value,
+	}
+}
+
+// encoder writes rows as they come, Close flushes what is buffered and finishes output
+type encoder interface {
+	Encode(point *model.StatPoint) error
+	Close() error
+}
+
+func newEncoder(format Format, w io.Writer) (encoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w)
+	case FormatNDJSON:
+		return newNDJSONEncoder(w), nil
+	case FormatParquet:
+		return newParquetEncoder(w), nil
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (*csvEncoder, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	return &csvEncoder{w: cw}, nil
+}
+
+func (e *csvEncoder) Encode(point *model.StatPoint) error {
+	err := e.w.Write([]string{
+		string(point.Entity),
+		strconv.Itoa(point.ID),
+		point.Timestamp.UTC().Format(time.RFC3339),
+		point.Metric,
+		strconv.FormatInt(point.Value, 10),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write csv row: %w", err)
+	}
+
+	return nil
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+
+	return nil
+}
+
+type ndjsonEncoder struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	buf := bufio.NewWriter(w)
+
+	return &ndjsonEncoder{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (e *ndjsonEncoder) Encode(point *model.StatPoint) error {
+	if err := e.enc.Encode(newRow(point)); err != nil {
+		return fmt.Errorf("failed to write ndjson row: %w", err)
+	}
+
+	return nil
+}
+
+func (e *ndjsonEncoder) Close() error {
+	if err := e.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush ndjson: %w", err)
+	}
+
+	return nil
+}
+
+// parquetEncoder streams row groups to output as they fill up, footer is written on Close
+type parquetEncoder struct {
+	w     *parquet.GenericWriter[Row]
+	batch []Row
+}
+
+func newParquetEncoder(w io.Writer) *parquetEncoder {
+	return &parquetEncoder{
+		w:     parquet.NewGenericWriter[Row](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		batch: make([]Row, 0, parquetBatchSize),
+	}
+}
+
+func (e *parquetEncoder) Encode(point *model.StatPoint) error {
+	e.batch = append(e.batch, newRow(point))
+	if len(e.batch) < parquetBatchSize {
+		return nil
+	}
+
+	return e.flushBatch()
+}
+
+func (e *parquetEncoder) flushBatch() error {
+	if len(e.batch) == 0 {
+		return nil
+	}
+
+	if _, err := e.w.Write(e.batch); err != nil {
+		return fmt.Errorf("failed to write parquet rows: %w", err)
+	}
+	e.batch = e.batch[:0]
+
+	return nil
+}
+
+func (e *parquetEncoder) Close() error {
+	if err := e.flushBatch(); err != nil {
+		return err
+	}
+
+	if err := e.w.Close(); err != nil {
+		return fmt.Errorf("failed to close parquet: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package exportprovide
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testPoints = []*model.StatPoint{
+	{Entity: model.StatEntityUser, ID: 1, Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), Metric: "play_count", Value: 100},
+	{Entity: model.StatEntityBeatmap, ID: 2, Timestamp: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC), Metric: "pass_count", Value: 7},
+}
+
+func encodeAll(t *testing.T, format Format) []byte {
+	var buf bytes.Buffer
+	enc, err := newEncoder(format, &buf)
+	require.NoError(t, err)
+
+	for _, p := range testPoints {
+		require.NoError(t, enc.Encode(p))
+	}
+	require.NoError(t, enc.Close())
+
+	return buf.Bytes()
+}
+
+func TestCSVEncoder(t *testing.T) {
+	out := encodeAll(t, FormatCSV)
+
+	assert.Equal(t, strings.Join([]string{
+		"entity,id,timestamp,metric,value",
+		"user,1,2024-01-01T12:00:00Z,play_count,100",
+		"beatmap,2,2024-01-02T12:00:00Z,pass_count,7",
+		"",
+	}, "\n"), string(out))
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	out := encodeAll(t, FormatNDJSON)
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var row Row
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &row))
+	assert.Equal(t, newRow(testPoints[1]), row)
+	assert.JSONEq(t, `{"entity":"user","id":1,"timestamp":"2024-01-01T12:00:00Z","metric":"play_count","value":100}`, lines[0])
+}
+
+func TestParquetEncoder(t *testing.T) {
+	out := encodeAll(t, FormatParquet)
+
+	rows, err := parquet.Read[Row](bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for i, p := range testPoints {
+		assert.Equal(t, newRow(p).Entity, rows[i].Entity)
+		assert.Equal(t, newRow(p).Metric, rows[i].Metric)
+		assert.Equal(t, newRow(p).Value, rows[i].Value)
+		assert.True(t, p.Timestamp.Equal(rows[i].Timestamp))
+	}
+}
+
+func TestParquetEncoder_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := newEncoder(FormatParquet, &buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+
+	rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
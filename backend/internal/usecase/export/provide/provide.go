@@ -0,0 +1,77 @@
+package exportprovide
+
+import (
+	"context"
+	"io"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"time"
+)
+
+// Command picks what is exported, empty Entities exports all of them, zero From or To leaves that side open
+type Command struct {
+	Format   Format
+	Entities []model.StatEntity
+	From     time.Time
+	To       time.Time
+}
+
+// Export writes stats history of requested entities to w in long format, one row per metric of every entry.
+// Rows are encoded while they are read so memory use doesn't grow with history size.
+// Nothing is written to w when command is invalid
+func (uc *UseCase) Export(ctx context.Context, cmd *Command, w io.Writer) error {
+	if err := validate(cmd); err != nil {
+		return err
+	}
+
+	entities := cmd.Entities
+	if len(entities) == 0 {
+		entities = model.StatEntities
+	}
+
+	filter := model.StatPointFilter{From: cmd.From, To: cmd.To}
+
+	enc, err := newEncoder(cmd.Format, w)
+	if err != nil {
+		return err
+	}
+
+	err = uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		for _, entity := range entities {
+			if err := uc.stats.StreamStats(ctx, tx, entity, filter, enc.Encode); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func validate(cmd *Command) error {
+	if !cmd.Format.IsValid() {
+		return domainerror.Validation("invalid format %q, expected csv, ndjson or parquet", cmd.Format)
+	}
+
+	seen := make(map[model.StatEntity]bool, len(cmd.Entities))
+	for _, entity := range cmd.Entities {
+		if !entity.IsValid() {
+			return domainerror.Validation("invalid entity %q, expected user, mapset or beatmap", entity)
+		}
+		if seen[entity] {
+			return domainerror.Validation("entity %q is requested more than once", entity)
+		}
+		seen[entity] = true
+	}
+
+	if !cmd.From.IsZero() && !cmd.To.IsZero() && cmd.From.After(cmd.To) {
+		return domainerror.Validation("from is after to")
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package exportprovide
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"testing"
+	"time"
+)
+
+type fakeStatsStore struct {
+	streamed []model.StatEntity
+	filter   model.StatPointFilter
+}
+
+func (s *fakeStatsStore) StreamStats(
+	_ context.Context,
+	_ txmanager.Tx,
+	entity model.StatEntity,
+	filter model.StatPointFilter,
+	fn func(point *model.StatPoint) error,
+) error {
+	s.streamed = append(s.streamed, entity)
+	s.filter = filter
+
+	return fn(&model.StatPoint{Entity: entity, ID: 1, Metric: "play_count", Value: 1})
+}
+
+func TestUseCase_Export(t *testing.T) {
+	stats := &fakeStatsStore{}
+	uc := New(nil, nil, txmanagertest.TxManager{}, stats)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := uc.Export(context.Background(), &Command{Format: FormatCSV, From: from}, &buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.StatEntities, stats.streamed)
+	assert.Equal(t, model.StatPointFilter{From: from}, stats.filter)
+	assert.Contains(t, buf.String(), "mapset,1,")
+}
+
+func TestUseCase_Export_Invalid(t *testing.T) {
+	uc := New(nil, nil, txmanagertest.TxManager{}, &fakeStatsStore{})
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		cmd  *Command
+	}{
+		{name: "format", cmd: &Command{Format: "xlsx"}},
+		{name: "entity", cmd: &Command{Format: FormatCSV, Entities: []model.StatEntity{"clean"}}},
+		{name: "duplicate entity", cmd: &Command{Format: FormatCSV, Entities: []model.StatEntity{"user", "user"}}},
+		{name: "range", cmd: &Command{Format: FormatCSV, From: now, To: now.Add(-time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := uc.Export(context.Background(), tt.cmd, &buf)
+
+			var domainErr *domainerror.Error
+			require.True(t, errors.As(err, &domainErr))
+			assert.Equal(t, domainerror.KindValidation, domainErr.Kind)
+			assert.Zero(t, buf.Len())
+		})
+	}
+}
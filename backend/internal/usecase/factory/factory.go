@@ -3,16 +3,45 @@ package factory
 import (
 	log "github.com/sirupsen/logrus"
 	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/anomalyrepository"
+	"playcount-monitor-backend/internal/database/repository/apikeyrepository"
+	"playcount-monitor-backend/internal/database/repository/auditrepository"
 	"playcount-monitor-backend/internal/database/repository/beatmaprepository"
+	"playcount-monitor-backend/internal/database/repository/digestrepository"
+	"playcount-monitor-backend/internal/database/repository/exportrepository"
 	"playcount-monitor-backend/internal/database/repository/followingrepository"
+	"playcount-monitor-backend/internal/database/repository/mapseteventrepository"
+	"playcount-monitor-backend/internal/database/repository/mapsetlistingrepository"
 	"playcount-monitor-backend/internal/database/repository/mapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/milestonerepository"
+	"playcount-monitor-backend/internal/database/repository/sessionrepository"
+	"playcount-monitor-backend/internal/database/repository/trackrepository"
 	"playcount-monitor-backend/internal/database/repository/userrepository"
+	"playcount-monitor-backend/internal/database/repository/watchedmapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/watchlistrepository"
+	"playcount-monitor-backend/internal/database/repository/webhookrepository"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/service/tokencipher"
+	accountmanage "playcount-monitor-backend/internal/usecase/account/manage"
+	analyticsprovide "playcount-monitor-backend/internal/usecase/analytics/provide"
+	anomalyprovide "playcount-monitor-backend/internal/usecase/anomaly/provide"
+	apikeymanage "playcount-monitor-backend/internal/usecase/apikey/manage"
+	apikeyprovide "playcount-monitor-backend/internal/usecase/apikey/provide"
+	auditcreate "playcount-monitor-backend/internal/usecase/audit/create"
+	auditprovide "playcount-monitor-backend/internal/usecase/audit/provide"
+	"playcount-monitor-backend/internal/usecase/cache"
+	digestmanage "playcount-monitor-backend/internal/usecase/digest/manage"
+	digestprovide "playcount-monitor-backend/internal/usecase/digest/provide"
+	exportprovide "playcount-monitor-backend/internal/usecase/export/provide"
+	feedprovide "playcount-monitor-backend/internal/usecase/feed/provide"
 	trackingcreate "playcount-monitor-backend/internal/usecase/following/create"
 	trackingprovide "playcount-monitor-backend/internal/usecase/following/provide"
 	mapsetcreate "playcount-monitor-backend/internal/usecase/mapset/create"
 	mapsetprovide "playcount-monitor-backend/internal/usecase/mapset/provide"
+	milestoneprovide "playcount-monitor-backend/internal/usecase/milestone/provide"
+	sessionmanage "playcount-monitor-backend/internal/usecase/session/manage"
 	statisticprovide "playcount-monitor-backend/internal/usecase/statistic/provide"
 	usercreate "playcount-monitor-backend/internal/usecase/user/create"
 	userprovide "playcount-monitor-backend/internal/usecase/user/provide"
@@ -20,6 +49,12 @@ import (
 	usercardcreate "playcount-monitor-backend/internal/usecase/usercard/create"
 	usercardprovide "playcount-monitor-backend/internal/usecase/usercard/provide"
 	usercardupdate "playcount-monitor-backend/internal/usecase/usercard/update"
+	watchedmapsetmanage "playcount-monitor-backend/internal/usecase/watchedmapset/manage"
+	watchedmapsetprovide "playcount-monitor-backend/internal/usecase/watchedmapset/provide"
+	watchlistmanage "playcount-monitor-backend/internal/usecase/watchlist/manage"
+	watchlistprovide "playcount-monitor-backend/internal/usecase/watchlist/provide"
+	webhookmanage "playcount-monitor-backend/internal/usecase/webhook/manage"
+	webhookprovide "playcount-monitor-backend/internal/usecase/webhook/provide"
 )
 
 type UseCaseFactory struct {
@@ -27,14 +62,32 @@ type UseCaseFactory struct {
 	cfg       *config.Config
 	txManager txmanager.TxManager
 	osuApi    osuapi.Interface
-	repos     *Repositories
+	osuOAuth  osuoauth.Interface
+	// tokenCipher is nil when TOKEN_ENCRYPTION_KEY isn't set
+	tokenCipher tokencipher.Interface
+	// cache is shared by read use cases, nil when CACHE_SIZE is zero
+	cache cache.Interface
+	repos *Repositories
 }
 
 type Repositories struct {
-	UserRepo      userrepository.Interface
-	BeatmapRepo   beatmaprepository.Interface
-	MapsetRepo    mapsetrepository.Interface
-	FollowingRepo followingrepository.Interface
+	UserRepo          userrepository.Interface
+	BeatmapRepo       beatmaprepository.Interface
+	MapsetRepo        mapsetrepository.Interface
+	MapsetListingRepo mapsetlistingrepository.Interface
+	FollowingRepo     followingrepository.Interface
+	AnomalyRepo       anomalyrepository.Interface
+	MilestoneRepo     milestonerepository.Interface
+	WebhookRepo       webhookrepository.Interface
+	MapsetEventRepo   mapseteventrepository.Interface
+	DigestRepo        digestrepository.Interface
+	APIKeyRepo        apikeyrepository.Interface
+	AuditRepo         auditrepository.Interface
+	SessionRepo       sessionrepository.Interface
+	WatchlistRepo     watchlistrepository.Interface
+	WatchedMapsetRepo watchedmapsetrepository.Interface
+	TrackRepo         trackrepository.Interface
+	ExportRepo        exportrepository.Interface
 }
 
 func New(
@@ -42,14 +95,20 @@ func New(
 	lg *log.Logger,
 	txManager txmanager.TxManager,
 	osuApi osuapi.Interface,
+	osuOAuth osuoauth.Interface,
+	tokenCipher tokencipher.Interface,
+	cache cache.Interface,
 	repos *Repositories,
 ) (*UseCaseFactory, error) {
 	return &UseCaseFactory{
-		cfg:       cfg,
-		lg:        lg,
-		txManager: txManager,
-		repos:     repos,
-		osuApi:    osuApi,
+		cfg:         cfg,
+		lg:          lg,
+		txManager:   txManager,
+		repos:       repos,
+		osuApi:      osuApi,
+		osuOAuth:    osuOAuth,
+		tokenCipher: tokenCipher,
+		cache:       cache,
 	}, nil
 }
 
@@ -70,6 +129,9 @@ func (f *UseCaseFactory) MakeProvideMapsetUseCase() *mapsetprovide.UseCase {
 		f.txManager,
 		f.repos.BeatmapRepo,
 		f.repos.MapsetRepo,
+		f.repos.MapsetListingRepo,
+		f.repos.WatchlistRepo,
+		f.cache,
 	)
 }
 
@@ -88,6 +150,7 @@ func (f *UseCaseFactory) MakeProvideUserUseCase() *userprovide.UseCase {
 		f.lg,
 		f.txManager,
 		f.repos.UserRepo,
+		f.repos.WatchlistRepo,
 		f.osuApi,
 	)
 }
@@ -119,7 +182,10 @@ func (f *UseCaseFactory) MakeProvideUserCardUseCase() *usercardprovide.UseCase {
 		f.txManager,
 		f.repos.UserRepo,
 		f.repos.MapsetRepo,
-		f.repos.BeatmapRepo,
+		f.repos.MapsetListingRepo,
+		f.repos.AnomalyRepo,
+		f.repos.MilestoneRepo,
+		f.cache,
 	)
 }
 
@@ -159,5 +225,197 @@ func (f *UseCaseFactory) MakeProvideStatisticUseCase() *statisticprovide.UseCase
 		f.txManager,
 		f.repos.BeatmapRepo,
 		f.repos.MapsetRepo,
+		f.cache,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideExportUseCase() *exportprovide.UseCase {
+	return exportprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.ExportRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideAnalyticsUseCase() *analyticsprovide.UseCase {
+	return analyticsprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.UserRepo,
+		f.repos.MapsetRepo,
+		f.repos.BeatmapRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideAnomalyUseCase() *anomalyprovide.UseCase {
+	return anomalyprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.AnomalyRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideMilestoneUseCase() *milestoneprovide.UseCase {
+	return milestoneprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.MilestoneRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageWebhookUseCase() *webhookmanage.UseCase {
+	return webhookmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WebhookRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideWebhookUseCase() *webhookprovide.UseCase {
+	return webhookprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WebhookRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageDigestUseCase() *digestmanage.UseCase {
+	return digestmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.DigestRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideDigestUseCase() *digestprovide.UseCase {
+	return digestprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.DigestRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideFeedUseCase() *feedprovide.UseCase {
+	return feedprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.UserRepo,
+		f.repos.MapsetRepo,
+		f.repos.MapsetEventRepo,
+		f.repos.MilestoneRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageAPIKeyUseCase() *apikeymanage.UseCase {
+	return apikeymanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.APIKeyRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideAPIKeyUseCase() *apikeyprovide.UseCase {
+	return apikeyprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.APIKeyRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeCreateAuditUseCase() *auditcreate.UseCase {
+	return auditcreate.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.AuditRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideAuditUseCase() *auditprovide.UseCase {
+	return auditprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.AuditRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageSessionUseCase() *sessionmanage.UseCase {
+	return sessionmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.SessionRepo,
+		f.osuOAuth,
+		f.tokenCipher,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageAccountUseCase() *accountmanage.UseCase {
+	return accountmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.FollowingRepo,
+		f.MakeManageSessionUseCase(),
+		f.osuOAuth,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageWatchlistUseCase() *watchlistmanage.UseCase {
+	return watchlistmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WatchlistRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideWatchlistUseCase() *watchlistprovide.UseCase {
+	return watchlistprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WatchlistRepo,
+	)
+}
+
+func (f *UseCaseFactory) MakeManageWatchedMapsetUseCase() *watchedmapsetmanage.UseCase {
+	return watchedmapsetmanage.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WatchedMapsetRepo,
+		f.osuApi,
+	)
+}
+
+func (f *UseCaseFactory) MakeProvideWatchedMapsetUseCase() *watchedmapsetprovide.UseCase {
+	return watchedmapsetprovide.New(
+		f.cfg,
+		f.lg,
+		f.txManager,
+		f.repos.WatchedMapsetRepo,
+	)
+}
+
+// MakeCacheInvalidator purges cache of read use cases after tracking runs and edits made through api
+func (f *UseCaseFactory) MakeCacheInvalidator() *cache.Invalidator {
+	return cache.NewInvalidator(
+		f.lg,
+		f.txManager,
+		f.repos.TrackRepo,
+		f.cache,
 	)
 }
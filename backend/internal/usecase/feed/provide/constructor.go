@@ -0,0 +1,67 @@
+package feedprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type userStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.User, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.User, error)
+}
+
+type mapsetStore interface {
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error)
+}
+
+type mapsetEventStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MapsetEventFilter,
+		limit int,
+		offset int,
+	) ([]*model.MapsetEvent, int, error)
+}
+
+type milestoneStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MilestoneFilter,
+		page model.PageRequest,
+	) ([]*model.Milestone, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg         *config.Config
+	lg          *log.Logger
+	txm         txmanager.TxManager
+	user        userStore
+	mapset      mapsetStore
+	mapsetEvent mapsetEventStore
+	milestone   milestoneStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	user userStore,
+	mapset mapsetStore,
+	mapsetEvent mapsetEventStore,
+	milestone milestoneStore,
+) *UseCase {
+	return &UseCase{
+		cfg:         cfg,
+		lg:          lg,
+		txm:         txm,
+		user:        user,
+		mapset:      mapset,
+		mapsetEvent: mapsetEvent,
+		milestone:   milestone,
+	}
+}
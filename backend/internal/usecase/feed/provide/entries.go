@@ -0,0 +1,87 @@
+package feedprovide
+
+import (
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"strconv"
+)
+
+const (
+	feedURNPrefix   = "urn:playcount-monitor:"
+	mapsetURLFormat = "https://osu.ppy.sh/beatmapsets/%v"
+	userURLFormat   = "https://osu.ppy.sh/users/%v"
+)
+
+var metricNames = map[string]string{
+	string(model.MilestonePlaycount):  "plays",
+	string(model.MilestoneFavourites): "favourites",
+	string(model.MilestoneMapCount):   "maps",
+}
+
+func mapsetEventEntry(event *model.MapsetEvent, mapset *model.Mapset) *dto.FeedEntry {
+	entry := &dto.FeedEntry{
+		ID:       fmt.Sprintf("%smapset-event:%v", feedURNPrefix, event.ID),
+		URL:      fmt.Sprintf(mapsetURLFormat, mapset.ID),
+		Author:   mapset.Creator,
+		ImageURL: coverURL(mapset),
+		Updated:  event.OccurredAt,
+	}
+
+	switch event.Kind {
+	case string(model.MapsetEventStatusChange):
+		entry.Title = fmt.Sprintf("%s - %s got %s", mapset.Artist, mapset.Title, event.Status)
+		entry.Summary = fmt.Sprintf("Status of %s's map changed from %s to %s",
+			mapset.Creator, event.PreviousStatus, event.Status)
+	default:
+		entry.Title = fmt.Sprintf("%s uploaded %s - %s", mapset.Creator, mapset.Artist, mapset.Title)
+		entry.Summary = fmt.Sprintf("New %s map by %s", event.Status, mapset.Creator)
+	}
+
+	return entry
+}
+
+// milestoneEntry builds entry of milestone, mapset is nil for milestones of user
+func milestoneEntry(milestone *model.Milestone, mapset *model.Mapset, user *model.User) *dto.FeedEntry {
+	username := strconv.Itoa(milestone.UserID)
+	if user != nil {
+		username = user.Username
+	}
+
+	entry := &dto.FeedEntry{
+		ID:      fmt.Sprintf("%smilestone:%v", feedURNPrefix, milestone.ID),
+		URL:     fmt.Sprintf(userURLFormat, milestone.UserID),
+		Author:  username,
+		Updated: milestone.ReachedAt,
+	}
+
+	subject := username
+	if mapset != nil {
+		subject = mapset.Artist + " - " + mapset.Title
+		entry.URL = fmt.Sprintf(mapsetURLFormat, mapset.ID)
+		entry.Author = mapset.Creator
+		entry.ImageURL = coverURL(mapset)
+	}
+
+	metric := metricNames[milestone.Kind]
+	if milestone.Kind == string(model.MilestoneFirstRanked) {
+		entry.Title = username + " got their first ranked map"
+		entry.Summary = fmt.Sprintf("%s now has %v ranked maps", username, milestone.Value)
+		return entry
+	}
+
+	entry.Title = fmt.Sprintf("%s passed %v %s", subject, milestone.Threshold, metric)
+	entry.Summary = fmt.Sprintf("%s reached %v %s", subject, milestone.Value, metric)
+
+	return entry
+}
+
+func coverURL(mapset *model.Mapset) string {
+	covers, err := mappers.MapCoversJSONToMapsetCovers(mapset.Covers)
+	if err != nil {
+		return ""
+	}
+
+	return covers["card"]
+}
@@ -0,0 +1,165 @@
+package feedprovide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"sort"
+)
+
+const feedMaxEntries = 50
+
+// GetGlobal builds feed of latest new mapsets, status changes and milestones of every tracked user
+func (uc *UseCase) GetGlobal(ctx context.Context) (*dto.Feed, error) {
+	var feed *dto.Feed
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		feed, err = uc.build(ctx, tx, model.MapsetEventFilter{}, model.MilestoneFilter{})
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	feed.ID = feedURNPrefix + "feed:global"
+	feed.Title = "Mapping activity"
+
+	return feed, nil
+}
+
+// GetForUser builds feed of latest new mapsets, status changes and milestones of user
+func (uc *UseCase) GetForUser(ctx context.Context, userID int) (*dto.Feed, error) {
+	var feed *dto.Feed
+	var user *model.User
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		user, err = uc.user.Get(ctx, tx, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("user %v not found", userID)
+		}
+		if err != nil {
+			return err
+		}
+
+		feed, err = uc.build(
+			ctx,
+			tx,
+			model.MapsetEventFilter{model.MapsetEventUserIDField: userID},
+			model.MilestoneFilter{model.MilestoneUserIDField: userID},
+		)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	feed.ID = fmt.Sprintf("%sfeed:user:%v", feedURNPrefix, userID)
+	feed.Title = "Mapping activity of " + user.Username
+
+	return feed, nil
+}
+
+func (uc *UseCase) build(
+	ctx context.Context,
+	tx txmanager.Tx,
+	eventFilter model.MapsetEventFilter,
+	milestoneFilter model.MilestoneFilter,
+) (*dto.Feed, error) {
+	events, _, err := uc.mapsetEvent.List(ctx, tx, eventFilter, feedMaxEntries, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones, _, err := uc.milestone.List(ctx, tx, milestoneFilter, model.PageRequest{Limit: feedMaxEntries})
+	if err != nil {
+		return nil, err
+	}
+
+	var mapsetIDs, userIDs []int
+	for _, event := range events {
+		mapsetIDs = append(mapsetIDs, event.MapsetID)
+		userIDs = append(userIDs, event.UserID)
+	}
+	for _, milestone := range milestones {
+		if milestone.EntityType == string(model.MilestoneMapset) {
+			mapsetIDs = append(mapsetIDs, milestone.EntityID)
+		}
+		userIDs = append(userIDs, milestone.UserID)
+	}
+
+	mapsetsByID := make(map[int]*model.Mapset)
+	if len(mapsetIDs) > 0 {
+		mapsets, err := uc.mapset.ListByIDs(ctx, tx, mapsetIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapset := range mapsets {
+			mapsetsByID[mapset.ID] = mapset
+		}
+	}
+
+	usersByID := make(map[int]*model.User)
+	if len(userIDs) > 0 {
+		users, err := uc.user.ListByIDs(ctx, tx, userIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			usersByID[user.ID] = user
+		}
+	}
+
+	feed := &dto.Feed{Entries: buildEntries(events, milestones, mapsetsByID, usersByID)}
+	if len(feed.Entries) > 0 {
+		feed.Updated = feed.Entries[0].Updated
+	}
+
+	return feed, nil
+}
+
+// buildEntries merges mapset events and milestones newest first, entries of mapsets no longer stored are skipped
+func buildEntries(
+	events []*model.MapsetEvent,
+	milestones []*model.Milestone,
+	mapsetsByID map[int]*model.Mapset,
+	usersByID map[int]*model.User,
+) []*dto.FeedEntry {
+	entries := make([]*dto.FeedEntry, 0, len(events)+len(milestones))
+	for _, event := range events {
+		mapset, ok := mapsetsByID[event.MapsetID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, mapsetEventEntry(event, mapset))
+	}
+
+	for _, milestone := range milestones {
+		var mapset *model.Mapset
+		if milestone.EntityType == string(model.MilestoneMapset) {
+			var ok bool
+			if mapset, ok = mapsetsByID[milestone.EntityID]; !ok {
+				continue
+			}
+		}
+		entries = append(entries, milestoneEntry(milestone, mapset, usersByID[milestone.UserID]))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Updated.After(entries[j].Updated)
+	})
+
+	if len(entries) > feedMaxEntries {
+		entries = entries[:feedMaxEntries]
+	}
+
+	return entries
+}
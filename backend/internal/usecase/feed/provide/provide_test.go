@@ -0,0 +1,49 @@
+package feedprovide
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+func Test_buildEntries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	mapsetsByID := map[int]*model.Mapset{
+		1: {ID: 1, Artist: "artist", Title: "title", Creator: "mapper",
+			Covers: repository.JSON(`{"card":"https://assets.ppy.sh/card.jpg"}`)},
+	}
+	usersByID := map[int]*model.User{7: {ID: 7, Username: "mapper"}}
+
+	events := []*model.MapsetEvent{
+		{ID: 1, MapsetID: 1, UserID: 7, Kind: "status_change", PreviousStatus: "qualified", Status: "ranked", OccurredAt: day(3)},
+		{ID: 2, MapsetID: 2, UserID: 7, Kind: "new_mapset", Status: "wip", OccurredAt: day(5)},
+		{ID: 3, MapsetID: 1, UserID: 7, Kind: "new_mapset", Status: "pending", OccurredAt: day(1)},
+	}
+	milestones := []*model.Milestone{
+		{ID: 4, EntityType: "user", EntityID: 7, UserID: 7, Kind: "first_ranked", Threshold: 1, Value: 1, ReachedAt: day(3)},
+		{ID: 5, EntityType: "mapset", EntityID: 1, UserID: 7, Kind: "play_count", Threshold: 100000, Value: 100200, ReachedAt: day(4)},
+	}
+
+	entries := buildEntries(events, milestones, mapsetsByID, usersByID)
+
+	// entry of mapset no longer stored is skipped
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "urn:playcount-monitor:milestone:5", entries[0].ID)
+	assert.Equal(t, "artist - title passed 100000 plays", entries[0].Title)
+	assert.Equal(t, "https://osu.ppy.sh/beatmapsets/1", entries[0].URL)
+	assert.Equal(t, "https://assets.ppy.sh/card.jpg", entries[0].ImageURL)
+
+	assert.Equal(t, "artist - title got ranked", entries[1].Title)
+	assert.Equal(t, "Status of mapper's map changed from qualified to ranked", entries[1].Summary)
+
+	assert.Equal(t, "mapper got their first ranked map", entries[2].Title)
+	assert.Equal(t, "https://osu.ppy.sh/users/7", entries[2].URL)
+
+	assert.Equal(t, "mapper uploaded artist - title", entries[3].Title)
+	assert.Equal(t, day(1), entries[3].Updated)
+}
@@ -2,8 +2,10 @@ package followingcreate
 
 import (
 	"context"
+	"playcount-monitor-backend/internal/database/repository"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"time"
 )
 
@@ -20,6 +22,9 @@ func (uc *UseCase) Create(
 		}
 
 		err := uc.following.Create(ctx, tx, follow)
+		if repository.IsUniqueViolation(err) {
+			return domainerror.Conflict(err, "user %v is already followed", id)
+		}
 		if err != nil {
 			return err
 		}
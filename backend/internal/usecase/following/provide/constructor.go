@@ -10,7 +10,7 @@ import (
 )
 
 type followingStore interface {
-	List(ctx context.Context, tx txmanager.Tx) ([]*model.Following, error)
+	ListPage(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.Following, *model.Page, error)
 }
 
 type UseCase struct {
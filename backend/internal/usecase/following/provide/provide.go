@@ -5,26 +5,44 @@ import (
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
 )
 
+type ListCommand struct {
+	pagination.Query
+}
+
+type ListResponse struct {
+	Following []*dto.Following
+	Page      *dto.Page
+}
+
 func (uc *UseCase) List(
 	ctx context.Context,
-) ([]*dto.Following, error) {
+	cmd *ListCommand,
+) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
 	var trackList []*model.Following
+	var page *model.Page
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		var err error
-		trackList, err = uc.following.List(ctx, tx)
-		if err != nil {
-			return err
-		}
+		trackList, page, err = uc.following.ListPage(ctx, tx, pageReq)
 
-		return nil
+		return pagination.Error(err)
 	})
 	if txErr != nil {
 		return nil, txErr
 	}
 
-	return mapTrackingModelToTrackingDTO(trackList), nil
+	return &ListResponse{
+		Following: mapTrackingModelToTrackingDTO(trackList),
+		Page:      mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
 }
 
 func mapTrackingModelToTrackingDTO(followingList []*model.Following) []*dto.Following {
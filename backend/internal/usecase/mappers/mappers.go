@@ -7,6 +7,8 @@ import (
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/dto"
 	"playcount-monitor-backend/internal/usecase/command"
+	"playcount-monitor-backend/internal/usecase/pagination"
+	"playcount-monitor-backend/internal/usecase/timeseries"
 	"reflect"
 	"sort"
 	"time"
@@ -110,23 +112,28 @@ func MapCreateMapsetCommandToMapsetModel(mapset *command.CreateMapsetCommand) (*
 	}
 
 	return &model.Mapset{
-		ID:            mapset.Id,
-		Artist:        mapset.Artist,
-		Title:         mapset.Title,
-		Covers:        covers,
-		Status:        mapset.Status,
-		LastUpdated:   mapset.LastUpdated,
-		UserID:        mapset.UserId,
-		Creator:       mapset.Creator,
-		Language:      mapset.Language,
-		Genre:         mapset.Genre,
-		PreviewURL:    mapset.PreviewUrl,
-		Tags:          mapset.Tags,
-		BPM:           mapset.Bpm,
-		MapsetStats:   mapsetStats,
-		LastPlaycount: mapset.PlayCount,
-		CreatedAt:     time.Now().UTC(),
-		UpdatedAt:     time.Now().UTC(),
+		ID:                mapset.Id,
+		Artist:            mapset.Artist,
+		Title:             mapset.Title,
+		Covers:            covers,
+		Status:            mapset.Status,
+		LastUpdated:       mapset.LastUpdated,
+		UserID:            mapset.UserId,
+		Creator:           mapset.Creator,
+		Language:          mapset.Language,
+		Genre:             mapset.Genre,
+		PreviewURL:        mapset.PreviewUrl,
+		Tags:              mapset.Tags,
+		BPM:               mapset.Bpm,
+		MapsetStats:       mapsetStats,
+		LastPlaycount:     mapset.PlayCount,
+		LastFavorites:     mapset.FavouriteCount,
+		LastComments:      mapset.CommentsCount,
+		LastPasscount:     sumCreateBeatmapCommandsPasscount(mapset.Beatmaps),
+		SubmittedDate:     mapset.SubmittedDate,
+		CommentsFetchedAt: mapset.CommentsFetchedAt,
+		CreatedAt:         time.Now().UTC(),
+		UpdatedAt:         time.Now().UTC(),
 	}, nil
 }
 
@@ -142,25 +149,48 @@ func MapUpdateMapsetCommandToMapsetModel(mapset *command.UpdateMapsetCommand) (*
 	}
 
 	return &model.Mapset{
-		ID:            mapset.Id,
-		Artist:        mapset.Artist,
-		Title:         mapset.Title,
-		Covers:        covers,
-		Status:        mapset.Status,
-		LastUpdated:   mapset.LastUpdated,
-		UserID:        mapset.UserId,
-		Creator:       mapset.Creator,
-		PreviewURL:    mapset.PreviewUrl,
-		Tags:          mapset.Tags,
-		MapsetStats:   mapsetStats,
-		BPM:           mapset.Bpm,
-		LastPlaycount: mapset.PlayCount,
-		Language:      mapset.Language,
-		Genre:         mapset.Genre,
-		UpdatedAt:     time.Now().UTC(),
+		ID:                mapset.Id,
+		Artist:            mapset.Artist,
+		Title:             mapset.Title,
+		Covers:            covers,
+		Status:            mapset.Status,
+		LastUpdated:       mapset.LastUpdated,
+		UserID:            mapset.UserId,
+		Creator:           mapset.Creator,
+		PreviewURL:        mapset.PreviewUrl,
+		Tags:              mapset.Tags,
+		MapsetStats:       mapsetStats,
+		BPM:               mapset.Bpm,
+		LastPlaycount:     mapset.PlayCount,
+		LastFavorites:     mapset.FavouriteCount,
+		LastComments:      mapset.CommentsCount,
+		LastPasscount:     sumUpdateBeatmapCommandsPasscount(mapset.Beatmaps),
+		SubmittedDate:     mapset.SubmittedDate,
+		Language:          mapset.Language,
+		Genre:             mapset.Genre,
+		CommentsFetchedAt: mapset.CommentsFetchedAt,
+		UpdatedAt:         time.Now().UTC(),
 	}, nil
 }
 
+func sumCreateBeatmapCommandsPasscount(beatmaps []*command.CreateBeatmapCommand) int {
+	var res int
+	for _, b := range beatmaps {
+		res += b.Passcount
+	}
+
+	return res
+}
+
+func sumUpdateBeatmapCommandsPasscount(beatmaps []*command.UpdateBeatmapCommand) int {
+	var res int
+	for _, b := range beatmaps {
+		res += b.Passcount
+	}
+
+	return res
+}
+
 func MapCreateBeatmapCommandToBeatmapModel(beatmap *command.CreateBeatmapCommand) (*model.Beatmap, error) {
 	stats, err := MapBeatmapInfoToStatsJSON(beatmap.Playcount, beatmap.Passcount)
 	if err != nil {
@@ -182,6 +212,8 @@ func MapCreateBeatmapCommandToBeatmapModel(beatmap *command.CreateBeatmapCommand
 		UserID:           beatmap.UserId,
 		LastUpdated:      beatmap.LastUpdated,
 		BeatmapStats:     stats,
+		LastPlaycount:    beatmap.Playcount,
+		LastPasscount:    beatmap.Passcount,
 		UpdatedAt:        time.Now().UTC(),
 		CreatedAt:        time.Now().UTC(),
 	}, nil
@@ -208,6 +240,8 @@ func MapUpdateBeatmapCommandToBeatmapModel(beatmap *command.UpdateBeatmapCommand
 		UserID:           beatmap.UserId,
 		LastUpdated:      beatmap.LastUpdated,
 		BeatmapStats:     stats,
+		LastPlaycount:    beatmap.Playcount,
+		LastPasscount:    beatmap.Passcount,
 		UpdatedAt:        time.Now().UTC(),
 	}, nil
 }
@@ -260,9 +294,13 @@ func MapMapsetModelToMapsetDTO(mapset *model.Mapset, beatmaps []*model.Beatmap)
 		return nil, err
 	}
 
-	stats, err := MapStatsJSONToMapsetStats(mapset.MapsetStats)
-	if err != nil {
-		return nil, err
+	// stats history is not read for latest stats listings
+	var stats model.MapsetStats
+	if mapset.MapsetStats != nil {
+		stats, err = MapStatsJSONToMapsetStats(mapset.MapsetStats)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &dto.Mapset{
@@ -278,12 +316,76 @@ func MapMapsetModelToMapsetDTO(mapset *model.Mapset, beatmaps []*model.Beatmap)
 		Creator:     mapset.Creator,
 		Bpm:         mapset.BPM,
 		MapsetStats: stats,
-		Beatmaps:    beatmapsDTOs,
-		Genre:       mapset.Genre,
-		Language:    mapset.Language,
+		LatestStats: &dto.MapsetLatestStats{
+			Playcount: mapset.LastPlaycount,
+			Favorites: mapset.LastFavorites,
+			Comments:  mapset.LastComments,
+			Passcount: mapset.LastPasscount,
+		},
+		Beatmaps: beatmapsDTOs,
+		Genre:    mapset.Genre,
+		Language: mapset.Language,
+
+		RemovalStatus: mapset.RemovalStatus,
+		RemovedAt:     mapset.RemovedAt,
 	}, nil
 }
 
+func MapMapsetListingsToMapsetDTOs(listings []*model.MapsetListing) ([]*dto.Mapset, error) {
+	res := make([]*dto.Mapset, len(listings))
+	for i, listing := range listings {
+		var err error
+		res[i], err = MapMapsetModelToMapsetDTO(listing.Mapset, listing.Beatmaps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func MapAnomalyModelsToAnomalyDTOs(anomalies []*model.Anomaly) []*dto.Anomaly {
+	res := make([]*dto.Anomaly, len(anomalies))
+	for i, anomaly := range anomalies {
+		res[i] = &dto.Anomaly{
+			ID:          anomaly.ID,
+			EntityType:  anomaly.EntityType,
+			EntityID:    anomaly.EntityID,
+			MapsetID:    anomaly.MapsetID,
+			UserID:      anomaly.UserID,
+			Metric:      anomaly.Metric,
+			Kind:        anomaly.Kind,
+			Severity:    anomaly.Severity,
+			DetectedFor: anomaly.DetectedFor,
+			Value:       anomaly.Value,
+			Delta:       anomaly.Delta,
+			Baseline:    anomaly.Baseline,
+			Score:       anomaly.Score,
+			Context:     json.RawMessage(anomaly.Context),
+		}
+	}
+
+	return res
+}
+
+func MapMilestoneModelsToMilestoneDTOs(milestones []*model.Milestone) []*dto.Milestone {
+	res := make([]*dto.Milestone, len(milestones))
+	for i, milestone := range milestones {
+		res[i] = &dto.Milestone{
+			ID:         milestone.ID,
+			EntityType: milestone.EntityType,
+			EntityID:   milestone.EntityID,
+			UserID:     milestone.UserID,
+			Kind:       milestone.Kind,
+			Threshold:  milestone.Threshold,
+			Value:      milestone.Value,
+			ReachedAt:  milestone.ReachedAt,
+		}
+	}
+
+	return res
+}
+
 func MapBeatmapModelsToBeatmapDTOs(beatmaps []*model.Beatmap) ([]*dto.Beatmap, error) {
 	res := make([]*dto.Beatmap, len(beatmaps))
 	for i, beatmap := range beatmaps {
@@ -298,9 +400,13 @@ func MapBeatmapModelsToBeatmapDTOs(beatmaps []*model.Beatmap) ([]*dto.Beatmap, e
 }
 
 func MapBeatmapModelToBeatmapDTO(beatmap *model.Beatmap) (*dto.Beatmap, error) {
-	stats, err := MapStatsJSONToBeatmapStats(beatmap.BeatmapStats)
-	if err != nil {
-		return nil, err
+	var stats model.BeatmapStats
+	if beatmap.BeatmapStats != nil {
+		var err error
+		stats, err = MapStatsJSONToBeatmapStats(beatmap.BeatmapStats)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &dto.Beatmap{
@@ -318,9 +424,147 @@ func MapBeatmapModelToBeatmapDTO(beatmap *model.Beatmap) (*dto.Beatmap, error) {
 		UserId:           beatmap.UserID,
 		LastUpdated:      beatmap.LastUpdated,
 		BeatmapStats:     stats,
+		LatestStats: &dto.BeatmapLatestStats{
+			Playcount: beatmap.LastPlaycount,
+			Passcount: beatmap.LastPasscount,
+		},
 	}, nil
 }
 
+// webhooks
+
+func MapWebhookSubscriptionModelToWebhookSubscriptionDTO(subscription *model.WebhookSubscription) (*dto.WebhookSubscription, error) {
+	events := make([]string, 0)
+	if len(subscription.Events) > 0 {
+		if err := json.Unmarshal(subscription.Events, &events); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook subscription events: %w", err)
+		}
+	}
+
+	var templates map[string]string
+	if len(subscription.Templates) > 0 {
+		if err := json.Unmarshal(subscription.Templates, &templates); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook subscription templates: %w", err)
+		}
+	}
+
+	return &dto.WebhookSubscription{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    events,
+		Kind:      subscription.Kind,
+		Templates: templates,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}, nil
+}
+
+func MapWebhookSubscriptionModelsToWebhookSubscriptionDTOs(
+	subscriptions []*model.WebhookSubscription,
+) ([]*dto.WebhookSubscription, error) {
+	res := make([]*dto.WebhookSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		var err error
+		res[i], err = MapWebhookSubscriptionModelToWebhookSubscriptionDTO(subscription)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func MapWebhookEventsToEventsJSON(events []string) (repository.JSON, error) {
+	eventsJson, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook subscription events: %w", err)
+	}
+
+	return eventsJson, nil
+}
+
+// MapWebhookTemplatesToTemplatesJSON keeps column null when subscription uses default templates
+func MapWebhookTemplatesToTemplatesJSON(templates map[string]string) (repository.JSON, error) {
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	templatesJson, err := json.Marshal(templates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook subscription templates: %w", err)
+	}
+
+	return templatesJson, nil
+}
+
+func MapWebhookDeliveryModelsToWebhookDeliveryDTOs(deliveries []*model.WebhookDelivery) []*dto.WebhookDelivery {
+	res := make([]*dto.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = &dto.WebhookDelivery{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventType:      delivery.EventType,
+			Payload:        json.RawMessage(delivery.Payload),
+			Attempts:       delivery.Attempts,
+			StatusCode:     delivery.StatusCode,
+			Success:        delivery.Success,
+			Error:          delivery.Error,
+			DeliveredAt:    delivery.DeliveredAt,
+			CreatedAt:      delivery.CreatedAt,
+		}
+	}
+
+	return res
+}
+
+// digests
+
+func MapDigestSubscriptionModelToDigestSubscriptionDTO(subscription *model.DigestSubscription) (*dto.DigestSubscription, error) {
+	userIDs := make([]int, 0)
+	if len(subscription.UserIDs) > 0 {
+		if err := json.Unmarshal(subscription.UserIDs, &userIDs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal digest subscription user ids: %w", err)
+		}
+	}
+
+	return &dto.DigestSubscription{
+		ID:         subscription.ID,
+		Email:      subscription.Email,
+		Frequency:  subscription.Frequency,
+		UserIDs:    userIDs,
+		LastSentAt: subscription.LastSentAt,
+		CreatedAt:  subscription.CreatedAt,
+	}, nil
+}
+
+func MapDigestSubscriptionModelsToDigestSubscriptionDTOs(
+	subscriptions []*model.DigestSubscription,
+) ([]*dto.DigestSubscription, error) {
+	res := make([]*dto.DigestSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		var err error
+		res[i], err = MapDigestSubscriptionModelToDigestSubscriptionDTO(subscription)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func MapDigestUserIDsToUserIDsJSON(userIDs []int) (repository.JSON, error) {
+	if userIDs == nil {
+		userIDs = make([]int, 0)
+	}
+
+	userIDsJson, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal digest subscription user ids: %w", err)
+	}
+
+	return userIDsJson, nil
+}
+
 // covers
 
 func MapMapsetCoversToCoversJSON(m map[string]string) (repository.JSON, error) {
@@ -421,6 +665,77 @@ func MapStatsJSONToMapsetStats(j repository.JSON) (model.MapsetStats, error) {
 	return mapsetStats, nil
 }
 
+// stats -> forecast
+
+func MapMapsetStatsToForecast(stats model.MapsetStats) map[string]*dto.Forecast {
+	return forecastNonNil(map[string]*timeseries.Forecast{
+		"play_count": timeseries.Project(
+			timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Playcount) }),
+			timeseries.DefaultHorizons,
+		),
+		"favourite_count": timeseries.Project(
+			timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Favorites) }),
+			timeseries.DefaultHorizons,
+		),
+	})
+}
+
+func MapUserStatsToForecast(stats model.UserStats) map[string]*dto.Forecast {
+	return forecastNonNil(map[string]*timeseries.Forecast{
+		"play_count": timeseries.Project(
+			timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.PlayCount) }),
+			timeseries.DefaultHorizons,
+		),
+		"favourite_count": timeseries.Project(
+			timeseries.FromMap(stats, func(s *model.UserStatsModel) float64 { return float64(s.Favorites) }),
+			timeseries.DefaultHorizons,
+		),
+	})
+}
+
+// forecastNonNil drops metrics without enough history, nil is returned when none is left
+func forecastNonNil(forecast map[string]*timeseries.Forecast) map[string]*dto.Forecast {
+	res := make(map[string]*dto.Forecast, len(forecast))
+	for metric, f := range forecast {
+		if f != nil {
+			res[metric] = MapForecastToDto(f)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+func MapForecastToDto(forecast *timeseries.Forecast) *dto.Forecast {
+	projections := make([]*dto.Projection, len(forecast.Projections))
+	for i, p := range forecast.Projections {
+		projections[i] = &dto.Projection{
+			Days:  p.Days,
+			Time:  p.Time,
+			Value: p.Value,
+			Lower: p.Lower,
+			Upper: p.Upper,
+		}
+	}
+
+	var nextMilestone *dto.ForecastMilestone
+	if forecast.NextMilestone != nil {
+		nextMilestone = &dto.ForecastMilestone{
+			Value: forecast.NextMilestone.Value,
+			ETA:   forecast.NextMilestone.ETA,
+		}
+	}
+
+	return &dto.Forecast{
+		Model:         string(forecast.Model),
+		Projections:   projections,
+		NextMilestone: nextMilestone,
+	}
+}
+
 func KeepLastNKeyValuesFromStats(m interface{}, n int) {
 	mapValue := reflect.ValueOf(m)
 	if mapValue.Kind() != reflect.Map {
@@ -569,3 +884,171 @@ func MapUserMapsetsToUserCountsJSON(statuses []string) (repository.JSON, error)
 
 	return countsJSON, nil
 }
+
+func MapAPIKeyModelToAPIKeyDTO(key *model.APIKey) *dto.APIKey {
+	return &dto.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       key.Role,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func MapAPIKeyModelsToAPIKeyDTOs(keys []*model.APIKey) []*dto.APIKey {
+	res := make([]*dto.APIKey, len(keys))
+	for i, key := range keys {
+		res[i] = MapAPIKeyModelToAPIKeyDTO(key)
+	}
+
+	return res
+}
+
+func MapAuditEntryModelToAuditEntryDTO(entry *model.AuditEntry) *dto.AuditEntry {
+	return &dto.AuditEntry{
+		ID:        entry.ID,
+		APIKeyID:  entry.APIKeyID,
+		Actor:     entry.Actor,
+		Method:    entry.Method,
+		Path:      entry.Path,
+		URI:       entry.URI,
+		Status:    entry.Status,
+		RequestID: entry.RequestID,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func MapAuditEntryModelsToAuditEntryDTOs(entries []*model.AuditEntry) []*dto.AuditEntry {
+	res := make([]*dto.AuditEntry, len(entries))
+	for i, entry := range entries {
+		res[i] = MapAuditEntryModelToAuditEntryDTO(entry)
+	}
+
+	return res
+}
+
+func MapSessionToAccountDTO(session *dto.Session, following bool) *dto.Account {
+	return &dto.Account{
+		UserID:           session.UserID,
+		Username:         session.Username,
+		Following:        following,
+		SessionExpiresAt: session.ExpiresAt,
+	}
+}
+
+func MapWatchlistModelToWatchlistDTO(
+	watchlist *model.Watchlist,
+	entries []*model.WatchlistEntry,
+) (*dto.Watchlist, error) {
+	res := &dto.Watchlist{
+		ID:        watchlist.ID,
+		Name:      watchlist.Name,
+		Entries:   make([]*dto.WatchlistEntry, 0, len(entries)),
+		CreatedAt: watchlist.CreatedAt,
+		UpdatedAt: watchlist.UpdatedAt,
+	}
+
+	for _, entry := range entries {
+		if entry.WatchlistID != watchlist.ID {
+			continue
+		}
+
+		tags := make([]string, 0)
+		if len(entry.Tags) > 0 {
+			if err := json.Unmarshal(entry.Tags, &tags); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal watchlist entry tags: %w", err)
+			}
+		}
+
+		res.Entries = append(res.Entries, &dto.WatchlistEntry{
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Note:       entry.Note,
+			Tags:       tags,
+			CreatedAt:  entry.CreatedAt,
+			UpdatedAt:  entry.UpdatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func MapWatchlistModelsToWatchlistDTOs(
+	watchlists []*model.Watchlist,
+	entries []*model.WatchlistEntry,
+) ([]*dto.Watchlist, error) {
+	res := make([]*dto.Watchlist, len(watchlists))
+	for i, watchlist := range watchlists {
+		var err error
+		res[i], err = MapWatchlistModelToWatchlistDTO(watchlist, entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func MapWatchlistTagsToTagsJSON(tags []string) (repository.JSON, error) {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+
+	tagsJson, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal watchlist entry tags: %w", err)
+	}
+
+	return tagsJson, nil
+}
+
+func MapWatchedMapsetModelToWatchedMapsetDTO(watched *model.WatchedMapset) *dto.WatchedMapset {
+	return &dto.WatchedMapset{
+		ID:          watched.ID,
+		LastFetched: watched.LastFetched,
+		CreatedAt:   watched.CreatedAt,
+	}
+}
+
+func MapWatchedMapsetModelsToWatchedMapsetDTOs(watched []*model.WatchedMapset) []*dto.WatchedMapset {
+	res := make([]*dto.WatchedMapset, len(watched))
+	for i, w := range watched {
+		res[i] = MapWatchedMapsetModelToWatchedMapsetDTO(w)
+	}
+
+	return res
+}
+
+func MapMapsetFacetsModelToMapsetFacetsDTO(facets model.MapsetFacets) *dto.MapsetFacets {
+	mapCounts := func(counts []*model.FacetCount) []*dto.FacetCount {
+		res := make([]*dto.FacetCount, len(counts))
+		for i, c := range counts {
+			res[i] = &dto.FacetCount{Value: c.Value, Count: c.Count}
+		}
+
+		return res
+	}
+
+	return &dto.MapsetFacets{
+		Status:   mapCounts(facets[model.MapsetStatusFacet]),
+		Genre:    mapCounts(facets[model.MapsetGenreFacet]),
+		Language: mapCounts(facets[model.MapsetLanguageFacet]),
+	}
+}
+
+func MapPageModelToPageDTO(req model.PageRequest, page *model.Page) *dto.Page {
+	res := &dto.Page{
+		Total: page.Total,
+		Limit: req.Limit,
+		Next:  pagination.EncodeCursor(page.Next),
+		Prev:  pagination.EncodeCursor(page.Prev),
+		Pages: pagination.Pages(page.Total, req.Limit),
+	}
+	if req.Cursor == nil {
+		res.CurrentPage = req.Offset/req.Limit + 1
+	}
+
+	return res
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"playcount-monitor-backend/internal/database/repository"
 	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/timeseries"
 	"testing"
 	"time"
 )
@@ -85,3 +87,66 @@ func Test_KeepLastNKeyValuesFromStats(t *testing.T) {
 	KeepLastNKeyValuesFromStats(data, 7)
 	assert.Equal(t, 7, len(data))
 }
+
+func Test_MapPageModelToPageDTO(t *testing.T) {
+	next := &model.Cursor{Order: "created_at DESC", Value: "2026-10-19T00:00:00Z", ID: 3}
+
+	// exact multiple of limit doesn't make an extra empty page
+	page := MapPageModelToPageDTO(model.PageRequest{Limit: 50, Offset: 50}, &model.Page{Total: 100, Next: next})
+	assert.Equal(t, 2, page.CurrentPage)
+	assert.Equal(t, 2, page.Pages)
+	assert.Equal(t, 100, page.Total)
+	assert.NotEmpty(t, page.Next)
+	assert.Empty(t, page.Prev)
+
+	// page number is unknown for pages reached by cursor
+	page = MapPageModelToPageDTO(model.PageRequest{Limit: 50, Cursor: next}, &model.Page{Total: 120})
+	assert.Equal(t, 0, page.CurrentPage)
+	assert.Equal(t, 3, page.Pages)
+}
+
+func Test_MapMapsetListingsToMapsetDTOs(t *testing.T) {
+	// latest stats listings are read without stats history
+	listings := []*model.MapsetListing{{
+		Mapset: &model.Mapset{
+			ID:            1,
+			Covers:        repository.JSON(`{}`),
+			LastPlaycount: 100,
+			LastFavorites: 5,
+			LastComments:  2,
+			LastPasscount: 40,
+		},
+		Beatmaps: []*model.Beatmap{{ID: 10, MapsetID: 1, LastPlaycount: 100, LastPasscount: 40}},
+	}}
+
+	mapsets, err := MapMapsetListingsToMapsetDTOs(listings)
+	assert.NoError(t, err)
+	assert.Len(t, mapsets, 1)
+	assert.Nil(t, mapsets[0].MapsetStats)
+	assert.Equal(t, &dto.MapsetLatestStats{Playcount: 100, Favorites: 5, Comments: 2, Passcount: 40}, mapsets[0].LatestStats)
+	assert.Nil(t, mapsets[0].Beatmaps[0].BeatmapStats)
+	assert.Equal(t, &dto.BeatmapLatestStats{Playcount: 100, Passcount: 40}, mapsets[0].Beatmaps[0].LatestStats)
+}
+
+func Test_MapMapsetStatsToForecast(t *testing.T) {
+	stats := make(model.MapsetStats)
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		stats[day.AddDate(0, 0, i)] = &model.MapsetStatsModel{Playcount: 1000 + 100*i, Favorites: 5}
+	}
+
+	forecast := MapMapsetStatsToForecast(stats)
+
+	// dto keeps json of forecast computed by timeseries
+	expected := timeseries.Project(
+		timeseries.FromMap(stats, func(s *model.MapsetStatsModel) float64 { return float64(s.Playcount) }),
+		timeseries.DefaultHorizons,
+	)
+	expectedJSON, err := json.Marshal(expected)
+	assert.NoError(t, err)
+	actualJSON, err := json.Marshal(forecast["play_count"])
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+
+	assert.Nil(t, MapMapsetStatsToForecast(model.MapsetStats{}))
+}
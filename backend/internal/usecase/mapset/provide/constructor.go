@@ -6,6 +6,7 @@ import (
 	"playcount-monitor-backend/internal/config"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/cache"
 )
 
 type beatmapStore interface {
@@ -21,32 +22,69 @@ type mapsetStore interface {
 	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Mapset, error)
 	Update(ctx context.Context, tx txmanager.Tx, mapset *model.Mapset) error
 	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
-	ListWithFilterSortLimitOffset(
+	ListWithFilterSort(
 		ctx context.Context,
 		tx txmanager.Tx,
 		filter model.MapsetFilter,
 		sort model.MapsetSort,
-		limit int,
-		offset int,
-	) ([]*model.Mapset, int, error)
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
 	ListForUser(ctx context.Context, tx txmanager.Tx, userId int) ([]*model.Mapset, error)
-	ListForUserWithFilterSortLimitOffset(
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error)
+	ListForUserWithFilterSort(
 		ctx context.Context,
 		tx txmanager.Tx,
 		userID int,
 		filter model.MapsetFilter,
 		sort model.MapsetSort,
-		limit int,
-		offset int,
-	) ([]*model.Mapset, int, error)
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	ListGuestForUserWithFilterSort(
+		ctx context.Context,
+		tx txmanager.Tx,
+		userID int,
+		filter model.MapsetFilter,
+		sort model.MapsetSort,
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	Search(
+		ctx context.Context,
+		tx txmanager.Tx,
+		search *model.MapsetSearch,
+		sort model.MapsetSort,
+		page model.PageRequest,
+	) ([]*model.Mapset, *model.Page, error)
+	SearchFacets(ctx context.Context, tx txmanager.Tx, search *model.MapsetSearch) (model.MapsetFacets, error)
+	ListTrending(
+		ctx context.Context,
+		tx txmanager.Tx,
+		periodDays int,
+		filter model.MapsetFilter,
+		sort model.TrendingSortField,
+		page model.PageRequest,
+	) ([]*model.TrendingMapset, *model.Page, error)
+}
+
+// listingStore is read side of mapset lists, it reads beatmaps of a whole page of mapsets at once
+type listingStore interface {
+	Attach(ctx context.Context, tx txmanager.Tx, stats model.ListingStats, mapsets ...*model.Mapset) ([]*model.MapsetListing, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, stats model.ListingStats, ids ...int) ([]*model.MapsetListing, error)
+}
+
+type watchlistStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error)
 }
 
 type UseCase struct {
-	cfg     *config.Config
-	lg      *log.Logger
-	txm     txmanager.TxManager
-	beatmap beatmapStore
-	mapset  mapsetStore
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	beatmap   beatmapStore
+	mapset    mapsetStore
+	listing   listingStore
+	watchlist watchlistStore
+	// cache keeps results until the next tracking run, nil disables it
+	cache cache.Interface
 }
 
 func New(
@@ -55,12 +93,18 @@ func New(
 	txm txmanager.TxManager,
 	beatmap beatmapStore,
 	mapset mapsetStore,
+	listing listingStore,
+	watchlist watchlistStore,
+	cache cache.Interface,
 ) *UseCase {
 	return &UseCase{
-		cfg:     cfg,
-		lg:      lg,
-		txm:     txm,
-		beatmap: beatmap,
-		mapset:  mapset,
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		beatmap:   beatmap,
+		mapset:    mapset,
+		listing:   listing,
+		watchlist: watchlist,
+		cache:     cache,
 	}
 }
@@ -2,18 +2,31 @@ package mapsetprovide
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"playcount-monitor-backend/internal/usecase/mappers"
 )
 
-func (uc *UseCase) Get(
+func (uc *UseCase) Get(ctx context.Context, id int) (*dto.Mapset, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.Get", id), func() (*dto.Mapset, error) {
+		return uc.get(ctx, id)
+	})
+}
+
+func (uc *UseCase) get(
 	ctx context.Context,
 	id int,
 ) (*dto.Mapset, error) {
 	var dtoMapset *dto.Mapset
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		mapset, err := uc.mapset.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("mapset %v not found", id)
+		}
 		if err != nil {
 			return err
 		}
@@ -34,6 +47,9 @@ func (uc *UseCase) Get(
 		return nil, txErr
 	}
 
+	// forecast uses whole history, so it goes before stats are trimmed
+	dtoMapset.Forecast = mappers.MapMapsetStatsToForecast(dtoMapset.MapsetStats)
+
 	mappers.KeepLastNKeyValuesFromStats(dtoMapset.MapsetStats, statsMaxElements)
 	for _, beatmap := range dtoMapset.Beatmaps {
 		mappers.KeepLastNKeyValuesFromStats(beatmap.BeatmapStats, statsMaxElements)
@@ -2,147 +2,152 @@ package mapsetprovide
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
 )
 
-const mapsetsPerPage = 50
 const statsMaxElements = 7
 
 type ListCommand struct {
-	Page   int
+	pagination.Query
 	Sort   model.MapsetSort
 	Filter model.MapsetFilter
+	// Stats picks whether stats history is listed along with latest stats, history is listed by default
+	Stats model.ListingStats
+	// WatchlistID narrows list to mapsets and mapsets of mappers in watchlist of AccountID
+	AccountID   int
+	WatchlistID int
 }
 
 type ListResponse struct {
-	Mapsets     []*dto.Mapset
-	CurrentPage int
-	Pages       int
+	Mapsets []*dto.Mapset
+	Page    *dto.Page
 }
 
-func (uc *UseCase) List(
+func (uc *UseCase) List(ctx context.Context, cmd *ListCommand) (*ListResponse, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.List", cmd), func() (*ListResponse, error) {
+		return uc.list(ctx, cmd)
+	})
+}
+
+func (uc *UseCase) list(
 	ctx context.Context,
 	cmd *ListCommand,
 ) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
 	var dtoMapsets []*dto.Mapset
-	var count int
+	var page *model.Page
 
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
-		mapsets, c, err := uc.mapset.ListWithFilterSortLimitOffset(
-			ctx,
-			tx,
-			cmd.Filter,
-			cmd.Sort,
-			mapsetsPerPage,
-			(cmd.Page-1)*mapsetsPerPage,
-		)
-		if err != nil {
-			return err
-		}
-
-		for _, m := range mapsets {
-			bm, err := uc.beatmap.ListForMapset(ctx, tx, m.ID)
-			if err != nil {
+		if cmd.WatchlistID != 0 {
+			if err := checkWatchlistOwner(ctx, tx, uc.watchlist, cmd.AccountID, cmd.WatchlistID); err != nil {
 				return err
 			}
-
-			dtoMapset, err := mappers.MapMapsetModelToMapsetDTO(m, bm)
-			if err != nil {
-				return err
+			if cmd.Filter == nil {
+				cmd.Filter = make(model.MapsetFilter)
 			}
+			cmd.Filter[model.MapsetWatchlistField] = cmd.WatchlistID
+		}
 
-			dtoMapsets = append(dtoMapsets, dtoMapset)
-			count = c
+		mapsets, p, err := uc.mapset.ListWithFilterSort(ctx, tx, cmd.Filter, cmd.Sort, pageReq)
+		if err != nil {
+			return pagination.Error(err)
+		}
+		page = p
+
+		listings, err := uc.listing.Attach(ctx, tx, cmd.Stats, mapsets...)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		dtoMapsets, err = mappers.MapMapsetListingsToMapsetDTOs(listings)
+		return err
 	})
 	if txErr != nil {
 		return nil, txErr
 	}
 
-	for _, mapset := range dtoMapsets {
-		mappers.KeepLastNKeyValuesFromStats(mapset.MapsetStats, statsMaxElements)
-		for _, beatmap := range mapset.Beatmaps {
-			mappers.KeepLastNKeyValuesFromStats(beatmap.BeatmapStats, statsMaxElements)
-		}
-	}
+	keepLastStats(dtoMapsets)
 
 	return &ListResponse{
-		Mapsets:     dtoMapsets,
-		CurrentPage: cmd.Page,
-		Pages:       (count / mapsetsPerPage) + 1,
+		Mapsets: dtoMapsets,
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
 	}, nil
 }
 
-func (uc *UseCase) ListForUser(
+func (uc *UseCase) ListForUser(ctx context.Context, userID int, cmd *ListCommand) (*ListResponse, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.ListForUser", userID, cmd), func() (*ListResponse, error) {
+		return uc.listForUser(ctx, userID, cmd)
+	})
+}
+
+func (uc *UseCase) listForUser(
 	ctx context.Context,
 	userID int,
 	cmd *ListCommand,
 ) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
 	var dtoMapsets []*dto.Mapset
-	var count int
+	var page *model.Page
 
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
-		mapsets, _, err := uc.mapset.ListForUserWithFilterSortLimitOffset(
-			ctx,
-			tx,
-			userID,
-			cmd.Filter,
-			cmd.Sort,
-			mapsetsPerPage,
-			(cmd.Page-1)*mapsetsPerPage,
-		)
+		mapsets, p, err := uc.mapset.ListForUserWithFilterSort(ctx, tx, userID, cmd.Filter, cmd.Sort, pageReq)
 		if err != nil {
-			return err
-		}
-
-		mapsetIDs := make([]int, len(mapsets))
-		for i, m := range mapsets {
-			mapsetIDs[i] = m.ID
+			return pagination.Error(err)
 		}
+		page = p
 
-		beatmaps, err := uc.beatmap.ListForMapsets(ctx, tx, mapsetIDs...)
+		listings, err := uc.listing.Attach(ctx, tx, cmd.Stats, mapsets...)
 		if err != nil {
 			return err
 		}
 
-		// now attach beatmaps to mapsets
-		for _, m := range mapsets {
-			mapsetBeatmaps := make([]*model.Beatmap, 0)
-			for _, bm := range beatmaps {
-				if bm.MapsetID == m.ID {
-					mapsetBeatmaps = append(mapsetBeatmaps, bm)
-				}
-			}
-
-			dtoMapset, err := mappers.MapMapsetModelToMapsetDTO(m, mapsetBeatmaps)
-			if err != nil {
-				return err
-			}
-
-			dtoMapsets = append(dtoMapsets, dtoMapset)
-		}
-
-		return nil
+		dtoMapsets, err = mappers.MapMapsetListingsToMapsetDTOs(listings)
+		return err
 	})
 	if txErr != nil {
 		return nil, txErr
 	}
 
-	for _, mapset := range dtoMapsets {
+	keepLastStats(dtoMapsets)
+
+	return &ListResponse{
+		Mapsets: dtoMapsets,
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
+
+// keepLastStats trims stats history of listed mapsets and their beatmaps
+func keepLastStats(mapsets []*dto.Mapset) {
+	for _, mapset := range mapsets {
 		mappers.KeepLastNKeyValuesFromStats(mapset.MapsetStats, statsMaxElements)
 		for _, beatmap := range mapset.Beatmaps {
 			mappers.KeepLastNKeyValuesFromStats(beatmap.BeatmapStats, statsMaxElements)
 		}
 	}
+}
 
-	return &ListResponse{
-		Mapsets:     dtoMapsets,
-		CurrentPage: cmd.Page,
-		Pages:       (count / mapsetsPerPage) + 1,
-	}, nil
+// checkWatchlistOwner reports watchlists of other accounts as missing so their ids are not disclosed
+func checkWatchlistOwner(ctx context.Context, tx txmanager.Tx, watchlist watchlistStore, accountID, id int) error {
+	w, err := watchlist.Get(ctx, tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && w.AccountID != accountID) {
+		return domainerror.NotFound("watchlist %v not found", id)
+	}
+
+	return err
 }
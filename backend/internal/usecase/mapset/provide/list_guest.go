@@ -0,0 +1,83 @@
+package mapsetprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+// ListGuestForUser lists page of other hosts' mapsets the user mapped guest difficulties for,
+// each mapset carries only beatmaps mapped by the user
+func (uc *UseCase) ListGuestForUser(ctx context.Context, userID int, cmd *ListCommand) (*ListResponse, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.ListGuestForUser", userID, cmd), func() (*ListResponse, error) {
+		return uc.listGuestForUser(ctx, userID, cmd)
+	})
+}
+
+func (uc *UseCase) listGuestForUser(
+	ctx context.Context,
+	userID int,
+	cmd *ListCommand,
+) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtoMapsets []*dto.Mapset
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapsets, p, err := uc.mapset.ListGuestForUserWithFilterSort(ctx, tx, userID, cmd.Filter, cmd.Sort, pageReq)
+		if err != nil {
+			return pagination.Error(err)
+		}
+		page = p
+
+		if len(mapsets) == 0 {
+			return nil
+		}
+
+		mapsetIDs := make([]int, 0, len(mapsets))
+		for _, mapset := range mapsets {
+			mapsetIDs = append(mapsetIDs, mapset.ID)
+		}
+
+		beatmaps, err := uc.beatmap.ListForMapsets(ctx, tx, mapsetIDs...)
+		if err != nil {
+			return err
+		}
+
+		guestBeatmaps := make(map[int][]*model.Beatmap)
+		for _, beatmap := range beatmaps {
+			if beatmap.UserID == userID {
+				guestBeatmaps[beatmap.MapsetID] = append(guestBeatmaps[beatmap.MapsetID], beatmap)
+			}
+		}
+
+		for _, mapset := range mapsets {
+			dtoMapset, err := mappers.MapMapsetModelToMapsetDTO(mapset, guestBeatmaps[mapset.ID])
+			if err != nil {
+				return err
+			}
+
+			dtoMapsets = append(dtoMapsets, dtoMapset)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	keepLastStats(dtoMapsets)
+
+	return &ListResponse{
+		Mapsets: dtoMapsets,
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package mapsetprovide
+
+import (
+	"context"
+	"errors"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+	"slices"
+)
+
+type ListTrendingCommand struct {
+	pagination.Query
+	// PeriodDays is one of configured trending periods, zero picks the first one
+	PeriodDays int
+	Sort       model.TrendingSortField
+	Filter     model.MapsetFilter
+	Stats      model.ListingStats
+}
+
+type ListTrendingResponse struct {
+	Mapsets []*dto.TrendingMapset
+	Page    *dto.Page
+}
+
+func (uc *UseCase) ListTrending(ctx context.Context, cmd *ListTrendingCommand) (*ListTrendingResponse, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.ListTrending", cmd), func() (*ListTrendingResponse, error) {
+		return uc.listTrending(ctx, cmd)
+	})
+}
+
+func (uc *UseCase) listTrending(
+	ctx context.Context,
+	cmd *ListTrendingCommand,
+) (*ListTrendingResponse, error) {
+	if len(uc.cfg.TrendingPeriods) == 0 {
+		return nil, errors.New("no trending periods are configured")
+	}
+
+	periodDays := cmd.PeriodDays
+	if periodDays == 0 {
+		periodDays = uc.cfg.TrendingPeriods[0]
+	}
+	if !slices.Contains(uc.cfg.TrendingPeriods, periodDays) {
+		return nil, domainerror.Validation("unknown trending period %v days, available: %v", periodDays, uc.cfg.TrendingPeriods)
+	}
+
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtoMapsets []*dto.TrendingMapset
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		trending, p, err := uc.mapset.ListTrending(ctx, tx, periodDays, cmd.Filter, cmd.Sort, pageReq)
+		if err != nil {
+			return pagination.Error(err)
+		}
+
+		page = p
+		if len(trending) == 0 {
+			return nil
+		}
+
+		mapsetIDs := make([]int, len(trending))
+		trendingByID := make(map[int]*model.TrendingMapset, len(trending))
+		for i, t := range trending {
+			mapsetIDs[i] = t.MapsetID
+			trendingByID[t.MapsetID] = t
+		}
+
+		// listings keep ranking order
+		listings, err := uc.listing.ListByIDs(ctx, tx, cmd.Stats, mapsetIDs...)
+		if err != nil {
+			return err
+		}
+
+		for _, listing := range listings {
+			dtoMapset, err := mappers.MapMapsetModelToMapsetDTO(listing.Mapset, listing.Beatmaps)
+			if err != nil {
+				return err
+			}
+
+			t := trendingByID[listing.Mapset.ID]
+			dtoMapsets = append(dtoMapsets, &dto.TrendingMapset{
+				Mapset:        dtoMapset,
+				PeriodDays:    t.PeriodDays,
+				PlaycountGain: t.PlaycountGain,
+				RelativeGain:  t.RelativeGain,
+			})
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	for _, mapset := range dtoMapsets {
+		mappers.KeepLastNKeyValuesFromStats(mapset.MapsetStats, statsMaxElements)
+		for _, beatmap := range mapset.Beatmaps {
+			mappers.KeepLastNKeyValuesFromStats(beatmap.BeatmapStats, statsMaxElements)
+		}
+	}
+
+	return &ListTrendingResponse{
+		Mapsets: dtoMapsets,
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
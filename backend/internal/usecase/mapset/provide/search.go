@@ -0,0 +1,106 @@
+package mapsetprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+const maxSearchQueryLength = 200
+
+type SearchCommand struct {
+	pagination.Query
+	Sort   model.MapsetSort
+	Search *model.MapsetSearch
+	Stats  model.ListingStats
+}
+
+type SearchResponse struct {
+	Mapsets []*dto.Mapset
+	Facets  *dto.MapsetFacets
+	Page    *dto.Page
+}
+
+// Search lists mapsets matching full-text query and filters along with facet counts of the whole result
+func (uc *UseCase) Search(ctx context.Context, cmd *SearchCommand) (*SearchResponse, error) {
+	return cache.Fetch(uc.cache, cache.Key("mapset.Search", cmd), func() (*SearchResponse, error) {
+		return uc.search(ctx, cmd)
+	})
+}
+
+func (uc *UseCase) search(
+	ctx context.Context,
+	cmd *SearchCommand,
+) (*SearchResponse, error) {
+	if err := validateSearch(cmd.Search); err != nil {
+		return nil, err
+	}
+
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtoMapsets []*dto.Mapset
+	var facets model.MapsetFacets
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapsets, p, err := uc.mapset.Search(ctx, tx, cmd.Search, cmd.Sort, pageReq)
+		if err != nil {
+			return pagination.Error(err)
+		}
+		page = p
+
+		facets, err = uc.mapset.SearchFacets(ctx, tx, cmd.Search)
+		if err != nil {
+			return err
+		}
+
+		listings, err := uc.listing.Attach(ctx, tx, cmd.Stats, mapsets...)
+		if err != nil {
+			return err
+		}
+
+		dtoMapsets, err = mappers.MapMapsetListingsToMapsetDTOs(listings)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	keepLastStats(dtoMapsets)
+
+	return &SearchResponse{
+		Mapsets: dtoMapsets,
+		Facets:  mappers.MapMapsetFacetsModelToMapsetFacetsDTO(facets),
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
+
+func validateSearch(search *model.MapsetSearch) error {
+	if len(search.Query) > maxSearchQueryLength {
+		return domainerror.Validation("search query is longer than %d characters", maxSearchQueryLength)
+	}
+
+	for name, r := range map[string]model.Range{
+		"bpm":         search.BPM,
+		"playcount":   search.Playcount,
+		"passcount":   search.Passcount,
+		"star rating": search.StarRating,
+		"length":      search.Length,
+		"ar":          search.AR,
+		"cs":          search.CS,
+	} {
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return domainerror.Validation("invalid %s range: min %v is greater than max %v", name, *r.Min, *r.Max)
+		}
+	}
+
+	return nil
+}
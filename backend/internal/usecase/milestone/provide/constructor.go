@@ -0,0 +1,39 @@
+package milestoneprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type milestoneStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MilestoneFilter,
+		page model.PageRequest,
+	) ([]*model.Milestone, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	milestone milestoneStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	milestone milestoneStore,
+) *UseCase {
+	return &UseCase{
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		milestone: milestone,
+	}
+}
@@ -0,0 +1,96 @@
+// Package pagination turns paging params of list commands into repository page requests
+// and keyset cursors into opaque strings clients pass back
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Query is paging part of list commands, Cursor continues from a previous page and takes precedence over Page
+type Query struct {
+	Page   int
+	Limit  int
+	Cursor string
+}
+
+// Request validates query, zero limit is DefaultLimit and zero page is the first one
+func Request(q Query) (model.PageRequest, error) {
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 0 || limit > MaxLimit {
+		return model.PageRequest{}, domainerror.Validation("limit must be between 1 and %d", MaxLimit)
+	}
+
+	req := model.PageRequest{Limit: limit}
+	if q.Cursor != "" {
+		cursor, err := DecodeCursor(q.Cursor)
+		if err != nil {
+			return model.PageRequest{}, domainerror.Validation("invalid cursor")
+		}
+		req.Cursor = cursor
+	} else if q.Page > 1 {
+		req.Offset = (q.Page - 1) * limit
+	}
+
+	return req, nil
+}
+
+// Error reports cursor made for another sort as invalid request, other errors are returned as is
+func Error(err error) error {
+	if errors.Is(err, keyset.ErrOrderMismatch) {
+		return domainerror.Validation("cursor was made for another sort, start over without it")
+	}
+
+	return err
+}
+
+// Pages is count of pages of limit entries, empty list still has one page
+func Pages(total int, limit int) int {
+	if total == 0 || limit <= 0 {
+		return 1
+	}
+
+	return (total + limit - 1) / limit
+}
+
+func EncodeCursor(cursor *model.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*model.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := new(model.Cursor)
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Order == "" {
+		return nil, errors.New("cursor has no order")
+	}
+
+	return cursor, nil
+}
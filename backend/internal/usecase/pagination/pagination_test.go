@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/keyset"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"testing"
+)
+
+func TestRequest(t *testing.T) {
+	cursor := &model.Cursor{Order: "created_at DESC", Value: "2026-10-19T00:00:00Z", ID: 42}
+
+	tt := []struct {
+		name     string
+		query    Query
+		expected model.PageRequest
+		wantErr  bool
+	}{
+		{name: "Default limit", query: Query{Page: 1}, expected: model.PageRequest{Limit: DefaultLimit}},
+		{name: "Page is offset", query: Query{Page: 3, Limit: 20}, expected: model.PageRequest{Limit: 20, Offset: 40}},
+		{
+			name:     "Cursor takes precedence over page",
+			query:    Query{Page: 3, Limit: 20, Cursor: EncodeCursor(cursor)},
+			expected: model.PageRequest{Limit: 20, Cursor: cursor},
+		},
+		{name: "Limit over max", query: Query{Limit: MaxLimit + 1}, wantErr: true},
+		{name: "Malformed cursor", query: Query{Cursor: "not a cursor"}, wantErr: true},
+		{name: "Cursor without order", query: Query{Cursor: EncodeCursor(&model.Cursor{ID: 1})}, wantErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := Request(tc.query)
+			if tc.wantErr {
+				assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, req)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	assert.True(t, domainerror.IsKind(Error(keyset.ErrOrderMismatch), domainerror.KindValidation))
+	assert.Nil(t, Error(nil))
+}
+
+func TestPages(t *testing.T) {
+	assert.Equal(t, 1, Pages(0, 50))
+	assert.Equal(t, 1, Pages(50, 50))
+	assert.Equal(t, 2, Pages(51, 50))
+	assert.Equal(t, 2, Pages(100, 50))
+}
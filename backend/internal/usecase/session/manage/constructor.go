@@ -0,0 +1,51 @@
+package sessionmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/service/tokencipher"
+	"time"
+)
+
+type sessionStore interface {
+	UpsertAccount(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error
+	GetAccount(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error)
+	GetAccountForUpdate(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error)
+	UpdateAccountTokens(ctx context.Context, tx txmanager.Tx, account *model.OsuAccount) error
+	CreateSession(ctx context.Context, tx txmanager.Tx, session *model.Session) error
+	GetSession(ctx context.Context, tx txmanager.Tx, tokenHash string) (*model.Session, error)
+	DeleteSession(ctx context.Context, tx txmanager.Tx, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, tx txmanager.Tx, now time.Time) error
+}
+
+type UseCase struct {
+	cfg     *config.Config
+	lg      *log.Logger
+	txm     txmanager.TxManager
+	session sessionStore
+	oauth   osuoauth.Interface
+	// cipher is nil when TOKEN_ENCRYPTION_KEY isn't set, login is disabled then
+	cipher tokencipher.Interface
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	session sessionStore,
+	oauth osuoauth.Interface,
+	cipher tokencipher.Interface,
+) *UseCase {
+	return &UseCase{
+		cfg:     cfg,
+		lg:      lg,
+		txm:     txm,
+		session: session,
+		oauth:   oauth,
+		cipher:  cipher,
+	}
+}
@@ -0,0 +1,238 @@
+package sessionmanage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"time"
+)
+
+const (
+	randomTokenBytes = 32
+	// access token is refreshed a bit before it expires, so it doesn't expire mid request
+	refreshLeeway = time.Minute
+)
+
+// Begin starts login, state guards callback against forged requests and has to be kept by the client
+func (uc *UseCase) Begin() (*dto.LoginRedirect, error) {
+	if uc.cipher == nil {
+		return nil, errLoginDisabled()
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginRedirect{
+		URL:   uc.oauth.AuthorizeURL(state),
+		State: state,
+	}, nil
+}
+
+// Login exchanges code from osu! callback for user token, stores the account and starts a session
+func (uc *UseCase) Login(ctx context.Context, code string) (*dto.Session, error) {
+	if uc.cipher == nil {
+		return nil, errLoginDisabled()
+	}
+
+	token, err := uc.oauth.Exchange(ctx, code)
+	if err != nil {
+		return nil, mapOAuthError(err, "failed to exchange osu! authorization code")
+	}
+
+	me, err := uc.oauth.GetMe(ctx, token.AccessToken)
+	if err != nil {
+		return nil, mapOAuthError(err, "failed to get osu! user who logged in")
+	}
+
+	now := time.Now().UTC()
+	account := &model.OsuAccount{
+		UserID:    me.ID,
+		Username:  me.Username,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.sealTokens(account, token); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &model.Session{
+		TokenHash: hashToken(plaintext),
+		UserID:    account.UserID,
+		ExpiresAt: now.Add(uc.cfg.SessionTTL),
+		CreatedAt: now,
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		if err := uc.session.UpsertAccount(ctx, tx, account); err != nil {
+			return err
+		}
+		// expired sessions are only ever read to be rejected, login is a good enough moment to drop them
+		if err := uc.session.DeleteExpiredSessions(ctx, tx, now); err != nil {
+			return err
+		}
+
+		return uc.session.CreateSession(ctx, tx, session)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return &dto.Session{
+		UserID:    account.UserID,
+		Username:  account.Username,
+		Token:     plaintext,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// Authenticate finds unexpired session by its cookie value
+func (uc *UseCase) Authenticate(ctx context.Context, plaintext string) (*dto.Session, error) {
+	var session *model.Session
+	var account *model.OsuAccount
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		session, err = uc.session.GetSession(ctx, tx, hashToken(plaintext))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.Unauthenticated("session not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		account, err = uc.session.GetAccount(ctx, tx, session.UserID)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	if !session.ExpiresAt.After(time.Now().UTC()) {
+		return nil, domainerror.Unauthenticated("session expired")
+	}
+
+	return &dto.Session{
+		UserID:    account.UserID,
+		Username:  account.Username,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (uc *UseCase) Logout(ctx context.Context, plaintext string) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.session.DeleteSession(ctx, tx, hashToken(plaintext))
+	})
+}
+
+// AccessToken returns osu! access token of logged in user, refreshing it first when it is about to expire
+func (uc *UseCase) AccessToken(ctx context.Context, userID int) (string, error) {
+	if uc.cipher == nil {
+		return "", errLoginDisabled()
+	}
+
+	var accessToken string
+
+	// account row stays locked while refreshing, osu! rotates refresh tokens so concurrent refreshes
+	// of the same account would invalidate each other
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		account, err := uc.session.GetAccountForUpdate(ctx, tx, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.Unauthenticated("osu! account %v never logged in", userID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if account.TokenExpiresAt.After(time.Now().UTC().Add(refreshLeeway)) {
+			accessToken, err = uc.cipher.Decrypt(account.AccessTokenEncrypted)
+			return err
+		}
+
+		refreshToken, err := uc.cipher.Decrypt(account.RefreshTokenEncrypted)
+		if err != nil {
+			return err
+		}
+
+		token, err := uc.oauth.Refresh(ctx, refreshToken)
+		if err != nil {
+			return mapOAuthError(err, "failed to refresh osu! token")
+		}
+
+		account.UpdatedAt = time.Now().UTC()
+		if err := uc.sealTokens(account, token); err != nil {
+			return err
+		}
+		if err := uc.session.UpdateAccountTokens(ctx, tx, account); err != nil {
+			return err
+		}
+		accessToken = token.AccessToken
+
+		return nil
+	})
+	if txErr != nil {
+		return "", txErr
+	}
+
+	return accessToken, nil
+}
+
+func (uc *UseCase) sealTokens(account *model.OsuAccount, token *osuoauth.Token) error {
+	accessToken, err := uc.cipher.Encrypt(token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+
+	refreshToken, err := uc.cipher.Encrypt(token.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt refresh token: %w", err)
+	}
+
+	account.AccessTokenEncrypted = accessToken
+	account.RefreshTokenEncrypted = refreshToken
+	account.TokenExpiresAt = token.ExpiresAt
+
+	return nil
+}
+
+// mapOAuthError asks user to log in again when osu! rejected their grant, other failures are upstream ones
+func mapOAuthError(err error, detail string) error {
+	if errors.Is(err, osuoauth.ErrInvalidGrant) {
+		return domainerror.Unauthenticated("osu! authorization is invalid or expired, log in again")
+	}
+
+	return domainerror.Unavailable(err, detail)
+}
+
+func errLoginDisabled() error {
+	return domainerror.Unavailable(nil, "login with osu! is not configured")
+}
+
+func hashToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, randomTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
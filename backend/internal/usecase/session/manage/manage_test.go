@@ -0,0 +1,227 @@
+package sessionmanage
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/url"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/service/osuoauth"
+	"playcount-monitor-backend/internal/service/osuoauth/osuoauthtest"
+	"playcount-monitor-backend/internal/service/tokencipher"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSessionStore struct {
+	accounts map[int]*model.OsuAccount
+	sessions map[string]*model.Session
+}
+
+func newFakeSessionStore() *fakeSessionStore {
+	return &fakeSessionStore{
+		accounts: make(map[int]*model.OsuAccount),
+		sessions: make(map[string]*model.Session),
+	}
+}
+
+func (s *fakeSessionStore) UpsertAccount(_ context.Context, _ txmanager.Tx, account *model.OsuAccount) error {
+	stored := *account
+	s.accounts[account.UserID] = &stored
+	return nil
+}
+
+func (s *fakeSessionStore) GetAccount(_ context.Context, _ txmanager.Tx, userID int) (*model.OsuAccount, error) {
+	account, ok := s.accounts[userID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get osu account %v: %w", userID, gorm.ErrRecordNotFound)
+	}
+
+	stored := *account
+	return &stored, nil
+}
+
+func (s *fakeSessionStore) GetAccountForUpdate(ctx context.Context, tx txmanager.Tx, userID int) (*model.OsuAccount, error) {
+	return s.GetAccount(ctx, tx, userID)
+}
+
+func (s *fakeSessionStore) UpdateAccountTokens(_ context.Context, _ txmanager.Tx, account *model.OsuAccount) error {
+	stored := s.accounts[account.UserID]
+	stored.AccessTokenEncrypted = account.AccessTokenEncrypted
+	stored.RefreshTokenEncrypted = account.RefreshTokenEncrypted
+	stored.TokenExpiresAt = account.TokenExpiresAt
+	return nil
+}
+
+func (s *fakeSessionStore) CreateSession(_ context.Context, _ txmanager.Tx, session *model.Session) error {
+	s.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (s *fakeSessionStore) GetSession(_ context.Context, _ txmanager.Tx, tokenHash string) (*model.Session, error) {
+	session, ok := s.sessions[tokenHash]
+	if !ok {
+		return nil, fmt.Errorf("failed to get session: %w", gorm.ErrRecordNotFound)
+	}
+
+	return session, nil
+}
+
+func (s *fakeSessionStore) DeleteSession(_ context.Context, _ txmanager.Tx, tokenHash string) error {
+	delete(s.sessions, tokenHash)
+	return nil
+}
+
+func (s *fakeSessionStore) DeleteExpiredSessions(_ context.Context, _ txmanager.Tx, now time.Time) error {
+	for hash, session := range s.sessions {
+		if !session.ExpiresAt.After(now) {
+			delete(s.sessions, hash)
+		}
+	}
+	return nil
+}
+
+func newTestUseCase(stand *osuoauthtest.Server, store *fakeSessionStore) *UseCase {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	cfg := &config.Config{
+		OsuAPIClientID:       osuoauthtest.ClientID,
+		OsuAPIClientSecret:   osuoauthtest.ClientSecret,
+		OsuAPIHost:           stand.APIHost(),
+		OsuOAuthHost:         stand.TokenURL(),
+		OsuOAuthAuthorizeURL: stand.AuthorizeURL(),
+		OsuOAuthRedirectURL:  "http://localhost:8080/api/auth/osu/callback",
+		SessionTTL:           time.Hour,
+	}
+
+	return New(cfg, lg, txmanagertest.TxManager{}, store, osuoauth.New(cfg, &http.Client{}), tokencipher.New("secret"))
+}
+
+// login goes through authorization code flow against stand-in and returns session cookie value
+func login(t *testing.T, uc *UseCase) string {
+	redirect, err := uc.Begin()
+	require.NoError(t, err)
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(redirect.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, redirect.State, callback.Query().Get("state"))
+
+	session, err := uc.Login(context.Background(), callback.Query().Get("code"))
+	require.NoError(t, err)
+	require.NotEmpty(t, session.Token)
+
+	return session.Token
+}
+
+func TestLoginStartsSession(t *testing.T) {
+	stand := osuoauthtest.NewServer(&osuapi.User{ID: 7, Username: "mapper"})
+	defer stand.Close()
+	store := newFakeSessionStore()
+	uc := newTestUseCase(stand, store)
+	ctx := context.Background()
+
+	token := login(t, uc)
+
+	require.Contains(t, store.accounts, 7)
+	account := store.accounts[7]
+	assert.Equal(t, "mapper", account.Username)
+	assert.NotEmpty(t, account.RefreshTokenEncrypted)
+	for hash := range store.sessions {
+		assert.NotEqual(t, token, hash, "only hash of session token is stored")
+	}
+
+	session, err := uc.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, 7, session.UserID)
+	assert.Equal(t, "mapper", session.Username)
+	assert.Empty(t, session.Token)
+
+	_, err = uc.Authenticate(ctx, "forged")
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+
+	store.sessions[hashToken(token)].ExpiresAt = time.Now().UTC().Add(-time.Second)
+	_, err = uc.Authenticate(ctx, token)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+
+	second := login(t, uc)
+	assert.Len(t, store.sessions, 1, "expired session is dropped on login")
+
+	require.NoError(t, uc.Logout(ctx, second))
+	_, err = uc.Authenticate(ctx, second)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+}
+
+func TestAccessTokenIsRefreshedWhenExpiring(t *testing.T) {
+	stand := osuoauthtest.NewServer(&osuapi.User{ID: 7, Username: "mapper"})
+	defer stand.Close()
+	store := newFakeSessionStore()
+	uc := newTestUseCase(stand, store)
+	ctx := context.Background()
+
+	login(t, uc)
+
+	accessToken, err := uc.AccessToken(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stand.Refreshes())
+
+	sealedRefreshToken := store.accounts[7].RefreshTokenEncrypted
+	store.accounts[7].TokenExpiresAt = time.Now().UTC().Add(refreshLeeway / 2)
+
+	refreshed, err := uc.AccessToken(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stand.Refreshes())
+	assert.NotEqual(t, accessToken, refreshed)
+	assert.NotEqual(t, sealedRefreshToken, store.accounts[7].RefreshTokenEncrypted)
+	assert.True(t, store.accounts[7].TokenExpiresAt.After(time.Now().UTC().Add(refreshLeeway)))
+
+	me, err := uc.oauth.GetMe(ctx, refreshed)
+	require.NoError(t, err)
+	assert.Equal(t, 7, me.ID)
+
+	again, err := uc.AccessToken(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, refreshed, again)
+	assert.Equal(t, 1, stand.Refreshes())
+
+	stand.RevokeAll()
+	store.accounts[7].TokenExpiresAt = time.Now().UTC()
+	_, err = uc.AccessToken(ctx, 7)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+
+	_, err = uc.AccessToken(ctx, 8)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnauthenticated))
+}
+
+func TestLoginDisabledWithoutEncryptionKey(t *testing.T) {
+	stand := osuoauthtest.NewServer(&osuapi.User{ID: 7, Username: "mapper"})
+	defer stand.Close()
+	uc := newTestUseCase(stand, newFakeSessionStore())
+	uc.cipher = nil
+
+	_, err := uc.Begin()
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnavailable))
+
+	_, err = uc.Login(context.Background(), "code")
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnavailable))
+}
@@ -0,0 +1,65 @@
+package statisticprovide
+
+import (
+	"playcount-monitor-backend/internal/database/repository/model"
+	"sort"
+)
+
+const collaboratorsMaxElements = 10
+
+// Collaborator is a user who mapped guest difficulties for the user or hosted user's guest difficulties
+type Collaborator struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	// DifficultiesForUser is the number of difficulties collaborator mapped on user's mapsets
+	DifficultiesForUser int `json:"difficulties_for_user"`
+	// DifficultiesByUser is the number of difficulties user mapped on collaborator's mapsets
+	DifficultiesByUser int `json:"difficulties_by_user"`
+}
+
+// collectCollaborators merges both directions of guest difficulties into per user counts,
+// most active collaborators come first
+func collectCollaborators(guestedForUser, guestedByUser []*model.GuestDifficulty) []*Collaborator {
+	byID := make(map[int]*Collaborator)
+	get := func(id int, username string) *Collaborator {
+		c, ok := byID[id]
+		if !ok {
+			c = &Collaborator{UserID: id}
+			byID[id] = c
+		}
+		if c.Username == "" {
+			c.Username = username
+		}
+
+		return c
+	}
+
+	for _, gd := range guestedForUser {
+		get(gd.MapperID, gd.MapperUsername).DifficultiesForUser++
+	}
+
+	for _, gd := range guestedByUser {
+		get(gd.HostID, gd.HostUsername).DifficultiesByUser++
+	}
+
+	res := make([]*Collaborator, 0, len(byID))
+	for _, c := range byID {
+		res = append(res, c)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		ti := res[i].DifficultiesForUser + res[i].DifficultiesByUser
+		tj := res[j].DifficultiesForUser + res[j].DifficultiesByUser
+		if ti != tj {
+			return ti > tj
+		}
+
+		return res[i].UserID < res[j].UserID
+	})
+
+	if len(res) > collaboratorsMaxElements {
+		res = res[:collaboratorsMaxElements]
+	}
+
+	return res
+}
@@ -0,0 +1,26 @@
+package statisticprovide
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+)
+
+func Test_collectCollaborators(t *testing.T) {
+	guestedForUser := []*model.GuestDifficulty{
+		{BeatmapID: 1, MapsetID: 1, HostID: 1, HostUsername: "host", MapperID: 2, MapperUsername: "gder"},
+		{BeatmapID: 2, MapsetID: 1, HostID: 1, HostUsername: "host", MapperID: 3},
+		{BeatmapID: 3, MapsetID: 2, HostID: 1, HostUsername: "host", MapperID: 2, MapperUsername: "gder"},
+	}
+	guestedByUser := []*model.GuestDifficulty{
+		{BeatmapID: 4, MapsetID: 3, HostID: 3, HostUsername: "other host", MapperID: 1, MapperUsername: "host"},
+	}
+
+	expected := []*Collaborator{
+		{UserID: 2, Username: "gder", DifficultiesForUser: 2},
+		{UserID: 3, Username: "other host", DifficultiesForUser: 1, DifficultiesByUser: 1},
+	}
+
+	assert.Equal(t, expected, collectCollaborators(guestedForUser, guestedByUser))
+	assert.Empty(t, collectCollaborators(nil, nil))
+}
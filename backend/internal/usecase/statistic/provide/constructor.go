@@ -6,15 +6,19 @@ import (
 	"playcount-monitor-backend/internal/config"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/cache"
 )
 
 type beatmapStore interface {
 	ListForMapset(ctx context.Context, tx txmanager.Tx, mapsetId int) ([]*model.Beatmap, error)
 	ListForMapsets(ctx context.Context, tx txmanager.Tx, mapsetIDs ...int) ([]*model.Beatmap, error)
+	ListGuestDifficultiesByMapper(ctx context.Context, tx txmanager.Tx, mapperID int) ([]*model.GuestDifficulty, error)
+	ListGuestDifficultiesForHost(ctx context.Context, tx txmanager.Tx, hostID int) ([]*model.GuestDifficulty, error)
 }
 
 type mapsetStore interface {
 	ListForUser(ctx context.Context, tx txmanager.Tx, userId int) ([]*model.Mapset, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error)
 }
 
 type UseCase struct {
@@ -23,6 +27,8 @@ type UseCase struct {
 	txm     txmanager.TxManager
 	beatmap beatmapStore
 	mapset  mapsetStore
+	// cache keeps results until the next tracking run, nil disables it
+	cache cache.Interface
 }
 
 func New(
@@ -31,6 +37,7 @@ func New(
 	txm txmanager.TxManager,
 	beatmap beatmapStore,
 	mapset mapsetStore,
+	cache cache.Interface,
 ) *UseCase {
 	return &UseCase{
 		cfg:     cfg,
@@ -38,5 +45,6 @@ func New(
 		txm:     txm,
 		beatmap: beatmap,
 		mapset:  mapset,
+		cache:   cache,
 	}
 }
@@ -3,7 +3,9 @@ package statisticprovide
 import (
 	"context"
 	"math"
+	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/cache"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,17 +17,27 @@ type UserMapStatistics struct {
 	Genres    map[string]int `json:"most_popular_genres"`
 	BPMs      map[string]int `json:"most_popular_bpms"`
 	Starrates map[string]int `json:"most_popular_starrates"`
+
+	Collaborators []*Collaborator `json:"collaborators"`
+}
+
+func (uc *UseCase) GetForUser(ctx context.Context, userID int, includeGuest bool) (*UserMapStatistics, error) {
+	return cache.Fetch(uc.cache, cache.Key("statistic.GetForUser", userID, includeGuest), func() (*UserMapStatistics, error) {
+		return uc.getForUser(ctx, userID, includeGuest)
+	})
 }
 
-func (uc *UseCase) GetForUser(
+func (uc *UseCase) getForUser(
 	ctx context.Context,
 	userID int,
+	includeGuest bool,
 ) (*UserMapStatistics, error) {
 	tags := make(map[string]int)
 	languages := make(map[string]int)
 	genres := make(map[string]int)
 	BPMs := make(map[string]int)
 	starrates := make(map[string]int)
+	var collaborators []*Collaborator
 
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		mapsets, err := uc.mapset.ListForUser(ctx, tx, userID)
@@ -33,10 +45,27 @@ func (uc *UseCase) GetForUser(
 			return err
 		}
 
-		mapsetIDs := make([]int, len(mapsets))
+		guestedByUser, err := uc.beatmap.ListGuestDifficultiesByMapper(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		guestedForUser, err := uc.beatmap.ListGuestDifficultiesForHost(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		collaborators = collectCollaborators(guestedForUser, guestedByUser)
+
+		mapsetIDs := make([]int, 0, len(mapsets))
+
+		for _, mapset := range mapsets {
+			// deleted and transferred mapsets are no longer part of user's portfolio
+			if mapset.RemovalStatus != string(model.MapsetActive) {
+				continue
+			}
 
-		for i, mapset := range mapsets {
-			mapsetIDs[i] = mapset.ID
+			mapsetIDs = append(mapsetIDs, mapset.ID)
 			tagsArr := strings.Fields(mapset.Tags)
 			for _, tag := range tagsArr {
 				tags[tag]++
@@ -49,11 +78,36 @@ func (uc *UseCase) GetForUser(
 			BPMs[bpmStr]++
 		}
 
+		// mapset level stats of other hosts' mapsets are theirs, guest mapsets count only for user's difficulties
+		if includeGuest && len(guestedByUser) > 0 {
+			guestMapsetIDs := make([]int, 0, len(guestedByUser))
+			for _, gd := range guestedByUser {
+				guestMapsetIDs = append(guestMapsetIDs, gd.MapsetID)
+			}
+
+			guestMapsets, err := uc.mapset.ListByIDs(ctx, tx, guestMapsetIDs...)
+			if err != nil {
+				return err
+			}
+
+			for _, mapset := range guestMapsets {
+				if mapset.RemovalStatus == string(model.MapsetActive) {
+					mapsetIDs = append(mapsetIDs, mapset.ID)
+				}
+			}
+		}
+
 		beatmaps, err := uc.beatmap.ListForMapsets(ctx, tx, mapsetIDs...)
 		if err != nil {
 			return err
 		}
 		for _, beatmap := range beatmaps {
+			// on own mapsets guest difficulties of others count only when guests are included,
+			// on other hosts' mapsets only difficulties of the user count
+			if beatmap.UserID != userID && (!includeGuest || !isHostedBy(mapsets, beatmap.MapsetID, userID)) {
+				continue
+			}
+
 			starrateStr := strconv.Itoa(roundUpToNearestNum(int(beatmap.DifficultyRating)))
 			starrates[starrateStr]++
 		}
@@ -72,14 +126,25 @@ func (uc *UseCase) GetForUser(
 	starrates = top5Values(starrates)
 
 	return &UserMapStatistics{
-		Tags:      tags,
-		Languages: languages,
-		Genres:    genres,
-		BPMs:      BPMs,
-		Starrates: starrates,
+		Tags:          tags,
+		Languages:     languages,
+		Genres:        genres,
+		BPMs:          BPMs,
+		Starrates:     starrates,
+		Collaborators: collaborators,
 	}, nil
 }
 
+func isHostedBy(mapsets []*model.Mapset, mapsetID int, userID int) bool {
+	for _, mapset := range mapsets {
+		if mapset.ID == mapsetID {
+			return mapset.UserID == userID
+		}
+	}
+
+	return false
+}
+
 func roundUpToNearestTen(num int) int {
 	rounded := int(math.Ceil(float64(num) / 10.0))
 	result := rounded * 10
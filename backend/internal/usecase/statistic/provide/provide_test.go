@@ -0,0 +1,91 @@
+package statisticprovide
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"testing"
+)
+
+type fakeMapsetStore struct {
+	own   []*model.Mapset
+	guest []*model.Mapset
+}
+
+func (s *fakeMapsetStore) ListForUser(context.Context, txmanager.Tx, int) ([]*model.Mapset, error) {
+	return s.own, nil
+}
+
+func (s *fakeMapsetStore) ListByIDs(context.Context, txmanager.Tx, ...int) ([]*model.Mapset, error) {
+	return s.guest, nil
+}
+
+type fakeBeatmapStore struct {
+	beatmapStore
+
+	beatmaps []*model.Beatmap
+	guested  []*model.GuestDifficulty
+}
+
+func (s *fakeBeatmapStore) ListForMapsets(_ context.Context, _ txmanager.Tx, mapsetIDs ...int) ([]*model.Beatmap, error) {
+	var res []*model.Beatmap
+	for _, beatmap := range s.beatmaps {
+		for _, id := range mapsetIDs {
+			if beatmap.MapsetID == id {
+				res = append(res, beatmap)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (s *fakeBeatmapStore) ListGuestDifficultiesByMapper(context.Context, txmanager.Tx, int) ([]*model.GuestDifficulty, error) {
+	return s.guested, nil
+}
+
+func (s *fakeBeatmapStore) ListGuestDifficultiesForHost(context.Context, txmanager.Tx, int) ([]*model.GuestDifficulty, error) {
+	return nil, nil
+}
+
+func TestUseCase_GetForUser_IncludeGuest(t *testing.T) {
+	const userID = 1
+
+	mapsets := &fakeMapsetStore{
+		own: []*model.Mapset{
+			{ID: 1, UserID: userID, Tags: "own", Language: "English", Genre: "Rock", BPM: 180, RemovalStatus: string(model.MapsetActive)},
+		},
+		guest: []*model.Mapset{
+			{ID: 2, UserID: 2, Tags: "other", Language: "Japanese", Genre: "Anime", BPM: 200, RemovalStatus: string(model.MapsetActive)},
+		},
+	}
+	beatmaps := &fakeBeatmapStore{
+		beatmaps: []*model.Beatmap{
+			{ID: 10, MapsetID: 1, UserID: userID, DifficultyRating: 4.2},
+			{ID: 11, MapsetID: 1, UserID: 3, DifficultyRating: 5.5},
+			{ID: 20, MapsetID: 2, UserID: userID, DifficultyRating: 6.1},
+			{ID: 21, MapsetID: 2, UserID: 2, DifficultyRating: 3.3},
+		},
+		guested: []*model.GuestDifficulty{{BeatmapID: 20, MapsetID: 2, HostID: 2, MapperID: userID}},
+	}
+	uc := New(nil, nil, txmanagertest.TxManager{}, beatmaps, mapsets, nil)
+
+	stats, err := uc.GetForUser(context.Background(), userID, true)
+	require.NoError(t, err)
+
+	// other host's mapset doesn't add its tags, language, genre or bpm
+	assert.Equal(t, map[string]int{"own": 1}, stats.Tags)
+	assert.Equal(t, map[string]int{"English": 1}, stats.Languages)
+	assert.Equal(t, map[string]int{"Rock": 1}, stats.Genres)
+	assert.Equal(t, map[string]int{"180": 1}, stats.BPMs)
+	// own mapset counts every difficulty, guest mapset only user's one
+	assert.Equal(t, map[string]int{"4": 1, "5": 1, "6": 1}, stats.Starrates)
+
+	stats, err = uc.GetForUser(context.Background(), userID, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"4": 1}, stats.Starrates)
+}
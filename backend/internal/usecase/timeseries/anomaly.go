@@ -0,0 +1,112 @@
+package timeseries
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type AnomalyKind string
+
+const (
+	// Spike is a delta far above recent baseline, e.g. mapset got into tournament pool or was played on stream
+	Spike AnomalyKind = "spike"
+	// Drop is a delta far below recent baseline
+	Drop AnomalyKind = "drop"
+	// Reset is a counter that went down, which never happens naturally
+	Reset AnomalyKind = "reset"
+)
+
+// madScale turns median absolute deviation into standard deviation estimate for normally distributed data
+const madScale = 1.4826
+
+type Anomaly struct {
+	Kind  AnomalyKind
+	Time  time.Time
+	Value float64
+	Delta float64
+	// Baseline is median of recent deltas the latest one is compared to
+	Baseline float64
+	// Score is the number of robust standard deviations delta is away from baseline
+	Score        float64
+	RecentDeltas []float64
+}
+
+// DetectAnomaly compares the latest daily delta with median of up to baselineWindow previous deltas.
+// Delta is flagged when it is more than threshold robust deviations and more than minDelta away from baseline,
+// nil is returned when latest delta looks usual or there is not enough history
+func DetectAnomaly(series Series, baselineWindow int, threshold float64, minDelta float64) *Anomaly {
+	buckets := Aggregate(series, Day, time.Time{}, time.Time{})
+	if len(buckets) < 2 {
+		return nil
+	}
+
+	latest := buckets[len(buckets)-1]
+
+	// first bucket has no delta
+	start := max(1, len(buckets)-1-baselineWindow)
+	recent := make([]float64, 0, baselineWindow)
+	for _, b := range buckets[start : len(buckets)-1] {
+		recent = append(recent, b.Delta)
+	}
+
+	if latest.Delta < 0 {
+		return &Anomaly{
+			Kind:         Reset,
+			Time:         latest.Time,
+			Value:        latest.Value,
+			Delta:        latest.Delta,
+			Baseline:     median(recent),
+			RecentDeltas: recent,
+		}
+	}
+
+	// a few points make median meaningless
+	if len(recent) < 3 {
+		return nil
+	}
+
+	baseline := median(recent)
+	deviations := make([]float64, len(recent))
+	for i, d := range recent {
+		deviations[i] = math.Abs(d - baseline)
+	}
+	scale := math.Max(madScale*median(deviations), 1)
+
+	diff := latest.Delta - baseline
+	score := math.Abs(diff) / scale
+	if score < threshold || math.Abs(diff) < minDelta {
+		return nil
+	}
+
+	kind := Spike
+	if diff < 0 {
+		kind = Drop
+	}
+
+	return &Anomaly{
+		Kind:         kind,
+		Time:         latest.Time,
+		Value:        latest.Value,
+		Delta:        latest.Delta,
+		Baseline:     baseline,
+		Score:        score,
+		RecentDeltas: recent,
+	}
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
@@ -0,0 +1,62 @@
+package timeseries
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func seriesFromDailyValues(values ...float64) Series {
+	series := make(Series, len(values))
+	for i, v := range values {
+		series[i] = Point{Time: date(i+1, 12), Value: v}
+	}
+
+	return series
+}
+
+func Test_DetectAnomaly(t *testing.T) {
+	t.Run("usual growth", func(t *testing.T) {
+		series := seriesFromDailyValues(100, 200, 310, 400, 505, 600, 700)
+		assert.Nil(t, DetectAnomaly(series, 7, 5, 50))
+	})
+
+	t.Run("spike", func(t *testing.T) {
+		series := seriesFromDailyValues(100, 200, 310, 400, 505, 600, 2600)
+
+		anomaly := DetectAnomaly(series, 7, 5, 50)
+		require.NotNil(t, anomaly)
+		assert.Equal(t, Spike, anomaly.Kind)
+		assert.Equal(t, 2000.0, anomaly.Delta)
+		assert.Equal(t, 100.0, anomaly.Baseline)
+		assert.Equal(t, []float64{100, 110, 90, 105, 95}, anomaly.RecentDeltas)
+		assert.Greater(t, anomaly.Score, 5.0)
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		series := seriesFromDailyValues(0, 1000, 2000, 3010, 4000, 5000, 5000)
+
+		anomaly := DetectAnomaly(series, 7, 5, 50)
+		require.NotNil(t, anomaly)
+		assert.Equal(t, Drop, anomaly.Kind)
+	})
+
+	t.Run("small absolute change is ignored", func(t *testing.T) {
+		series := seriesFromDailyValues(10, 11, 12, 13, 14, 30)
+		assert.Nil(t, DetectAnomaly(series, 7, 5, 50))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		series := seriesFromDailyValues(1000, 1100, 50)
+
+		anomaly := DetectAnomaly(series, 7, 5, 50)
+		require.NotNil(t, anomaly)
+		assert.Equal(t, Reset, anomaly.Kind)
+		assert.Equal(t, -1050.0, anomaly.Delta)
+	})
+
+	t.Run("not enough history", func(t *testing.T) {
+		assert.Nil(t, DetectAnomaly(seriesFromDailyValues(100, 5000), 7, 5, 50))
+		assert.Nil(t, DetectAnomaly(nil, 7, 5, 50))
+	})
+}
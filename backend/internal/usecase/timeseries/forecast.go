@@ -0,0 +1,192 @@
+package timeseries
+
+import (
+	"math"
+	"time"
+)
+
+type FitModel string
+
+const (
+	Linear      FitModel = "linear"
+	Exponential FitModel = "exponential"
+)
+
+// z value of 95% confidence interval
+const confidenceZ = 1.96
+
+// milestones further than that are not estimated, the trend will surely change before
+const maxMilestoneETA = 10 * 365 * 24 * time.Hour
+
+// DefaultHorizons are forecast horizons in days
+var DefaultHorizons = []int{7, 30, 90}
+
+type Projection struct {
+	Days  int       `json:"days"`
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	// Lower and Upper bound 95% prediction interval
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+type Milestone struct {
+	Value float64   `json:"value"`
+	ETA   time.Time `json:"eta"`
+}
+
+type Forecast struct {
+	Model       FitModel      `json:"model"`
+	Projections []*Projection `json:"projections"`
+	// NextMilestone is the next round number counter is expected to reach, nil when it is not growing
+	NextMilestone *Milestone `json:"next_milestone"`
+}
+
+// fit is least squares line y = a + b*x over x in days since origin,
+// for exponential model y is log of value
+type fit struct {
+	model  FitModel
+	origin time.Time
+	a, b   float64
+	// residual standard error and stats needed for prediction interval
+	se    float64
+	n     int
+	meanX float64
+	sxx   float64
+	// sse is sum of squared errors in original value space, used to pick the better model
+	sse float64
+}
+
+// Project fits linear and exponential trend to counter history and projects it for each horizon.
+// Exponential trend is used only when it fits the history better, nil is returned for less than 3 points
+func Project(series Series, horizons []int) *Forecast {
+	if len(series) < 3 {
+		return nil
+	}
+
+	best := fitLine(series, Linear)
+	if best == nil {
+		return nil
+	}
+	if exp := fitLine(series, Exponential); exp != nil && exp.sse < best.sse {
+		best = exp
+	}
+
+	latest := series[len(series)-1]
+	forecast := &Forecast{Model: best.model}
+	for _, days := range horizons {
+		t := latest.Time.Add(time.Duration(days) * 24 * time.Hour)
+		value, lower, upper := best.predict(t)
+
+		// counters never go down
+		forecast.Projections = append(forecast.Projections, &Projection{
+			Days:  days,
+			Time:  t,
+			Value: math.Max(value, latest.Value),
+			Lower: math.Max(lower, latest.Value),
+			Upper: math.Max(upper, latest.Value),
+		})
+	}
+
+	milestone := NextMilestone(latest.Value)
+	if eta, ok := best.reachedAt(milestone); ok && eta.Sub(latest.Time) <= maxMilestoneETA {
+		if eta.Before(latest.Time) {
+			eta = latest.Time
+		}
+		forecast.NextMilestone = &Milestone{Value: milestone, ETA: eta}
+	}
+
+	return forecast
+}
+
+// NextMilestone returns the next value out of 1, 2.5, 5 times power of ten ladder above v, e.g. 1M after 750k
+func NextMilestone(v float64) float64 {
+	for power := 1.0; ; power *= 10 {
+		for _, step := range []float64{1, 2.5, 5} {
+			if m := step * power; m > v {
+				return m
+			}
+		}
+	}
+}
+
+func fitLine(series Series, model FitModel) *fit {
+	origin := series[0].Time
+	n := len(series)
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, p := range series {
+		xs[i] = p.Time.Sub(origin).Hours() / 24
+		ys[i] = p.Value
+		if model == Exponential {
+			if p.Value <= 0 {
+				return nil
+			}
+			ys[i] = math.Log(p.Value)
+		}
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var sxx, sxy float64
+	for i := range xs {
+		sxx += (xs[i] - meanX) * (xs[i] - meanX)
+		sxy += (xs[i] - meanX) * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return nil
+	}
+
+	f := &fit{model: model, origin: origin, n: n, meanX: meanX, sxx: sxx}
+	f.b = sxy / sxx
+	f.a = meanY - f.b*meanX
+
+	var residuals float64
+	for i := range xs {
+		r := ys[i] - (f.a + f.b*xs[i])
+		residuals += r * r
+
+		value, _, _ := f.predict(series[i].Time)
+		f.sse += (series[i].Value - value) * (series[i].Value - value)
+	}
+	f.se = math.Sqrt(residuals / float64(n-2))
+
+	return f
+}
+
+func (f *fit) predict(t time.Time) (value, lower, upper float64) {
+	x := t.Sub(f.origin).Hours() / 24
+	y := f.a + f.b*x
+	margin := confidenceZ * f.se * math.Sqrt(1+1/float64(f.n)+(x-f.meanX)*(x-f.meanX)/f.sxx)
+
+	if f.model == Exponential {
+		return math.Exp(y), math.Exp(y - margin), math.Exp(y + margin)
+	}
+
+	return y, y - margin, y + margin
+}
+
+func (f *fit) reachedAt(value float64) (time.Time, bool) {
+	if f.b <= 0 {
+		return time.Time{}, false
+	}
+
+	y := value
+	if f.model == Exponential {
+		y = math.Log(value)
+	}
+
+	// far away values are cut off early so they don't overflow time.Duration
+	days := (y - f.a) / f.b
+	if math.IsInf(days, 0) || math.IsNaN(days) || days > 2*maxMilestoneETA.Hours()/24 {
+		return time.Time{}, false
+	}
+
+	return f.origin.Add(time.Duration(days * 24 * float64(time.Hour))), true
+}
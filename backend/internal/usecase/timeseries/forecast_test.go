@@ -0,0 +1,65 @@
+package timeseries
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_NextMilestone(t *testing.T) {
+	assert.Equal(t, 1.0, NextMilestone(0))
+	assert.Equal(t, 1000.0, NextMilestone(999))
+	assert.Equal(t, 2500.0, NextMilestone(1000))
+	assert.Equal(t, 1e6, NextMilestone(750_000))
+}
+
+func Test_Project(t *testing.T) {
+	t.Run("linear growth", func(t *testing.T) {
+		// 1000 plays a day with a bit of noise
+		series := seriesFromDailyValues(10_000, 11_010, 11_990, 13_000, 14_020, 14_990, 16_000)
+
+		forecast := Project(series, DefaultHorizons)
+		require.NotNil(t, forecast)
+		assert.Equal(t, Linear, forecast.Model)
+		require.Len(t, forecast.Projections, 3)
+
+		week := forecast.Projections[0]
+		assert.Equal(t, 7, week.Days)
+		assert.Equal(t, date(14, 12), week.Time)
+		assert.InDelta(t, 23_000, week.Value, 100)
+		assert.Less(t, week.Lower, week.Value)
+		assert.Greater(t, week.Upper, week.Value)
+
+		// interval widens further away
+		quarter := forecast.Projections[2]
+		assert.Greater(t, quarter.Upper-quarter.Lower, week.Upper-week.Lower)
+
+		require.NotNil(t, forecast.NextMilestone)
+		assert.Equal(t, 25_000.0, forecast.NextMilestone.Value)
+		assert.WithinDuration(t, date(16, 12), forecast.NextMilestone.ETA, 6*time.Hour)
+	})
+
+	t.Run("exponential growth", func(t *testing.T) {
+		series := seriesFromDailyValues(100, 200, 400, 800, 1600, 3200)
+
+		forecast := Project(series, []int{1})
+		require.NotNil(t, forecast)
+		assert.Equal(t, Exponential, forecast.Model)
+		assert.InDelta(t, 6400, forecast.Projections[0].Value, 1)
+	})
+
+	t.Run("flat history has no milestone and never goes down", func(t *testing.T) {
+		series := seriesFromDailyValues(500, 500, 500, 500)
+
+		forecast := Project(series, []int{30})
+		require.NotNil(t, forecast)
+		assert.Nil(t, forecast.NextMilestone)
+		assert.Equal(t, 500.0, forecast.Projections[0].Value)
+		assert.Equal(t, 500.0, forecast.Projections[0].Lower)
+	})
+
+	t.Run("not enough history", func(t *testing.T) {
+		assert.Nil(t, Project(seriesFromDailyValues(1, 2), DefaultHorizons))
+	})
+}
@@ -0,0 +1,136 @@
+// Package timeseries holds pure math over stats history (cumulative counters sampled by tracker)
+package timeseries
+
+import (
+	"sort"
+	"time"
+)
+
+type Granularity string
+
+const (
+	Day   Granularity = "day"
+	Week  Granularity = "week"
+	Month Granularity = "month"
+)
+
+func (g Granularity) IsValid() bool {
+	return g == Day || g == Week || g == Month
+}
+
+// Point is a single sample of cumulative counter
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a list of points sorted by time
+type Series []Point
+
+// Bucket is a counter value at the end of a period with period's growth
+type Bucket struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	// Delta is growth since previous period, 0 for the first known period
+	Delta float64 `json:"delta"`
+	// GrowthRate is Delta relative to previous period value, nil when there is nothing to compare with
+	GrowthRate *float64 `json:"growth_rate"`
+	// MovingAverage is mean Delta over last MovingAverageWindow periods
+	MovingAverage float64 `json:"moving_average"`
+}
+
+// FromMap builds series out of stats history, value picks metric out of stats model
+func FromMap[T any](stats map[time.Time]T, value func(T) float64) Series {
+	series := make(Series, 0, len(stats))
+	for t, s := range stats {
+		series = append(series, Point{Time: t, Value: value(s)})
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Time.Before(series[j].Time)
+	})
+
+	return series
+}
+
+// Truncate returns start of the period t belongs to, weeks start on monday
+func Truncate(t time.Time, g Granularity) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch g {
+	case Week:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// MovingAverageWindow is the number of periods moving average is calculated over
+func MovingAverageWindow(g Granularity) int {
+	switch g {
+	case Week:
+		return 4
+	case Month:
+		return 3
+	default:
+		return 7
+	}
+}
+
+// Aggregate groups series into periods keeping the last value of each period and calculates growth between them.
+// Deltas are calculated over whole series so the first period inside [from, to] window has its delta too,
+// zero from or to leaves that side of window open
+func Aggregate(series Series, g Granularity, from, to time.Time) []*Bucket {
+	var buckets []*Bucket
+	for _, point := range series {
+		periodStart := Truncate(point.Time, g)
+		if len(buckets) > 0 && buckets[len(buckets)-1].Time.Equal(periodStart) {
+			buckets[len(buckets)-1].Value = point.Value
+			continue
+		}
+
+		buckets = append(buckets, &Bucket{Time: periodStart, Value: point.Value})
+	}
+
+	window := MovingAverageWindow(g)
+	for i, bucket := range buckets {
+		if i > 0 {
+			prev := buckets[i-1].Value
+			bucket.Delta = bucket.Value - prev
+			if prev != 0 {
+				rate := bucket.Delta / prev
+				bucket.GrowthRate = &rate
+			}
+		}
+
+		start := i - window + 1
+		if start < 1 {
+			// first bucket has no delta, leave it out of average unless it is the only one
+			start = min(1, i)
+		}
+
+		var sum float64
+		for _, b := range buckets[start : i+1] {
+			sum += b.Delta
+		}
+		bucket.MovingAverage = sum / float64(i+1-start)
+	}
+
+	res := make([]*Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		if !from.IsZero() && bucket.Time.Before(Truncate(from, g)) {
+			continue
+		}
+		if !to.IsZero() && bucket.Time.After(to) {
+			continue
+		}
+
+		res = append(res, bucket)
+	}
+
+	return res
+}
@@ -0,0 +1,84 @@
+package timeseries
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func date(day int, hour int) time.Time {
+	return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+}
+
+func Test_Truncate(t *testing.T) {
+	// 2024-01-10 is wednesday
+	ts := time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, date(10, 0), Truncate(ts, Day))
+	assert.Equal(t, date(8, 0), Truncate(ts, Week))
+	assert.Equal(t, date(1, 0), Truncate(ts, Month))
+	assert.Equal(t, date(8, 0), Truncate(date(8, 0), Week))
+	assert.Equal(t, date(8, 0), Truncate(date(14, 23), Week))
+}
+
+func Test_FromMap(t *testing.T) {
+	stats := map[time.Time]int{
+		date(3, 0): 30,
+		date(1, 0): 10,
+		date(2, 0): 20,
+	}
+
+	series := FromMap(stats, func(v int) float64 { return float64(v) })
+	assert.Equal(t, Series{{date(1, 0), 10}, {date(2, 0), 20}, {date(3, 0), 30}}, series)
+}
+
+func Test_Aggregate(t *testing.T) {
+	series := Series{
+		{date(1, 6), 100},
+		{date(1, 18), 110}, // same day, last value wins
+		{date(2, 12), 130},
+		{date(3, 12), 130},
+		{date(4, 12), 190},
+	}
+
+	t.Run("daily", func(t *testing.T) {
+		buckets := Aggregate(series, Day, time.Time{}, time.Time{})
+		require.Len(t, buckets, 4)
+
+		assert.Equal(t, date(1, 0), buckets[0].Time)
+		assert.Equal(t, 110.0, buckets[0].Value)
+		assert.Equal(t, 0.0, buckets[0].Delta)
+		assert.Nil(t, buckets[0].GrowthRate)
+
+		assert.Equal(t, 20.0, buckets[1].Delta)
+		require.NotNil(t, buckets[1].GrowthRate)
+		assert.InDelta(t, 20.0/110.0, *buckets[1].GrowthRate, 1e-9)
+		assert.Equal(t, 20.0, buckets[1].MovingAverage)
+
+		assert.Equal(t, 0.0, buckets[2].Delta)
+		assert.Equal(t, 10.0, buckets[2].MovingAverage)
+
+		assert.Equal(t, 60.0, buckets[3].Delta)
+		assert.Equal(t, 80.0/3, buckets[3].MovingAverage)
+	})
+
+	t.Run("window keeps deltas calculated before it", func(t *testing.T) {
+		buckets := Aggregate(series, Day, date(3, 0), date(3, 23))
+		require.Len(t, buckets, 1)
+		assert.Equal(t, date(3, 0), buckets[0].Time)
+		assert.Equal(t, 0.0, buckets[0].Delta)
+		assert.Equal(t, 10.0, buckets[0].MovingAverage)
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		buckets := Aggregate(Series{{date(1, 0), 100}, {date(7, 0), 150}, {date(8, 0), 200}}, Week, time.Time{}, time.Time{})
+		require.Len(t, buckets, 2)
+		assert.Equal(t, 150.0, buckets[0].Value)
+		assert.Equal(t, 50.0, buckets[1].Delta)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Aggregate(nil, Month, time.Time{}, time.Time{}))
+	})
+}
@@ -0,0 +1,147 @@
+package track
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/timeseries"
+)
+
+const anomalyMetricPlaycount = "play_count"
+
+// detectAnomalies flags mapsets and beatmaps whose latest daily playcount delta is far off their recent baseline
+func (uc *UseCase) detectAnomalies(ctx context.Context, stats *runStats) error {
+	eventsBefore := len(stats.events)
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapsets, err := uc.mapset.List(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		var anomalies []*model.Anomaly
+		mapsetsByID := make(map[int]*model.Mapset, len(mapsets))
+		mapsetIDs := make([]int, 0, len(mapsets))
+		for _, mapset := range mapsets {
+			if mapset.RemovalStatus != string(model.MapsetActive) {
+				continue
+			}
+
+			mapsetsByID[mapset.ID] = mapset
+			mapsetIDs = append(mapsetIDs, mapset.ID)
+
+			mapsetStats, err := mappers.MapStatsJSONToMapsetStats(mapset.MapsetStats)
+			if err != nil {
+				return fmt.Errorf("failed to map stats of mapset %v: %w", mapset.ID, err)
+			}
+
+			series := timeseries.FromMap(mapsetStats, func(s *model.MapsetStatsModel) float64 { return float64(s.Playcount) })
+			anomaly, err := uc.newAnomaly(series, model.AnomalyMapset, mapset.ID, mapset, mapset.Artist+" - "+mapset.Title)
+			if err != nil {
+				return err
+			}
+			if anomaly != nil {
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+
+		if len(mapsetIDs) > 0 {
+			beatmaps, err := uc.beatmap.ListForMapsets(ctx, tx, mapsetIDs...)
+			if err != nil {
+				return err
+			}
+
+			for _, beatmap := range beatmaps {
+				beatmapStats, err := mappers.MapStatsJSONToBeatmapStats(beatmap.BeatmapStats)
+				if err != nil {
+					return fmt.Errorf("failed to map stats of beatmap %v: %w", beatmap.ID, err)
+				}
+
+				mapset := mapsetsByID[beatmap.MapsetID]
+				series := timeseries.FromMap(beatmapStats, func(s *model.BeatmapStatsModel) float64 { return float64(s.Playcount) })
+				title := fmt.Sprintf("%s - %s [%s]", mapset.Artist, mapset.Title, beatmap.Version)
+				anomaly, err := uc.newAnomaly(series, model.AnomalyBeatmap, beatmap.ID, mapset, title)
+				if err != nil {
+					return err
+				}
+				if anomaly != nil {
+					anomalies = append(anomalies, anomaly)
+				}
+			}
+		}
+
+		// anomalies flagged by previous runs are skipped, only new ones are counted and notified about
+		created, err := uc.anomaly.Create(ctx, tx, anomalies...)
+		if err != nil {
+			return err
+		}
+
+		stats.anomaliesDetected = len(created)
+
+		// drop events collected by failed attempt if transaction is retried
+		stats.events = stats.events[:eventsBefore]
+		for _, anomaly := range created {
+			if isSpike(anomaly) {
+				stats.events = append(stats.events, spikeEvent(anomaly, mapsetsByID[anomaly.MapsetID]))
+			}
+		}
+
+		return nil
+	})
+}
+
+func (uc *UseCase) newAnomaly(
+	series timeseries.Series,
+	entityType model.AnomalyEntityType,
+	entityID int,
+	mapset *model.Mapset,
+	title string,
+) (*model.Anomaly, error) {
+	detected := timeseries.DetectAnomaly(
+		series,
+		uc.cfg.AnomalyBaselineDays,
+		uc.cfg.AnomalyThreshold,
+		float64(uc.cfg.AnomalyMinDelta),
+	)
+	if detected == nil {
+		return nil, nil
+	}
+
+	anomalyContext, err := json.Marshal(&model.AnomalyContext{
+		RecentDeltas: detected.RecentDeltas,
+		Title:        title,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal anomaly context: %w", err)
+	}
+
+	return &model.Anomaly{
+		EntityType:  string(entityType),
+		EntityID:    entityID,
+		MapsetID:    mapset.ID,
+		UserID:      mapset.UserID,
+		Metric:      anomalyMetricPlaycount,
+		Kind:        string(detected.Kind),
+		Severity:    string(uc.anomalySeverity(detected)),
+		DetectedFor: detected.Time,
+		Value:       int(detected.Value),
+		Delta:       int(detected.Delta),
+		Baseline:    detected.Baseline,
+		Score:       detected.Score,
+		Context:     anomalyContext,
+	}, nil
+}
+
+// anomalySeverity grows with how far delta is from baseline, resets are always severe
+func (uc *UseCase) anomalySeverity(anomaly *timeseries.Anomaly) model.AnomalySeverity {
+	switch {
+	case anomaly.Kind == timeseries.Reset || anomaly.Score >= 4*uc.cfg.AnomalyThreshold:
+		return model.AnomalyHigh
+	case anomaly.Score >= 2*uc.cfg.AnomalyThreshold:
+		return model.AnomalyMedium
+	default:
+		return model.AnomalyLow
+	}
+}
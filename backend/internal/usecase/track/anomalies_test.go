@@ -0,0 +1,51 @@
+package track
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/notifier"
+	"testing"
+)
+
+func TestUseCase_detectAnomalies_NotifiesOnlyNewSpikes(t *testing.T) {
+	mapsets := &fakeMapsetStore{listed: []*model.Mapset{{
+		ID:            1,
+		UserID:        7,
+		Artist:        "artist",
+		Title:         "title",
+		RemovalStatus: string(model.MapsetActive),
+		MapsetStats: repository.JSON(`{
+			"2024-01-01T00:00:00Z":{"play_count":100},
+			"2024-01-02T00:00:00Z":{"play_count":110},
+			"2024-01-03T00:00:00Z":{"play_count":120},
+			"2024-01-04T00:00:00Z":{"play_count":130},
+			"2024-01-05T00:00:00Z":{"play_count":1000}
+		}`),
+	}}}
+	uc := &UseCase{
+		cfg:     &config.Config{AnomalyThreshold: 5, AnomalyMinDelta: 50, AnomalyBaselineDays: 7},
+		txm:     txmanagertest.TxManager{},
+		mapset:  mapsets,
+		beatmap: &fakeBeatmapStore{},
+		anomaly: &fakeAnomalyStore{stored: make(map[string]bool)},
+	}
+
+	stats := &runStats{}
+	require.NoError(t, uc.detectAnomalies(context.Background(), stats))
+
+	assert.Equal(t, 1, stats.anomaliesDetected)
+	require.Len(t, stats.events, 1)
+	assert.Equal(t, notifier.EventSpike, stats.events[0].Type)
+
+	// next run sees the same spike, it is already stored and must not be sent again
+	stats = &runStats{}
+	require.NoError(t, uc.detectAnomalies(context.Background(), stats))
+
+	assert.Zero(t, stats.anomaliesDetected)
+	assert.Empty(t, stats.events)
+}
@@ -5,6 +5,7 @@ import (
 	"playcount-monitor-backend/internal/config"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/notifier"
 	"playcount-monitor-backend/internal/service/osuapi"
 	"time"
 )
@@ -17,18 +18,19 @@ type userStore interface {
 }
 
 type mapsetStore interface {
-	Create(ctx context.Context, tx txmanager.Tx, mapset *model.Mapset) error
-	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Mapset, error)
-	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
-	Update(ctx context.Context, tx txmanager.Tx, mapset *model.Mapset) error
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.Mapset, error)
 	ListForUser(ctx context.Context, tx txmanager.Tx, userID int) ([]*model.Mapset, error)
+	ListByIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]*model.Mapset, error)
+	ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error)
+	Upsert(ctx context.Context, tx txmanager.Tx, mapsets ...*model.Mapset) error
+	MarkRemoved(ctx context.Context, tx txmanager.Tx, status model.MapsetRemovalStatus, removedAt time.Time, ids ...int) error
+	ReplaceTrending(ctx context.Context, tx txmanager.Tx, trending ...*model.TrendingMapset) error
 }
 
 type beatmapStore interface {
-	Create(ctx context.Context, tx txmanager.Tx, beatmap *model.Beatmap) error
-	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Beatmap, error)
-	Update(ctx context.Context, tx txmanager.Tx, beatmap *model.Beatmap) error
-	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+	ListForMapsets(ctx context.Context, tx txmanager.Tx, mapsetIDs ...int) ([]*model.Beatmap, error)
+	ListExistingIDs(ctx context.Context, tx txmanager.Tx, ids ...int) ([]int, error)
+	Upsert(ctx context.Context, tx txmanager.Tx, beatmaps ...*model.Beatmap) error
 }
 
 type followingStore interface {
@@ -36,21 +38,48 @@ type followingStore interface {
 	SetLastFetchedForUser(ctx context.Context, tx txmanager.Tx, username string, lastFetched time.Time) error
 }
 
+type watchlistStore interface {
+	ListWatchedIDs(ctx context.Context, tx txmanager.Tx, entityType model.WatchlistEntityType) ([]int, error)
+}
+
+type watchedMapsetStore interface {
+	ListIDs(ctx context.Context, tx txmanager.Tx) ([]int, error)
+	SetLastFetched(ctx context.Context, tx txmanager.Tx, id int, lastFetched time.Time) error
+}
+
 type trackStore interface {
 	Create(ctx context.Context, tx txmanager.Tx, track *model.Track) error
 	GetLastTrack(ctx context.Context, tx txmanager.Tx) (*model.Track, error)
 	List(ctx context.Context, tx txmanager.Tx) ([]*model.Track, error)
 }
 
+type anomalyStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, anomalies ...*model.Anomaly) ([]*model.Anomaly, error)
+}
+
+type milestoneStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, milestones ...*model.Milestone) ([]*model.Milestone, error)
+}
+
+type mapsetEventStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, events ...*model.MapsetEvent) error
+}
+
 type UseCase struct {
-	cfg       *config.Config
-	txm       txmanager.TxManager
-	osuApi    osuapi.Interface
-	user      userStore
-	mapset    mapsetStore
-	beatmap   beatmapStore
-	following followingStore
-	track     trackStore
+	cfg           *config.Config
+	txm           txmanager.TxManager
+	osuApi        osuapi.Interface
+	user          userStore
+	mapset        mapsetStore
+	beatmap       beatmapStore
+	following     followingStore
+	watchlist     watchlistStore
+	watchedMapset watchedMapsetStore
+	track         trackStore
+	anomaly       anomalyStore
+	milestone     milestoneStore
+	mapsetEvent   mapsetEventStore
+	notifier      notifier.Notifier
 }
 
 func New(
@@ -61,16 +90,28 @@ func New(
 	mapset mapsetStore,
 	beatmap beatmapStore,
 	following followingStore,
+	watchlist watchlistStore,
+	watchedMapset watchedMapsetStore,
 	track trackStore,
+	anomaly anomalyStore,
+	milestone milestoneStore,
+	mapsetEvent mapsetEventStore,
+	notifier notifier.Notifier,
 ) *UseCase {
 	return &UseCase{
-		cfg:       cfg,
-		txm:       txManager,
-		osuApi:    osuAPI,
-		user:      user,
-		mapset:    mapset,
-		beatmap:   beatmap,
-		following: following,
-		track:     track,
+		cfg:           cfg,
+		txm:           txManager,
+		osuApi:        osuAPI,
+		user:          user,
+		mapset:        mapset,
+		beatmap:       beatmap,
+		following:     following,
+		watchlist:     watchlist,
+		watchedMapset: watchedMapset,
+		track:         track,
+		anomaly:       anomaly,
+		milestone:     milestone,
+		mapsetEvent:   mapsetEvent,
+		notifier:      notifier,
 	}
 }
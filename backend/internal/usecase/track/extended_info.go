@@ -0,0 +1,105 @@
+package track
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"strconv"
+	"time"
+)
+
+// resolveExtendedInfo fills genre, language and comments count of api mapsets.
+// Genre and language are static, so they are reused from db while mapset last_updated is unchanged,
+// comments count is reused until it gets older than refresh interval for mapset status.
+func (uc *UseCase) resolveExtendedInfo(
+	ctx context.Context,
+	stats *runStats,
+	dbMapsets []*model.Mapset,
+	apiMapsets []*osuapi.MapsetExtended,
+) error {
+	for _, mapset := range apiMapsets {
+		dbMapset := getMapsetByID(dbMapsets, mapset.Id)
+
+		if extendedInfoIsCached(dbMapset, mapset) {
+			mapset.Genre = dbMapset.Genre
+			mapset.Language = dbMapset.Language
+			stats.extendedCached++
+		} else {
+			langGenreInfo, err := uc.osuApi.GetMapsetExtended(ctx, strconv.Itoa(mapset.Id))
+			if err != nil {
+				return fmt.Errorf("failed to get mapset extended info from api, mapset id: %v, err: %w", mapset.Id, err)
+			}
+
+			mapset.Genre = langGenreInfo.Genre.Name
+			mapset.Language = langGenreInfo.Language.Name
+			stats.extendedFetched++
+		}
+
+		if dbMapset != nil && time.Since(dbMapset.CommentsFetchedAt) < uc.commentsRefreshInterval(mapset.Status) {
+			comments, err := lastCommentsCount(dbMapset)
+			if err != nil {
+				return err
+			}
+
+			mapset.CommentsCount = comments
+			mapset.CommentsFetchedAt = dbMapset.CommentsFetchedAt
+			stats.commentsCached++
+		} else {
+			comments, err := uc.osuApi.GetMapsetCommentsCount(ctx, strconv.Itoa(mapset.Id))
+			if err != nil {
+				return fmt.Errorf("failed to get mapset comments count from api, mapset id: %v, err: %w", mapset.Id, err)
+			}
+
+			mapset.CommentsCount = comments
+			mapset.CommentsFetchedAt = time.Now().UTC()
+			stats.commentsFetched++
+		}
+	}
+
+	return nil
+}
+
+func extendedInfoIsCached(dbMapset *model.Mapset, apiMapset *osuapi.MapsetExtended) bool {
+	if dbMapset == nil {
+		return false
+	}
+
+	if dbMapset.Genre == "" || dbMapset.Language == "" {
+		return false
+	}
+
+	return dbMapset.LastUpdated.Equal(apiMapset.LastUpdated)
+}
+
+func (uc *UseCase) commentsRefreshInterval(status string) time.Duration {
+	switch model.MapsetStatus(status) {
+	case model.Graveyard:
+		return uc.cfg.CommentsRefreshGraveyard
+	case model.Ranked, model.Approved, model.Loved:
+		return uc.cfg.CommentsRefreshRanked
+	default:
+		// wip, pending, qualified maps get the most activity
+		return uc.cfg.CommentsRefreshPending
+	}
+}
+
+// lastCommentsCount returns comments count from the latest mapset stats entry
+func lastCommentsCount(mapset *model.Mapset) (int, error) {
+	stats, err := mappers.MapStatsJSONToMapsetStats(mapset.MapsetStats)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastTime time.Time
+	var comments int
+	for t, s := range stats {
+		if t.After(lastTime) {
+			lastTime = t
+			comments = s.Comments
+		}
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,141 @@
+package track
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"testing"
+	"time"
+)
+
+func Test_extendedInfoIsCached(t *testing.T) {
+	lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiMapset := &osuapi.MapsetExtended{Mapset: &osuapi.Mapset{Id: 1, LastUpdated: lastUpdated}}
+
+	tests := []struct {
+		name     string
+		dbMapset *model.Mapset
+		want     bool
+	}{
+		{name: "new mapset", dbMapset: nil, want: false},
+		{name: "unchanged", dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Genre: "Rock", Language: "English"}, want: true},
+		{name: "last updated changed", dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated.Add(-time.Hour), Genre: "Rock", Language: "English"}, want: false},
+		{name: "genre missing", dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Language: "English"}, want: false},
+		{name: "language missing", dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Genre: "Rock"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extendedInfoIsCached(tt.dbMapset, apiMapset))
+		})
+	}
+}
+
+func TestUseCase_commentsRefreshInterval(t *testing.T) {
+	uc := &UseCase{cfg: &config.Config{
+		CommentsRefreshGraveyard: 7 * 24 * time.Hour,
+		CommentsRefreshPending:   24 * time.Hour,
+		CommentsRefreshRanked:    3 * 24 * time.Hour,
+	}}
+
+	tests := []struct {
+		status string
+		want   time.Duration
+	}{
+		{status: "graveyard", want: 7 * 24 * time.Hour},
+		{status: "wip", want: 24 * time.Hour},
+		{status: "pending", want: 24 * time.Hour},
+		{status: "qualified", want: 24 * time.Hour},
+		{status: "ranked", want: 3 * 24 * time.Hour},
+		{status: "approved", want: 3 * 24 * time.Hour},
+		{status: "loved", want: 3 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.want, uc.commentsRefreshInterval(tt.status))
+		})
+	}
+}
+
+func TestUseCase_resolveExtendedInfo(t *testing.T) {
+	lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		CommentsRefreshGraveyard: 7 * 24 * time.Hour,
+		CommentsRefreshPending:   24 * time.Hour,
+		CommentsRefreshRanked:    3 * 24 * time.Hour,
+	}
+	stats := repository.JSON(`{"2024-01-01T00:00:00Z":{"comments_count":5},"2024-01-02T00:00:00Z":{"comments_count":7}}`)
+
+	tests := []struct {
+		name         string
+		dbMapset     *model.Mapset
+		status       string
+		lastUpdated  time.Time
+		wantGenre    string
+		wantComments int
+		wantStats    runStats
+	}{
+		{
+			name:         "new mapset is fetched",
+			status:       "graveyard",
+			lastUpdated:  lastUpdated,
+			wantGenre:    "Electronic",
+			wantComments: 42,
+			wantStats:    runStats{extendedFetched: 1, commentsFetched: 1},
+		},
+		{
+			name: "unchanged mapset with fresh comments is cached",
+			dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Genre: "Rock", Language: "English",
+				CommentsFetchedAt: time.Now().Add(-2 * 24 * time.Hour), MapsetStats: stats},
+			status:       "graveyard",
+			lastUpdated:  lastUpdated,
+			wantGenre:    "Rock",
+			wantComments: 7,
+			wantStats:    runStats{extendedCached: 1, commentsCached: 1},
+		},
+		{
+			name: "updated mapset is fetched again",
+			dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Genre: "Rock", Language: "English",
+				CommentsFetchedAt: time.Now(), MapsetStats: stats},
+			status:       "graveyard",
+			lastUpdated:  lastUpdated.Add(time.Hour),
+			wantGenre:    "Electronic",
+			wantComments: 7,
+			wantStats:    runStats{extendedFetched: 1, commentsCached: 1},
+		},
+		{
+			name: "comments older than interval of status are fetched",
+			dbMapset: &model.Mapset{ID: 1, LastUpdated: lastUpdated, Genre: "Rock", Language: "English",
+				CommentsFetchedAt: time.Now().Add(-2 * 24 * time.Hour), MapsetStats: stats},
+			status:       "pending",
+			lastUpdated:  lastUpdated,
+			wantGenre:    "Rock",
+			wantComments: 42,
+			wantStats:    runStats{extendedCached: 1, commentsFetched: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeOsuAPI{}
+			uc := &UseCase{cfg: cfg, osuApi: api}
+
+			var dbMapsets []*model.Mapset
+			if tt.dbMapset != nil {
+				dbMapsets = append(dbMapsets, tt.dbMapset)
+			}
+			apiMapset := &osuapi.MapsetExtended{Mapset: &osuapi.Mapset{Id: 1, Status: tt.status, LastUpdated: tt.lastUpdated}}
+
+			stats := &runStats{}
+			require.NoError(t, uc.resolveExtendedInfo(context.Background(), stats, dbMapsets, []*osuapi.MapsetExtended{apiMapset}))
+
+			assert.Equal(t, tt.wantGenre, apiMapset.Genre)
+			assert.Equal(t, tt.wantComments, apiMapset.CommentsCount)
+			assert.Equal(t, tt.wantStats, *stats)
+			assert.Equal(t, tt.wantStats.extendedFetched, api.extendedCalls)
+			assert.Equal(t, tt.wantStats.commentsFetched, api.commentsCalls)
+		})
+	}
+}
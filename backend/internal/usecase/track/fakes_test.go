@@ -0,0 +1,244 @@
+package track
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fakeTokenProvider struct{}
+
+func (fakeTokenProvider) GetToken(context.Context) (string, error) {
+	return "token", nil
+}
+
+// newStubOsuAPI returns real api client whose every request is answered with status and body
+func newStubOsuAPI(t *testing.T, status int, body string) osuapi.Interface {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return osuapi.New(&config.Config{OsuAPIHost: srv.URL}, fakeTokenProvider{}, srv.Client())
+}
+
+// fakeOsuAPI answers lookups of single mapsets from mapsets and counts calls of extended info endpoints
+type fakeOsuAPI struct {
+	osuapi.Interface
+
+	mapsets map[int]*osuapi.Mapset
+
+	extendedCalls int
+	commentsCalls int
+}
+
+func (a *fakeOsuAPI) GetMapset(_ context.Context, mapsetID string) (*osuapi.Mapset, error) {
+	id, err := strconv.Atoi(mapsetID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapset, ok := a.mapsets[id]
+	if !ok {
+		return nil, osuapi.ErrNotFound
+	}
+
+	return mapset, nil
+}
+
+func (a *fakeOsuAPI) GetMapsetExtended(context.Context, string) (*osuapi.MapsetLangGenre, error) {
+	a.extendedCalls++
+
+	return &osuapi.MapsetLangGenre{
+		Genre:    osuapi.Entity{Name: "Electronic"},
+		Language: osuapi.Entity{Name: "Instrumental"},
+	}, nil
+}
+
+func (a *fakeOsuAPI) GetMapsetCommentsCount(context.Context, string) (int, error) {
+	a.commentsCalls++
+
+	return 42, nil
+}
+
+type fakeUserStore struct {
+	userStore
+
+	users map[int]*model.User
+}
+
+func (s *fakeUserStore) Exists(_ context.Context, _ txmanager.Tx, id int) (bool, error) {
+	_, ok := s.users[id]
+	return ok, nil
+}
+
+func (s *fakeUserStore) Get(_ context.Context, _ txmanager.Tx, id int) (*model.User, error) {
+	return s.users[id], nil
+}
+
+func (s *fakeUserStore) Create(_ context.Context, _ txmanager.Tx, user *model.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *fakeUserStore) Update(_ context.Context, _ txmanager.Tx, user *model.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+// fakeMapsetStore treats existing ids as stored, upserts don't change them so that retried attempts see the same rows
+type fakeMapsetStore struct {
+	mapsetStore
+
+	listed   []*model.Mapset
+	existing []int
+	upserted []*model.Mapset
+	removed  map[model.MapsetRemovalStatus][]int
+}
+
+func (s *fakeMapsetStore) List(context.Context, txmanager.Tx) ([]*model.Mapset, error) {
+	return s.listed, nil
+}
+
+func (s *fakeMapsetStore) ListByIDs(_ context.Context, _ txmanager.Tx, ids ...int) ([]*model.Mapset, error) {
+	var mapsets []*model.Mapset
+	for _, mapset := range s.listed {
+		if len(intersect([]int{mapset.ID}, ids)) > 0 {
+			mapsets = append(mapsets, mapset)
+		}
+	}
+
+	return mapsets, nil
+}
+
+func (s *fakeMapsetStore) ListExistingIDs(_ context.Context, _ txmanager.Tx, ids ...int) ([]int, error) {
+	return intersect(s.existing, ids), nil
+}
+
+func (s *fakeMapsetStore) Upsert(_ context.Context, _ txmanager.Tx, mapsets ...*model.Mapset) error {
+	s.upserted = mapsets
+	return nil
+}
+
+func (s *fakeMapsetStore) MarkRemoved(
+	_ context.Context,
+	_ txmanager.Tx,
+	status model.MapsetRemovalStatus,
+	_ time.Time,
+	ids ...int,
+) error {
+	if s.removed == nil {
+		s.removed = make(map[model.MapsetRemovalStatus][]int)
+	}
+	s.removed[status] = ids
+
+	return nil
+}
+
+type fakeWatchedMapsetStore struct {
+	watchedMapsetStore
+
+	lastFetched []int
+}
+
+func (s *fakeWatchedMapsetStore) SetLastFetched(_ context.Context, _ txmanager.Tx, id int, _ time.Time) error {
+	s.lastFetched = append(s.lastFetched, id)
+	return nil
+}
+
+type fakeBeatmapStore struct {
+	beatmapStore
+
+	existing []int
+	upserted []*model.Beatmap
+}
+
+func (s *fakeBeatmapStore) ListForMapsets(context.Context, txmanager.Tx, ...int) ([]*model.Beatmap, error) {
+	return nil, nil
+}
+
+func (s *fakeBeatmapStore) ListExistingIDs(_ context.Context, _ txmanager.Tx, ids ...int) ([]int, error) {
+	return intersect(s.existing, ids), nil
+}
+
+func (s *fakeBeatmapStore) Upsert(_ context.Context, _ txmanager.Tx, beatmaps ...*model.Beatmap) error {
+	s.upserted = beatmaps
+	return nil
+}
+
+type fakeFollowingStore struct {
+	followingStore
+}
+
+func (fakeFollowingStore) SetLastFetchedForUser(context.Context, txmanager.Tx, string, time.Time) error {
+	return nil
+}
+
+// fakeAnomalyStore skips anomalies stored before like unique constraint of the table does
+type fakeAnomalyStore struct {
+	stored map[string]bool
+}
+
+func (s *fakeAnomalyStore) Create(_ context.Context, _ txmanager.Tx, anomalies ...*model.Anomaly) ([]*model.Anomaly, error) {
+	var created []*model.Anomaly
+	for _, anomaly := range anomalies {
+		key := fmt.Sprint(anomaly.EntityType, anomaly.EntityID, anomaly.Metric, anomaly.Kind, anomaly.DetectedFor)
+		if s.stored[key] {
+			continue
+		}
+
+		s.stored[key] = true
+		created = append(created, anomaly)
+	}
+
+	return created, nil
+}
+
+// fakeMilestoneStore skips milestones stored before like unique constraint of the table does
+type fakeMilestoneStore struct {
+	stored map[string]bool
+}
+
+func (s *fakeMilestoneStore) Create(_ context.Context, _ txmanager.Tx, milestones ...*model.Milestone) ([]*model.Milestone, error) {
+	var created []*model.Milestone
+	for _, milestone := range milestones {
+		key := fmt.Sprint(milestone.EntityType, milestone.EntityID, milestone.Kind, milestone.Threshold)
+		if s.stored[key] {
+			continue
+		}
+
+		s.stored[key] = true
+		created = append(created, milestone)
+	}
+
+	return created, nil
+}
+
+type fakeMapsetEventStore struct{}
+
+func (fakeMapsetEventStore) Create(context.Context, txmanager.Tx, ...*model.MapsetEvent) error {
+	return nil
+}
+
+func intersect(stored, ids []int) []int {
+	res := []int{}
+	for _, id := range ids {
+		for _, s := range stored {
+			if s == id {
+				res = append(res, id)
+				break
+			}
+		}
+	}
+
+	return res
+}
@@ -7,24 +7,49 @@ import (
 	"time"
 )
 
-func containsMapset(entities []*model.Mapset, id int) bool {
+func getMapsetByID(entities []*model.Mapset, id int) *model.Mapset {
 	for _, entity := range entities {
 		if entity.ID == id {
-			if entity.Genre == "" || entity.Language == "" {
-				return true
-			}
+			return entity
 		}
 	}
-	return false
+	return nil
 }
 
-func getMapsetByID(entities []*model.Mapset, id int) *model.Mapset {
+// uniqueByID keeps the last entity for every id, order of first occurrence is preserved
+func uniqueByID[T any](entities []T, id func(T) int) []T {
+	index := make(map[int]int, len(entities))
+	res := make([]T, 0, len(entities))
 	for _, entity := range entities {
-		if entity.ID == id {
-			return entity
+		if i, ok := index[id(entity)]; ok {
+			res[i] = entity
+			continue
 		}
+		index[id(entity)] = len(res)
+		res = append(res, entity)
 	}
-	return nil
+
+	return res
+}
+
+// mergeWatchedUsers adds users watched in any watchlist to follows, so every user is fetched
+// once per run however many watchlists have them. watched only users get follow without username
+func mergeWatchedUsers(follows []*model.Following, watchedIDs []int) []*model.Following {
+	followed := make(map[int]bool, len(follows))
+	for _, follow := range follows {
+		followed[follow.ID] = true
+	}
+
+	res := follows
+	for _, id := range watchedIDs {
+		if followed[id] {
+			continue
+		}
+		followed[id] = true
+		res = append(res, &model.Following{ID: id})
+	}
+
+	return res
 }
 
 func (uc *UseCase) GetLastTimeTracked(
@@ -0,0 +1,25 @@
+package track
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+)
+
+func Test_mergeWatchedUsers(t *testing.T) {
+	follows := []*model.Following{
+		{ID: 1, Username: "first"},
+		{ID: 2, Username: "second"},
+	}
+
+	merged := mergeWatchedUsers(follows, []int{2, 3, 3, 4})
+
+	assert.Equal(t, []*model.Following{
+		{ID: 1, Username: "first"},
+		{ID: 2, Username: "second"},
+		{ID: 3},
+		{ID: 4},
+	}, merged)
+
+	assert.Empty(t, mergeWatchedUsers(nil, nil))
+}
@@ -21,23 +21,25 @@ func mapOsuApiMapsetsToCreateMapsetCommands(mapsets []*osuapi.MapsetExtended) []
 	var cmds []*command.CreateMapsetCommand
 	for _, m := range mapsets {
 		cmds = append(cmds, &command.CreateMapsetCommand{
-			Id:             m.Id,
-			Artist:         m.Artist,
-			Title:          m.Title,
-			Covers:         m.Covers,
-			Status:         m.Status,
-			LastUpdated:    m.LastUpdated,
-			UserId:         m.UserId,
-			PreviewUrl:     m.PreviewUrl,
-			Tags:           m.Tags,
-			PlayCount:      m.PlayCount,
-			FavouriteCount: m.FavouriteCount,
-			CommentsCount:  m.CommentsCount,
-			Bpm:            m.Bpm,
-			Creator:        m.Creator,
-			Language:       m.Language,
-			Genre:          m.Genre,
-			Beatmaps:       mapOsuApiBeatmapsToCreateBeatmapCommands(m.Beatmaps),
+			Id:                m.Id,
+			Artist:            m.Artist,
+			Title:             m.Title,
+			Covers:            m.Covers,
+			Status:            m.Status,
+			LastUpdated:       m.LastUpdated,
+			UserId:            m.UserId,
+			PreviewUrl:        m.PreviewUrl,
+			Tags:              m.Tags,
+			PlayCount:         m.PlayCount,
+			FavouriteCount:    m.FavouriteCount,
+			CommentsCount:     m.CommentsCount,
+			CommentsFetchedAt: m.CommentsFetchedAt,
+			SubmittedDate:     m.SubmittedDate,
+			Bpm:               m.Bpm,
+			Creator:           m.Creator,
+			Language:          m.Language,
+			Genre:             m.Genre,
+			Beatmaps:          mapOsuApiBeatmapsToCreateBeatmapCommands(m.Beatmaps),
 		})
 	}
 	return cmds
@@ -83,23 +85,25 @@ func mapOsuApiMapsetsToUpdateMapsetCommands(mapsets []*osuapi.MapsetExtended) []
 	var cmds []*command.UpdateMapsetCommand
 	for _, m := range mapsets {
 		cmds = append(cmds, &command.UpdateMapsetCommand{
-			Id:             m.Id,
-			Artist:         m.Artist,
-			Title:          m.Title,
-			Covers:         m.Covers,
-			Status:         m.Status,
-			LastUpdated:    m.LastUpdated,
-			UserId:         m.UserId,
-			PreviewUrl:     m.PreviewUrl,
-			Tags:           m.Tags,
-			PlayCount:      m.PlayCount,
-			FavouriteCount: m.FavouriteCount,
-			CommentsCount:  m.CommentsCount,
-			Bpm:            m.Bpm,
-			Creator:        m.Creator,
-			Language:       m.Language,
-			Genre:          m.Genre,
-			Beatmaps:       mapOsuApiBeatmapsToUpdateBeatmapCommands(m.Beatmaps),
+			Id:                m.Id,
+			Artist:            m.Artist,
+			Title:             m.Title,
+			Covers:            m.Covers,
+			Status:            m.Status,
+			LastUpdated:       m.LastUpdated,
+			UserId:            m.UserId,
+			PreviewUrl:        m.PreviewUrl,
+			Tags:              m.Tags,
+			PlayCount:         m.PlayCount,
+			FavouriteCount:    m.FavouriteCount,
+			CommentsCount:     m.CommentsCount,
+			CommentsFetchedAt: m.CommentsFetchedAt,
+			SubmittedDate:     m.SubmittedDate,
+			Bpm:               m.Bpm,
+			Creator:           m.Creator,
+			Language:          m.Language,
+			Genre:             m.Genre,
+			Beatmaps:          mapOsuApiBeatmapsToUpdateBeatmapCommands(m.Beatmaps),
 		})
 	}
 	return cmds
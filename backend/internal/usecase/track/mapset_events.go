@@ -0,0 +1,43 @@
+package track
+
+import (
+	"playcount-monitor-backend/internal/database/repository/model"
+	"time"
+)
+
+// detectMapsetEvents lists new mapsets and status changes of user's mapsets since previous snapshot
+func detectMapsetEvents(
+	prevMapsets []*model.Mapset,
+	createdMapsets []*model.Mapset,
+	newMapsets []*model.Mapset,
+	occurredAt time.Time,
+) []*model.MapsetEvent {
+	var events []*model.MapsetEvent
+	for _, mapset := range createdMapsets {
+		events = append(events, &model.MapsetEvent{
+			MapsetID:   mapset.ID,
+			UserID:     mapset.UserID,
+			Kind:       string(model.MapsetEventNew),
+			Status:     mapset.Status,
+			OccurredAt: occurredAt,
+		})
+	}
+
+	for _, mapset := range newMapsets {
+		prev := getMapsetByID(prevMapsets, mapset.ID)
+		if prev == nil || prev.Status == mapset.Status {
+			continue
+		}
+
+		events = append(events, &model.MapsetEvent{
+			MapsetID:       mapset.ID,
+			UserID:         mapset.UserID,
+			Kind:           string(model.MapsetEventStatusChange),
+			PreviousStatus: prev.Status,
+			Status:         mapset.Status,
+			OccurredAt:     occurredAt,
+		})
+	}
+
+	return events
+}
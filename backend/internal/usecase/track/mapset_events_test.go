@@ -0,0 +1,29 @@
+package track
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+func Test_detectMapsetEvents(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	prevMapsets := []*model.Mapset{
+		{ID: 1, UserID: 7, Status: "pending"},
+		{ID: 2, UserID: 7, Status: "graveyard"},
+	}
+	newMapsets := []*model.Mapset{
+		{ID: 1, UserID: 7, Status: "ranked"},
+		{ID: 2, UserID: 7, Status: "graveyard"},
+		{ID: 3, UserID: 7, Status: "wip"},
+	}
+
+	events := detectMapsetEvents(prevMapsets, createdMapsets(newMapsets, []int{1, 2}), newMapsets, now)
+
+	assert.Equal(t, []*model.MapsetEvent{
+		{MapsetID: 3, UserID: 7, Kind: "new_mapset", Status: "wip", OccurredAt: now},
+		{MapsetID: 1, UserID: 7, Kind: "status_change", PreviousStatus: "pending", Status: "ranked", OccurredAt: now},
+	}, events)
+}
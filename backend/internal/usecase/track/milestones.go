@@ -0,0 +1,116 @@
+package track
+
+import (
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"time"
+)
+
+// detectMilestones compares previous snapshot of user and their mapsets with the new one
+// and lists every configured threshold crossed in between
+func (uc *UseCase) detectMilestones(
+	prevUser *model.User,
+	newUser *model.User,
+	prevMapsets []*model.Mapset,
+	newMapsets []*model.Mapset,
+	reachedAt time.Time,
+) ([]*model.Milestone, error) {
+	prevUserStats, err := latestUserStats(prevUser)
+	if err != nil {
+		return nil, err
+	}
+
+	newUserStats, err := latestUserStats(newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	var milestones []*model.Milestone
+	add := func(entityType model.MilestoneEntityType, entityID int, kind model.MilestoneKind, prev, cur int, thresholds []int) {
+		for _, threshold := range crossedThresholds(prev, cur, thresholds) {
+			milestones = append(milestones, &model.Milestone{
+				EntityType: string(entityType),
+				EntityID:   entityID,
+				UserID:     newUser.ID,
+				Kind:       string(kind),
+				Threshold:  threshold,
+				Value:      cur,
+				ReachedAt:  reachedAt,
+			})
+		}
+	}
+
+	add(model.MilestoneUser, newUser.ID, model.MilestonePlaycount,
+		prevUserStats.PlayCount, newUserStats.PlayCount, uc.cfg.MilestoneUserPlaycounts)
+	add(model.MilestoneUser, newUser.ID, model.MilestoneFavourites,
+		prevUserStats.Favorites, newUserStats.Favorites, uc.cfg.MilestoneFavourites)
+	add(model.MilestoneUser, newUser.ID, model.MilestoneMapCount,
+		prevUserStats.MapCount, newUserStats.MapCount, uc.cfg.MilestoneMapCounts)
+	add(model.MilestoneUser, newUser.ID, model.MilestoneFirstRanked,
+		rankedCount(prevMapsets), rankedCount(newMapsets), []int{1})
+
+	prevByID := make(map[int]*model.Mapset, len(prevMapsets))
+	for _, mapset := range prevMapsets {
+		prevByID[mapset.ID] = mapset
+	}
+
+	for _, mapset := range newMapsets {
+		// new mapsets have no previous snapshot to compare with
+		prev, ok := prevByID[mapset.ID]
+		if !ok {
+			continue
+		}
+
+		add(model.MilestoneMapset, mapset.ID, model.MilestonePlaycount,
+			prev.LastPlaycount, mapset.LastPlaycount, uc.cfg.MilestoneMapsetPlaycounts)
+		add(model.MilestoneMapset, mapset.ID, model.MilestoneFavourites,
+			prev.LastFavorites, mapset.LastFavorites, uc.cfg.MilestoneFavourites)
+	}
+
+	return milestones, nil
+}
+
+// crossedThresholds lists thresholds in (prev, cur] range
+func crossedThresholds(prev, cur int, thresholds []int) []int {
+	var crossed []int
+	for _, threshold := range thresholds {
+		if prev < threshold && threshold <= cur {
+			crossed = append(crossed, threshold)
+		}
+	}
+
+	return crossed
+}
+
+func rankedCount(mapsets []*model.Mapset) int {
+	var count int
+	for _, mapset := range mapsets {
+		if mapset.RemovalStatus != string(model.MapsetActive) {
+			continue
+		}
+
+		if mapset.Status == string(model.Ranked) || mapset.Status == string(model.Approved) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func latestUserStats(user *model.User) (*model.UserStatsModel, error) {
+	stats, err := mappers.MapStatsJSONToUserStats(user.UserStats)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastTime time.Time
+	latest := &model.UserStatsModel{}
+	for t, s := range stats {
+		if t.After(lastTime) {
+			lastTime = t
+			latest = s
+		}
+	}
+
+	return latest, nil
+}
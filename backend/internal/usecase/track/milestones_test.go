@@ -0,0 +1,65 @@
+package track
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+func Test_crossedThresholds(t *testing.T) {
+	thresholds := []int{100, 1000, 10000}
+
+	assert.Equal(t, []int{100, 1000}, crossedThresholds(99, 1000, thresholds))
+	assert.Empty(t, crossedThresholds(100, 999, thresholds))
+	assert.Empty(t, crossedThresholds(2000, 1500, thresholds))
+}
+
+func Test_detectMilestones(t *testing.T) {
+	uc := &UseCase{cfg: &config.Config{
+		MilestoneUserPlaycounts:   []int{100000, 1000000},
+		MilestoneMapsetPlaycounts: []int{100000},
+		MilestoneFavourites:       []int{100, 1000},
+		MilestoneMapCounts:        []int{50},
+	}}
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	prevUser := &model.User{ID: 1, UserStats: repository.JSON(
+		`{"2024-01-01T00:00:00Z":{"play_count":99000,"favourite_count":90,"map_count":49}}`)}
+	newUser := &model.User{ID: 1, UserStats: repository.JSON(
+		`{"2024-01-02T00:00:00Z":{"play_count":101000,"favourite_count":95,"map_count":50}}`)}
+
+	prevMapsets := []*model.Mapset{
+		{ID: 10, Status: "pending", LastPlaycount: 99999, LastFavorites: 99},
+	}
+	newMapsets := []*model.Mapset{
+		{ID: 10, Status: "ranked", LastPlaycount: 100000, LastFavorites: 99},
+		{ID: 11, Status: "graveyard", LastPlaycount: 500000, LastFavorites: 5000},
+	}
+
+	milestones, err := uc.detectMilestones(prevUser, newUser, prevMapsets, newMapsets, now)
+	require.NoError(t, err)
+
+	type reached struct {
+		entityType string
+		entityID   int
+		kind       string
+		threshold  int
+	}
+	var actual []reached
+	for _, m := range milestones {
+		assert.Equal(t, 1, m.UserID)
+		assert.Equal(t, now, m.ReachedAt)
+		actual = append(actual, reached{m.EntityType, m.EntityID, m.Kind, m.Threshold})
+	}
+
+	assert.Equal(t, []reached{
+		{"user", 1, "play_count", 100000},
+		{"user", 1, "map_count", 50},
+		{"user", 1, "first_ranked", 1},
+		{"mapset", 10, "play_count", 100000},
+	}, actual)
+}
@@ -0,0 +1,117 @@
+package track
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/service/notifier"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/timeseries"
+)
+
+const mapsetURLFormat = "https://osu.ppy.sh/beatmapsets/%v"
+
+// notify sends events collected during run, failing to notify doesn't fail the run
+func (uc *UseCase) notify(ctx context.Context, lg *log.Logger, events []*notifier.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	// run context is already done when tracking timed out, events should still go out within their own deadline
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), uc.cfg.WebhookNotifyTimeout)
+	defer cancel()
+
+	if err := uc.notifier.Notify(ctx, events...); err != nil {
+		lg.Errorf("failed to notify about %v tracking events: %v", len(events), err)
+	}
+}
+
+func mapsetEventNotifications(events []*model.MapsetEvent, mapsets []*model.Mapset) []*notifier.Event {
+	res := make([]*notifier.Event, 0, len(events))
+	for _, event := range events {
+		mapset := getMapsetByID(mapsets, event.MapsetID)
+		if mapset == nil {
+			continue
+		}
+
+		notification := &notifier.Event{
+			Type:       notifier.EventNewMapset,
+			OccurredAt: event.OccurredAt,
+			UserID:     event.UserID,
+			Mapset:     eventMapset(mapset),
+		}
+		if event.Kind == string(model.MapsetEventStatusChange) {
+			notification.Type = notifier.EventStatusChange
+			notification.PreviousStatus = event.PreviousStatus
+		}
+
+		res = append(res, notification)
+	}
+
+	return res
+}
+
+func milestoneNotifications(user *model.User, milestones []*model.Milestone, mapsets []*model.Mapset) []*notifier.Event {
+	events := make([]*notifier.Event, 0, len(milestones))
+	for _, milestone := range milestones {
+		event := &notifier.Event{
+			Type:       notifier.EventMilestone,
+			OccurredAt: milestone.ReachedAt,
+			UserID:     user.ID,
+			Username:   user.Username,
+			Metric:     milestone.Kind,
+			Threshold:  milestone.Threshold,
+			Value:      milestone.Value,
+		}
+		if milestone.EntityType == string(model.MilestoneMapset) {
+			if mapset := getMapsetByID(mapsets, milestone.EntityID); mapset != nil {
+				event.Mapset = eventMapset(mapset)
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func spikeEvent(anomaly *model.Anomaly, mapset *model.Mapset) *notifier.Event {
+	event := &notifier.Event{
+		Type:       notifier.EventSpike,
+		OccurredAt: anomaly.DetectedFor,
+		UserID:     anomaly.UserID,
+		Mapset:     eventMapset(mapset),
+		Metric:     anomaly.Metric,
+		Value:      anomaly.Value,
+		Delta:      anomaly.Delta,
+	}
+	if anomaly.EntityType == string(model.AnomalyBeatmap) {
+		event.BeatmapID = anomaly.EntityID
+	}
+
+	return event
+}
+
+func isSpike(anomaly *model.Anomaly) bool {
+	return anomaly.Kind == string(timeseries.Spike)
+}
+
+// eventMapset maps mapset to its event view, covers are left out when they can't be parsed
+func eventMapset(mapset *model.Mapset) *notifier.Mapset {
+	covers, err := mappers.MapCoversJSONToMapsetCovers(mapset.Covers)
+	if err != nil {
+		covers = nil
+	}
+
+	return &notifier.Mapset{
+		ID:      mapset.ID,
+		UserID:  mapset.UserID,
+		Creator: mapset.Creator,
+		Artist:  mapset.Artist,
+		Title:   mapset.Title,
+		Status:  mapset.Status,
+		Covers:  covers,
+		URL:     fmt.Sprintf(mapsetURLFormat, mapset.ID),
+	}
+}
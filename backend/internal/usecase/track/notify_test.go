@@ -0,0 +1,57 @@
+package track
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/service/notifier"
+	"testing"
+	"time"
+)
+
+func Test_mapsetEventNotifications(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mapsets := []*model.Mapset{
+		{ID: 1, UserID: 7, Status: "ranked", Covers: repository.JSON(`{"card":"https://assets.ppy.sh/card.jpg"}`)},
+		{ID: 3, UserID: 7, Status: "wip"},
+	}
+	events := []*model.MapsetEvent{
+		{MapsetID: 3, UserID: 7, Kind: "new_mapset", Status: "wip", OccurredAt: now},
+		{MapsetID: 1, UserID: 7, Kind: "status_change", PreviousStatus: "pending", Status: "ranked", OccurredAt: now},
+	}
+
+	notifications := mapsetEventNotifications(events, mapsets)
+	require.Len(t, notifications, 2)
+
+	assert.Equal(t, notifier.EventNewMapset, notifications[0].Type)
+	assert.Equal(t, 3, notifications[0].Mapset.ID)
+	assert.Equal(t, "https://osu.ppy.sh/beatmapsets/3", notifications[0].Mapset.URL)
+
+	assert.Equal(t, notifier.EventStatusChange, notifications[1].Type)
+	assert.Equal(t, "pending", notifications[1].PreviousStatus)
+	assert.Equal(t, "ranked", notifications[1].Mapset.Status)
+	assert.Equal(t, "https://assets.ppy.sh/card.jpg", notifications[1].Mapset.Covers["card"])
+	assert.Equal(t, now, notifications[1].OccurredAt)
+}
+
+func Test_milestoneNotifications(t *testing.T) {
+	user := &model.User{ID: 7, Username: "mapper"}
+	mapsets := []*model.Mapset{{ID: 1, UserID: 7, Title: "title"}}
+	milestones := []*model.Milestone{
+		{EntityType: "user", EntityID: 7, Kind: "play_count", Threshold: 100000, Value: 100500},
+		{EntityType: "mapset", EntityID: 1, Kind: "favourite_count", Threshold: 100, Value: 101},
+	}
+
+	events := milestoneNotifications(user, milestones, mapsets)
+	require.Len(t, events, 2)
+
+	assert.Nil(t, events[0].Mapset)
+	assert.Equal(t, "mapper", events[0].Username)
+	assert.Equal(t, 100000, events[0].Threshold)
+
+	require.NotNil(t, events[1].Mapset)
+	assert.Equal(t, "title", events[1].Mapset.Title)
+	assert.Equal(t, "favourite_count", events[1].Metric)
+}
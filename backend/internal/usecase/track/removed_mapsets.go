@@ -0,0 +1,97 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"strconv"
+	"time"
+)
+
+// removedMapsets holds ids of db mapsets that are missing from host's api listing, grouped by removal status
+type removedMapsets map[model.MapsetRemovalStatus][]int
+
+// detectRemovedMapsets diffs api listing against active db mapsets of the user,
+// every missing mapset is looked up by id to tell deleted mapsets from ones moved to another host
+func (uc *UseCase) detectRemovedMapsets(
+	ctx context.Context,
+	userID int,
+	dbMapsets []*model.Mapset,
+	apiMapsets []*osuapi.MapsetExtended,
+) (removedMapsets, error) {
+	listed := make(map[int]struct{}, len(apiMapsets))
+	for _, mapset := range apiMapsets {
+		listed[mapset.Id] = struct{}{}
+	}
+
+	removed := make(removedMapsets)
+	for _, dbMapset := range dbMapsets {
+		if dbMapset.RemovalStatus != string(model.MapsetActive) {
+			continue
+		}
+
+		if _, ok := listed[dbMapset.ID]; ok {
+			continue
+		}
+
+		apiMapset, err := uc.osuApi.GetMapset(ctx, strconv.Itoa(dbMapset.ID))
+		if errors.Is(err, osuapi.ErrNotFound) {
+			removed[model.MapsetDeleted] = append(removed[model.MapsetDeleted], dbMapset.ID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get missing mapset from api, mapset id: %v, err: %w", dbMapset.ID, err)
+		}
+
+		// mapset still belongs to the user but is not listed, e.g. in a status we don't fetch, leave it as is
+		if apiMapset.UserId != userID {
+			removed[model.MapsetTransferred] = append(removed[model.MapsetTransferred], dbMapset.ID)
+		}
+	}
+
+	return removed, nil
+}
+
+// allMapsetsRemoved marks every active mapset as deleted, used when user itself is gone from api (restricted)
+func allMapsetsRemoved(dbMapsets []*model.Mapset) removedMapsets {
+	removed := make(removedMapsets)
+	for _, dbMapset := range dbMapsets {
+		if dbMapset.RemovalStatus == string(model.MapsetActive) {
+			removed[model.MapsetDeleted] = append(removed[model.MapsetDeleted], dbMapset.ID)
+		}
+	}
+
+	return removed
+}
+
+// removeUserMapsets marks every active mapset of a user missing from api as deleted
+func (uc *UseCase) removeUserMapsets(ctx context.Context, stats *runStats, dbMapsets []*model.Mapset) error {
+	before := *stats
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		// drop counters collected by failed attempt if transaction is retried
+		*stats = before
+		return uc.markRemovedMapsets(ctx, tx, stats, allMapsetsRemoved(dbMapsets))
+	})
+}
+
+func (uc *UseCase) markRemovedMapsets(
+	ctx context.Context,
+	tx txmanager.Tx,
+	stats *runStats,
+	removed removedMapsets,
+) error {
+	now := time.Now().UTC()
+	for status, ids := range removed {
+		if err := uc.mapset.MarkRemoved(ctx, tx, status, now, ids...); err != nil {
+			return err
+		}
+	}
+
+	stats.mapsetsDeleted += len(removed[model.MapsetDeleted])
+	stats.mapsetsTransferred += len(removed[model.MapsetTransferred])
+
+	return nil
+}
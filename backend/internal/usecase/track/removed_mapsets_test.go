@@ -0,0 +1,94 @@
+package track
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"testing"
+)
+
+func TestUseCase_detectRemovedMapsets(t *testing.T) {
+	const userID = 7
+
+	api := &fakeOsuAPI{mapsets: map[int]*osuapi.Mapset{
+		// 2 is missing from api entirely, so it was deleted
+		3: {Id: 3, UserId: 8},      // moved to another host
+		4: {Id: 4, UserId: userID}, // still owned, not listed e.g. because of status we don't fetch
+	}}
+	uc := &UseCase{osuApi: api}
+
+	dbMapsets := []*model.Mapset{
+		{ID: 1, RemovalStatus: string(model.MapsetActive)},
+		{ID: 2, RemovalStatus: string(model.MapsetActive)},
+		{ID: 3, RemovalStatus: string(model.MapsetActive)},
+		{ID: 4, RemovalStatus: string(model.MapsetActive)},
+		{ID: 5, RemovalStatus: string(model.MapsetDeleted)},
+	}
+	apiMapsets := []*osuapi.MapsetExtended{{Mapset: &osuapi.Mapset{Id: 1, UserId: userID}}}
+
+	removed, err := uc.detectRemovedMapsets(context.Background(), userID, dbMapsets, apiMapsets)
+	require.NoError(t, err)
+
+	assert.Equal(t, removedMapsets{
+		model.MapsetDeleted:     {2},
+		model.MapsetTransferred: {3},
+	}, removed)
+}
+
+func Test_allMapsetsRemoved(t *testing.T) {
+	removed := allMapsetsRemoved([]*model.Mapset{
+		{ID: 1, RemovalStatus: string(model.MapsetActive)},
+		{ID: 2, RemovalStatus: string(model.MapsetTransferred)},
+		{ID: 3, RemovalStatus: string(model.MapsetActive)},
+	})
+
+	assert.Equal(t, removedMapsets{model.MapsetDeleted: {1, 3}}, removed)
+	assert.Empty(t, allMapsetsRemoved(nil))
+}
+
+func TestUseCase_markRemovedMapsets(t *testing.T) {
+	mapsets := &fakeMapsetStore{}
+	uc := &UseCase{mapset: mapsets}
+
+	stats := &runStats{}
+	err := uc.markRemovedMapsets(context.Background(), nil, stats, removedMapsets{
+		model.MapsetDeleted:     {1, 2},
+		model.MapsetTransferred: {3},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, mapsets.removed[model.MapsetDeleted])
+	assert.Equal(t, []int{3}, mapsets.removed[model.MapsetTransferred])
+	assert.Equal(t, runStats{mapsetsDeleted: 2, mapsetsTransferred: 1}, *stats)
+}
+
+func TestUseCase_removeUserMapsets_RetryKeepsStats(t *testing.T) {
+	mapsets := &fakeMapsetStore{}
+	uc := &UseCase{txm: txmanagertest.TxManager{Retries: 1}, mapset: mapsets}
+
+	stats := &runStats{mapsetsDeleted: 1}
+	err := uc.removeUserMapsets(context.Background(), stats, []*model.Mapset{
+		{ID: 1, RemovalStatus: string(model.MapsetActive)},
+		{ID: 2, RemovalStatus: string(model.MapsetActive)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, mapsets.removed[model.MapsetDeleted])
+	assert.Equal(t, 3, stats.mapsetsDeleted)
+}
+
+func TestUseCase_detectRemovedMapsets_APIErrorKeepsMapsetActive(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError} {
+		uc := &UseCase{osuApi: newStubOsuAPI(t, status, `{"error":"Too Many Attempts."}`)}
+
+		removed, err := uc.detectRemovedMapsets(context.Background(), 7, []*model.Mapset{
+			{ID: 1, RemovalStatus: string(model.MapsetActive)},
+		}, nil)
+		assert.Error(t, err, "status %v", status)
+		assert.Empty(t, removed, "status %v", status)
+	}
+}
@@ -0,0 +1,40 @@
+package track
+
+import (
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/service/notifier"
+)
+
+// runStats counts what was fetched from api and what was reused from db during one tracking run,
+// it also collects events to notify about once run is over
+type runStats struct {
+	extendedFetched int
+	extendedCached  int
+	commentsFetched int
+	commentsCached  int
+	mapsetsCreated  int
+	mapsetsUpdated  int
+	beatmapsCreated int
+	beatmapsUpdated int
+
+	mapsetsDeleted     int
+	mapsetsTransferred int
+
+	trendingMapsets   int
+	anomaliesDetected int
+	milestonesReached int
+
+	events []*notifier.Event
+}
+
+func (s *runStats) log(lg *log.Logger) {
+	lg.Infof("Mapset extended info: %v fetched, %v cached", s.extendedFetched, s.extendedCached)
+	lg.Infof("Mapset comments count: %v fetched, %v cached", s.commentsFetched, s.commentsCached)
+	lg.Infof("Mapsets: %v created, %v updated", s.mapsetsCreated, s.mapsetsUpdated)
+	lg.Infof("Beatmaps: %v created, %v updated", s.beatmapsCreated, s.beatmapsUpdated)
+	lg.Infof("Mapsets removed from api: %v deleted, %v transferred", s.mapsetsDeleted, s.mapsetsTransferred)
+	lg.Infof("Trending mapsets ranked: %v", s.trendingMapsets)
+	lg.Infof("Anomalies detected: %v", s.anomaliesDetected)
+	lg.Infof("Milestones reached: %v", s.milestonesReached)
+	lg.Infof("Events to notify about: %v", len(s.events))
+}
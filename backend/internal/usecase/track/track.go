@@ -2,10 +2,12 @@ package track
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/notifier"
 	"playcount-monitor-backend/internal/service/osuapi"
 	"strconv"
 	"time"
@@ -14,26 +16,62 @@ import (
 func (uc *UseCase) Track(
 	ctx context.Context,
 	lg *log.Logger,
+) error {
+	stats := &runStats{}
+
+	err := uc.run(ctx, lg, stats)
+	if err != nil {
+		stats.events = append(stats.events, &notifier.Event{
+			Type:       notifier.EventTrackingFailed,
+			OccurredAt: time.Now().UTC(),
+			Error:      err.Error(),
+		})
+	}
+
+	// data of users tracked before failure is already stored, so their events are sent as well
+	uc.notify(ctx, lg, stats.events)
+
+	return err
+}
+
+// run fetches every followed user from api and stores fresh data, then runs analysis over stored data
+func (uc *UseCase) run(
+	ctx context.Context,
+	lg *log.Logger,
+	stats *runStats,
 ) error {
 	startTime := time.Now()
 
-	// get all following IDs from db and get updated data from api, update data in db
+	// get all following and watched IDs from db and get updated data from api, update data in db
 	var follows []*model.Following
+	var watchedMapsetIDs []int
 	if err := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		var err error
 		if follows, err = uc.following.List(ctx, tx); err != nil {
 			return err
 		}
-		return nil
+
+		watchedIDs, err := uc.watchlist.ListWatchedIDs(ctx, tx, model.WatchlistUser)
+		if err != nil {
+			return err
+		}
+		follows = mergeWatchedUsers(follows, watchedIDs)
+
+		watchedMapsetIDs, err = uc.listWatchedMapsetIDs(ctx, tx)
+
+		return err
 	}); err != nil {
 		return err
 	}
 
-	lg.Infof("got following IDs from db, %v total", len(follows))
-	if len(follows) == 0 {
-		return fmt.Errorf("no following users present in db")
+	lg.Infof("got following and watched IDs from db, %v users and %v mapsets total", len(follows), len(watchedMapsetIDs))
+	if len(follows) == 0 && len(watchedMapsetIDs) == 0 {
+		return fmt.Errorf("no following or watched users and mapsets present in db")
 	}
 
+	// mapsets of fetched users are up to date already, watched mapsets among them are not fetched again
+	fetchedMapsets := make(map[int]bool)
+
 	// max 300 requests a minute
 	for i, following := range follows {
 		lg.Infof("fetching user %s with id %v, %v/%v", following.Username, following.ID, i, len(follows))
@@ -51,47 +89,56 @@ func (uc *UseCase) Track(
 
 		// get data from api
 		user, userMapsets, err := uc.osuApi.GetUserWithMapsets(ctx, strconv.Itoa(following.ID))
+		if errors.Is(err, osuapi.ErrNotFound) {
+			// user is restricted or deleted, their mapsets are gone with them
+			lg.Warnf("user %s with id %v not found in api, marking their mapsets as deleted", following.Username, following.ID)
+			if err := uc.removeUserMapsets(ctx, stats, dbUserMapsets); err != nil {
+				return err
+			}
+			continue
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get info from api, user id: %v, err: %w", following.ID, err)
 		}
 
-		// if mapset doesnt exist in db or doesnt have genre/lang data, fetch Extended info
+		// fill genre/lang and comments count, reusing db values where they are still fresh
+		if err := uc.resolveExtendedInfo(ctx, stats, dbUserMapsets, userMapsets); err != nil {
+			return err
+		}
+
 		for _, mapset := range userMapsets {
-			// if mapset does not exist in db, then get Extended info like genre, language
-			if !containsMapset(dbUserMapsets, mapset.Id) {
-				langGenreInfo, err := uc.osuApi.GetMapsetExtended(ctx, strconv.Itoa(mapset.Id))
-				if err != nil {
-					return fmt.Errorf("failed to get mapset extended info from api, mapset id: %v, err: %w", mapset.Id, err)
-				}
-
-				mapset.Genre = langGenreInfo.Genre.Name
-				mapset.Language = langGenreInfo.Language.Name
-			} else {
-				//if mapset exist in db but doesnt have genre/lang data, then also fetch Extended info
-				dbMapset := getMapsetByID(dbUserMapsets, mapset.Id)
-				if dbMapset.Genre == "" || dbMapset.Language == "" {
-					langGenreInfo, err := uc.osuApi.GetMapsetExtended(ctx, strconv.Itoa(mapset.Id))
-					if err != nil {
-						return fmt.Errorf("failed to get mapset extended info from api, mapset id: %v, err: %w", mapset.Id, err)
-					}
-
-					mapset.Genre = langGenreInfo.Genre.Name
-					mapset.Language = langGenreInfo.Language.Name
-				}
-			}
+			fetchedMapsets[mapset.Id] = true
+		}
+
+		removed, err := uc.detectRemovedMapsets(ctx, following.ID, dbUserMapsets, userMapsets)
+		if err != nil {
+			return err
 		}
 
-		if err := uc.createOrUpdateData(ctx, following, user, userMapsets); err != nil {
+		if err := uc.createOrUpdateData(ctx, stats, following, user, userMapsets, dbUserMapsets, removed); err != nil {
 			return fmt.Errorf("failed to create or update data, user id: %v, err: %w", following.ID, err)
 		}
 	}
 
+	if err := uc.trackWatchedMapsets(ctx, lg, stats, watchedMapsetIDs, fetchedMapsets); err != nil {
+		return fmt.Errorf("failed to track watched mapsets: %w", err)
+	}
+
+	if err := uc.updateTrending(ctx, stats); err != nil {
+		return fmt.Errorf("failed to update trending mapsets: %w", err)
+	}
+
+	if err := uc.detectAnomalies(ctx, stats); err != nil {
+		return fmt.Errorf("failed to detect anomalies: %w", err)
+	}
+
 	elapsed := time.Since(startTime)
 	reqs := uc.osuApi.GetOutgoingRequestCount()
 	avgReqsPerMin := float64(reqs) / elapsed.Minutes()
 
 	lg.Infof("Sent %v requests to api in %v minutes", reqs, elapsed.Minutes())
 	lg.Infof("Average requests per minute: %f", avgReqsPerMin)
+	stats.log(lg)
 
 	uc.osuApi.ResetOutgoingRequestCount()
 
@@ -100,12 +147,19 @@ func (uc *UseCase) Track(
 
 func (uc *UseCase) createOrUpdateData(
 	ctx context.Context,
+	stats *runStats,
 	following *model.Following,
 	user *osuapi.User,
 	userMapsets []*osuapi.MapsetExtended,
+	dbUserMapsets []*model.Mapset,
+	removed removedMapsets,
 ) error {
 	// create/update data in db
+	before := *stats
 	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		// drop counters and events collected by failed attempt if transaction is retried
+		*stats = before
+
 		userExists, err := uc.user.Exists(ctx, tx, user.ID)
 		if err != nil {
 			return err
@@ -113,21 +167,29 @@ func (uc *UseCase) createOrUpdateData(
 
 		if userExists {
 			// update
-			err := uc.updateUserCard(ctx, tx, user, userMapsets)
+			err := uc.updateUserCard(ctx, tx, stats, user, userMapsets, dbUserMapsets)
 			if err != nil {
 				return fmt.Errorf("failed to update user card, user id: %v, err: %w", user.ID, err)
 			}
 		} else {
 			// create
-			err := uc.createUserCard(ctx, tx, user, userMapsets)
+			err := uc.createUserCard(ctx, tx, stats, user, userMapsets)
 			if err != nil {
 				return fmt.Errorf("failed to create user card, user id: %v, err: %w", user.ID, err)
 			}
 		}
 
-		err = uc.following.SetLastFetchedForUser(ctx, tx, following.Username, time.Now().UTC())
+		err = uc.markRemovedMapsets(ctx, tx, stats, removed)
 		if err != nil {
-			return fmt.Errorf("failed to set last fetched for following %v: %w", following.Username, err)
+			return fmt.Errorf("failed to mark removed mapsets, user id: %v, err: %w", user.ID, err)
+		}
+
+		// users that are only watched have no following row to update
+		if following.Username != "" {
+			err = uc.following.SetLastFetchedForUser(ctx, tx, following.Username, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("failed to set last fetched for following %v: %w", following.Username, err)
+			}
 		}
 
 		return nil
@@ -0,0 +1,115 @@
+package track
+
+import (
+	"context"
+	"fmt"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"sort"
+	"time"
+)
+
+// trendingWindowSlack lets a stats entry tracked slightly before the period start still be used as its baseline
+const trendingWindowSlack = time.Hour
+
+const day = 24 * time.Hour
+
+// updateTrending ranks all active mapsets by playcount gain for every configured period,
+// done once per run so trending requests only read precomputed rows
+func (uc *UseCase) updateTrending(ctx context.Context, stats *runStats) error {
+	now := time.Now().UTC()
+
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		mapsets, err := uc.mapset.List(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		var trending []*model.TrendingMapset
+		for _, mapset := range mapsets {
+			if mapset.RemovalStatus != string(model.MapsetActive) {
+				continue
+			}
+
+			mapsetStats, err := mappers.MapStatsJSONToMapsetStats(mapset.MapsetStats)
+			if err != nil {
+				return fmt.Errorf("failed to map stats of mapset %v: %w", mapset.ID, err)
+			}
+
+			for _, periodDays := range uc.cfg.TrendingPeriods {
+				gain, ok := playcountGain(mapsetStats, periodDays)
+				if !ok {
+					continue
+				}
+
+				trending = append(trending, &model.TrendingMapset{
+					PeriodDays:    periodDays,
+					MapsetID:      mapset.ID,
+					PlaycountGain: gain,
+					RelativeGain:  relativeGain(gain, mapset.LastPlaycount, mapsetAge(mapset, now), periodDays),
+					ComputedAt:    now,
+				})
+			}
+		}
+
+		stats.trendingMapsets = len(trending)
+
+		return uc.mapset.ReplaceTrending(ctx, tx, trending...)
+	})
+}
+
+// playcountGain is the difference between the latest stats entry and the earliest one inside the period,
+// false when there are no two entries to compare
+func playcountGain(stats model.MapsetStats, periodDays int) (int, bool) {
+	times := make([]time.Time, 0, len(stats))
+	for t := range stats {
+		times = append(times, t)
+	}
+
+	if len(times) < 2 {
+		return 0, false
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	latest := times[len(times)-1]
+	periodStart := latest.Add(-time.Duration(periodDays)*day - trendingWindowSlack)
+
+	baseline := sort.Search(len(times), func(i int) bool {
+		return !times[i].Before(periodStart)
+	})
+	if baseline == len(times)-1 {
+		return 0, false
+	}
+
+	return stats[latest].Playcount - stats[times[baseline]].Playcount, true
+}
+
+// relativeGain compares gain to the average gain over the same period during the whole mapset lifetime,
+// 1 means mapset is as popular as usual, higher values mean it gets played more than its age suggests
+func relativeGain(gain int, playcount int, age time.Duration, periodDays int) float64 {
+	ageDays := age.Hours() / 24
+	if ageDays < float64(periodDays) {
+		ageDays = float64(periodDays)
+	}
+
+	expected := float64(playcount) / ageDays * float64(periodDays)
+	if expected == 0 {
+		return 0
+	}
+
+	return float64(gain) / expected
+}
+
+func mapsetAge(mapset *model.Mapset, now time.Time) time.Duration {
+	// submitted date is filled by tracking runs, fall back to when we first saw the mapset
+	submitted := mapset.SubmittedDate
+	if submitted.IsZero() {
+		submitted = mapset.CreatedAt
+	}
+
+	return now.Sub(submitted)
+}
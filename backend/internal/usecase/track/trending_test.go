@@ -0,0 +1,44 @@
+package track
+
+import (
+	"github.com/stretchr/testify/assert"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"testing"
+	"time"
+)
+
+func Test_playcountGain(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := model.MapsetStats{
+		start:                                 {Playcount: 100},
+		start.Add(24*time.Hour - time.Minute): {Playcount: 150},
+		start.Add(48 * time.Hour):             {Playcount: 170},
+		start.Add(72*time.Hour + time.Minute): {Playcount: 230},
+	}
+
+	gain, ok := playcountGain(stats, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 60, gain)
+
+	gain, ok = playcountGain(stats, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 80, gain)
+
+	// history is shorter than period, whole history is used
+	gain, ok = playcountGain(stats, 7)
+	assert.True(t, ok)
+	assert.Equal(t, 130, gain)
+
+	_, ok = playcountGain(model.MapsetStats{start: {Playcount: 100}}, 1)
+	assert.False(t, ok)
+}
+
+func Test_relativeGain(t *testing.T) {
+	// 1000 plays over 100 days is 70 plays a week on average
+	assert.InDelta(t, 2.0, relativeGain(140, 1000, 100*24*time.Hour, 7), 1e-9)
+
+	// mapsets younger than period are compared to their whole lifetime
+	assert.InDelta(t, 1.0, relativeGain(100, 100, 24*time.Hour, 7), 1e-9)
+
+	assert.Equal(t, 0.0, relativeGain(0, 0, time.Hour, 7))
+}
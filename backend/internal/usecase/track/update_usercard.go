@@ -7,13 +7,16 @@ import (
 	"playcount-monitor-backend/internal/service/osuapi"
 	"playcount-monitor-backend/internal/usecase/command"
 	"playcount-monitor-backend/internal/usecase/mappers"
+	"time"
 )
 
 func (uc *UseCase) updateUserCard(
 	ctx context.Context,
 	tx txmanager.Tx,
+	stats *runStats,
 	user *osuapi.User,
 	userMapsets []*osuapi.MapsetExtended,
+	dbUserMapsets []*model.Mapset,
 ) error {
 	// create cmd
 	cmd := &command.UpdateUserCardCommand{
@@ -44,110 +47,122 @@ func (uc *UseCase) updateUserCard(
 		return err
 	}
 
-	// update user mapsets
-	for _, ms := range cmd.Mapsets {
-		var mapsetExist bool
-		mapsetExist, err = uc.mapset.Exists(ctx, tx, ms.Id)
-		if err != nil {
-			return err
-		}
-
-		if mapsetExist {
-			err = uc.updateExistingMapsetAndItsBeatmaps(ctx, tx, ms)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = uc.createNewMapsetWithItsBeatmaps(ctx, tx, ms)
-			if err != nil {
-				return err
-			}
-		}
-
-	}
-
-	return nil
-}
-
-func (uc *UseCase) updateExistingMapsetAndItsBeatmaps(
-	ctx context.Context,
-	tx txmanager.Tx,
-	ms *command.UpdateMapsetCommand,
-) error {
-	// update mapset
-	existingMapset, err := uc.mapset.Get(ctx, tx, ms.Id)
+	// upsert user mapsets and their beatmaps, stats history is appended in db
+	mapsets, beatmaps, err := mapUpdateMapsetCommandsToModels(cmd.Mapsets)
 	if err != nil {
 		return err
 	}
 
-	var newMapset *model.Mapset
-	newMapset, err = mappers.MapUpdateMapsetCommandToMapsetModel(ms)
+	now := time.Now().UTC()
+	milestones, err := uc.detectMilestones(existingUser, newUser, dbUserMapsets, mapsets, now)
 	if err != nil {
 		return err
 	}
 
-	newMapset.MapsetStats, err = mappers.AppendNewMapsetStats(existingMapset.MapsetStats, newMapset.MapsetStats)
+	// milestones reached before are skipped, only new ones are counted and notified about
+	milestones, err = uc.milestone.Create(ctx, tx, milestones...)
 	if err != nil {
 		return err
 	}
-	newMapset.CreatedAt = existingMapset.CreatedAt
+	stats.milestonesReached += len(milestones)
 
-	err = uc.mapset.Update(ctx, tx, newMapset)
+	createdMapsets, err := uc.upsertMapsetsWithBeatmaps(ctx, tx, stats, mapsets, beatmaps)
 	if err != nil {
 		return err
 	}
 
-	// update mapset beatmaps
-	for _, bm := range ms.Beatmaps {
-		var beatmapExist bool
-		beatmapExist, err = uc.beatmap.Exists(ctx, tx, bm.Id)
-		if err != nil {
-			return err
-		}
-		if beatmapExist {
-			err = uc.updateExistingBeatmap(ctx, tx, bm)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = uc.createNewBeatmap(ctx, tx, bm)
-			if err != nil {
-				return err
-			}
-		}
+	mapsetEvents := detectMapsetEvents(dbUserMapsets, createdMapsets, mapsets, now)
+	if err = uc.mapsetEvent.Create(ctx, tx, mapsetEvents...); err != nil {
+		return err
 	}
+
+	stats.events = append(stats.events, mapsetEventNotifications(mapsetEvents, mapsets)...)
+	stats.events = append(stats.events, milestoneNotifications(newUser, milestones, mapsets)...)
+
 	return nil
 }
 
-func (uc *UseCase) updateExistingBeatmap(
+func (uc *UseCase) upsertMapsetsWithBeatmaps(
 	ctx context.Context,
 	tx txmanager.Tx,
-	bm *command.UpdateBeatmapCommand,
-) error {
-	existingBeatmap, err := uc.beatmap.Get(ctx, tx, bm.Id)
-	if err != nil {
-		return err
+	stats *runStats,
+	mapsets []*model.Mapset,
+	beatmaps []*model.Beatmap,
+) ([]*model.Mapset, error) {
+	// single upsert can't touch the same row twice, api can list a mapset twice if its status changes mid-fetch
+	mapsets = uniqueByID(mapsets, func(m *model.Mapset) int { return m.ID })
+	beatmaps = uniqueByID(beatmaps, func(b *model.Beatmap) int { return b.ID })
+
+	mapsetIDs := make([]int, len(mapsets))
+	for i, ms := range mapsets {
+		mapsetIDs[i] = ms.ID
 	}
 
-	var newBeatmap *model.Beatmap
-	newBeatmap, err = mappers.MapUpdateBeatmapCommandToBeatmapModel(bm)
+	existingMapsetIDs, err := uc.mapset.ListExistingIDs(ctx, tx, mapsetIDs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	newBeatmap.BeatmapStats, err = mappers.AppendNewBeatmapStats(
-		existingBeatmap.BeatmapStats,
-		newBeatmap.BeatmapStats,
-	)
-	if err != nil {
-		return err
+	beatmapIDs := make([]int, len(beatmaps))
+	for i, bm := range beatmaps {
+		beatmapIDs[i] = bm.ID
 	}
-	newBeatmap.CreatedAt = existingBeatmap.CreatedAt
 
-	err = uc.beatmap.Update(ctx, tx, newBeatmap)
+	existingBeatmapIDs, err := uc.beatmap.ListExistingIDs(ctx, tx, beatmapIDs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if err = uc.mapset.Upsert(ctx, tx, mapsets...); err != nil {
+		return nil, err
+	}
+
+	if err = uc.beatmap.Upsert(ctx, tx, beatmaps...); err != nil {
+		return nil, err
+	}
+
+	stats.mapsetsCreated += len(mapsets) - len(existingMapsetIDs)
+	stats.mapsetsUpdated += len(existingMapsetIDs)
+	stats.beatmapsCreated += len(beatmaps) - len(existingBeatmapIDs)
+	stats.beatmapsUpdated += len(existingBeatmapIDs)
+
+	return createdMapsets(mapsets, existingMapsetIDs), nil
+}
+
+func createdMapsets(mapsets []*model.Mapset, existingIDs []int) []*model.Mapset {
+	existing := make(map[int]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+
+	var created []*model.Mapset
+	for _, mapset := range mapsets {
+		if !existing[mapset.ID] {
+			created = append(created, mapset)
+		}
+	}
+
+	return created
+}
+
+func mapUpdateMapsetCommandsToModels(cmds []*command.UpdateMapsetCommand) ([]*model.Mapset, []*model.Beatmap, error) {
+	mapsets := make([]*model.Mapset, 0, len(cmds))
+	beatmaps := make([]*model.Beatmap, 0)
+	for _, ms := range cmds {
+		mapset, err := mappers.MapUpdateMapsetCommandToMapsetModel(ms)
+		if err != nil {
+			return nil, nil, err
+		}
+		mapsets = append(mapsets, mapset)
+
+		for _, bm := range ms.Beatmaps {
+			beatmap, err := mappers.MapUpdateBeatmapCommandToBeatmapModel(bm)
+			if err != nil {
+				return nil, nil, err
+			}
+			beatmaps = append(beatmaps, beatmap)
+		}
+	}
+
+	return mapsets, beatmaps, nil
 }
@@ -0,0 +1,131 @@
+package track
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/notifier"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"testing"
+	"time"
+)
+
+func Test_uniqueByID(t *testing.T) {
+	first := &model.Beatmap{ID: 1, Version: "first"}
+	second := &model.Beatmap{ID: 2}
+	relisted := &model.Beatmap{ID: 1, Version: "relisted"}
+
+	unique := uniqueByID([]*model.Beatmap{first, second, relisted}, func(b *model.Beatmap) int { return b.ID })
+
+	assert.Equal(t, []*model.Beatmap{relisted, second}, unique)
+	assert.Empty(t, uniqueByID([]*model.Beatmap{}, func(b *model.Beatmap) int { return b.ID }))
+}
+
+func TestUseCase_upsertMapsetsWithBeatmaps(t *testing.T) {
+	mapsets := &fakeMapsetStore{existing: []int{1}}
+	beatmaps := &fakeBeatmapStore{existing: []int{10}}
+	uc := &UseCase{mapset: mapsets, beatmap: beatmaps}
+
+	// api listed mapset 1 twice, the later listing wins
+	stale := &model.Mapset{ID: 1, Status: "pending"}
+	fresh := &model.Mapset{ID: 1, Status: "ranked"}
+	created := &model.Mapset{ID: 2, Status: "wip"}
+
+	stats := &runStats{}
+	createdMapsets, err := uc.upsertMapsetsWithBeatmaps(context.Background(), nil, stats,
+		[]*model.Mapset{stale, created, fresh},
+		[]*model.Beatmap{{ID: 10, MapsetID: 1}, {ID: 20, MapsetID: 2}, {ID: 10, MapsetID: 1}},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*model.Mapset{fresh, created}, mapsets.upserted)
+	assert.Len(t, beatmaps.upserted, 2)
+	assert.Equal(t, []*model.Mapset{created}, createdMapsets)
+	assert.Equal(t, runStats{mapsetsCreated: 1, mapsetsUpdated: 1, beatmapsCreated: 1, beatmapsUpdated: 1}, *stats)
+}
+
+func TestUseCase_createOrUpdateData_RetryKeepsStats(t *testing.T) {
+	lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := &fakeUserStore{users: map[int]*model.User{
+		7: {ID: 7, Username: "mapper", UserStats: repository.JSON(`{"2024-01-01T00:00:00Z":{"play_count":10}}`)},
+	}}
+	mapsets := &fakeMapsetStore{existing: []int{1}}
+	beatmaps := &fakeBeatmapStore{existing: []int{10}}
+
+	uc := &UseCase{
+		cfg:         &config.Config{},
+		txm:         txmanagertest.TxManager{Retries: 1},
+		user:        users,
+		mapset:      mapsets,
+		beatmap:     beatmaps,
+		following:   fakeFollowingStore{},
+		milestone:   &fakeMilestoneStore{stored: make(map[string]bool)},
+		mapsetEvent: fakeMapsetEventStore{},
+	}
+
+	apiMapsets := []*osuapi.MapsetExtended{
+		{Mapset: &osuapi.Mapset{Id: 1, UserId: 7, Status: "ranked", LastUpdated: lastUpdated,
+			Beatmaps: []*osuapi.Beatmap{{Id: 10, BeatmapsetId: 1}}}},
+		{Mapset: &osuapi.Mapset{Id: 2, UserId: 7, Status: "wip", LastUpdated: lastUpdated,
+			Beatmaps: []*osuapi.Beatmap{{Id: 20, BeatmapsetId: 2}, {Id: 21, BeatmapsetId: 2}}}},
+	}
+	dbMapsets := []*model.Mapset{{ID: 1, UserID: 7, Status: "ranked", RemovalStatus: string(model.MapsetActive)}}
+
+	stats := &runStats{}
+	err := uc.createOrUpdateData(context.Background(), stats, &model.Following{ID: 7, Username: "mapper"},
+		&osuapi.User{ID: 7, Username: "mapper"}, apiMapsets, dbMapsets, removedMapsets{model.MapsetTransferred: {3}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.mapsetsCreated)
+	assert.Equal(t, 1, stats.mapsetsUpdated)
+	assert.Equal(t, 2, stats.beatmapsCreated)
+	assert.Equal(t, 1, stats.beatmapsUpdated)
+	assert.Equal(t, 1, stats.mapsetsTransferred)
+	require.Len(t, stats.events, 1)
+	assert.Equal(t, notifier.EventNewMapset, stats.events[0].Type)
+}
+
+func TestUseCase_createOrUpdateData_NotifiesOnlyNewMilestones(t *testing.T) {
+	lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uc := &UseCase{
+		cfg: &config.Config{MilestoneMapsetPlaycounts: []int{100}},
+		txm: txmanagertest.TxManager{},
+		user: &fakeUserStore{users: map[int]*model.User{
+			7: {ID: 7, Username: "mapper", UserStats: repository.JSON(`{}`)},
+		}},
+		mapset:      &fakeMapsetStore{existing: []int{1}},
+		beatmap:     &fakeBeatmapStore{},
+		following:   fakeFollowingStore{},
+		milestone:   &fakeMilestoneStore{stored: make(map[string]bool)},
+		mapsetEvent: fakeMapsetEventStore{},
+	}
+
+	apiMapsets := []*osuapi.MapsetExtended{{Mapset: &osuapi.Mapset{
+		Id: 1, UserId: 7, Status: "ranked", LastUpdated: lastUpdated, PlayCount: 150,
+	}}}
+
+	// both runs compare against the same snapshot, e.g. when the run after the first one failed to store it
+	for run, expectedEvents := range []int{1, 0} {
+		dbMapsets := []*model.Mapset{{
+			ID: 1, UserID: 7, Status: "ranked", LastPlaycount: 50, RemovalStatus: string(model.MapsetActive),
+		}}
+
+		stats := &runStats{}
+		err := uc.createOrUpdateData(context.Background(), stats, &model.Following{ID: 7, Username: "mapper"},
+			&osuapi.User{ID: 7, Username: "mapper"}, apiMapsets, dbMapsets, removedMapsets{})
+		require.NoError(t, err)
+
+		var milestoneEvents int
+		for _, event := range stats.events {
+			if event.Type == notifier.EventMilestone {
+				milestoneEvents++
+			}
+		}
+		assert.Equal(t, expectedEvents, milestoneEvents, "run %d", run)
+		assert.Equal(t, expectedEvents, stats.milestonesReached, "run %d", run)
+	}
+}
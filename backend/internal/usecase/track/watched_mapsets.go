@@ -0,0 +1,134 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"strconv"
+	"time"
+)
+
+// listWatchedMapsetIDs lists mapsets watched on their own and in any watchlist, each only once
+func (uc *UseCase) listWatchedMapsetIDs(ctx context.Context, tx txmanager.Tx) ([]int, error) {
+	watchedIDs, err := uc.watchedMapset.ListIDs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlistIDs, err := uc.watchlist.ListWatchedIDs(ctx, tx, model.WatchlistMapset)
+	if err != nil {
+		return nil, err
+	}
+
+	return uniqueByID(append(watchedIDs, watchlistIDs...), func(id int) int { return id }), nil
+}
+
+// trackWatchedMapsets fetches watched mapsets one by one, skipping ones already fetched with their host this run
+func (uc *UseCase) trackWatchedMapsets(
+	ctx context.Context,
+	lg *log.Logger,
+	stats *runStats,
+	watchedIDs []int,
+	fetched map[int]bool,
+) error {
+	ids := make([]int, 0, len(watchedIDs))
+	for _, id := range watchedIDs {
+		if !fetched[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var dbMapsets []*model.Mapset
+	if err := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		dbMapsets, err = uc.mapset.ListByIDs(ctx, tx, ids...)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		lg.Infof("fetching watched mapset with id %v, %v/%v", id, i, len(ids))
+
+		apiMapset, err := uc.osuApi.GetMapset(ctx, strconv.Itoa(id))
+		if errors.Is(err, osuapi.ErrNotFound) {
+			lg.Warnf("watched mapset with id %v not found in api", id)
+			if err := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+				return uc.markRemovedMapsets(ctx, tx, stats, allMapsetsRemoved(existingMapsets(dbMapsets, id)))
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get watched mapset from api, mapset id: %v, err: %w", id, err)
+		}
+
+		apiMapsets := []*osuapi.MapsetExtended{{Mapset: apiMapset}}
+		if err := uc.resolveExtendedInfo(ctx, stats, dbMapsets, apiMapsets); err != nil {
+			return err
+		}
+
+		if err := uc.updateWatchedMapset(ctx, stats, apiMapsets, existingMapsets(dbMapsets, id)); err != nil {
+			return fmt.Errorf("failed to update watched mapset, mapset id: %v, err: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *UseCase) updateWatchedMapset(
+	ctx context.Context,
+	stats *runStats,
+	apiMapsets []*osuapi.MapsetExtended,
+	dbMapsets []*model.Mapset,
+) error {
+	mapsets, beatmaps, err := mapUpdateMapsetCommandsToModels(mapOsuApiMapsetsToUpdateMapsetCommands(apiMapsets))
+	if err != nil {
+		return err
+	}
+
+	eventsBefore := len(stats.events)
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		// drop events collected by failed attempt if transaction is retried
+		stats.events = stats.events[:eventsBefore]
+
+		created, err := uc.upsertMapsetsWithBeatmaps(ctx, tx, stats, mapsets, beatmaps)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		events := detectMapsetEvents(dbMapsets, created, mapsets, now)
+		if err = uc.mapsetEvent.Create(ctx, tx, events...); err != nil {
+			return err
+		}
+		stats.events = append(stats.events, mapsetEventNotifications(events, mapsets)...)
+
+		// it updates nothing for mapsets watched only through watchlist
+		for _, mapset := range mapsets {
+			if err := uc.watchedMapset.SetLastFetched(ctx, tx, mapset.ID, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// existingMapsets returns stored mapset with id as a slice, empty when it isn't stored yet
+func existingMapsets(dbMapsets []*model.Mapset, id int) []*model.Mapset {
+	if mapset := getMapsetByID(dbMapsets, id); mapset != nil {
+		return []*model.Mapset{mapset}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package track
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"testing"
+)
+
+func TestUseCase_trackWatchedMapsets_APIErrorStoresNothing(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError} {
+		mapsets := &fakeMapsetStore{listed: []*model.Mapset{{ID: 1, RemovalStatus: string(model.MapsetActive)}}}
+		watched := &fakeWatchedMapsetStore{}
+		uc := &UseCase{
+			txm:           txmanagertest.TxManager{},
+			osuApi:        newStubOsuAPI(t, status, `{"error":"Too Many Attempts."}`),
+			mapset:        mapsets,
+			watchedMapset: watched,
+		}
+
+		err := uc.trackWatchedMapsets(context.Background(), log.New(), &runStats{}, []int{1}, map[int]bool{})
+		assert.Error(t, err, "status %v", status)
+		assert.Empty(t, mapsets.upserted, "status %v", status)
+		assert.Empty(t, mapsets.removed, "status %v", status)
+		assert.Empty(t, watched.lastFetched, "status %v", status)
+	}
+}
@@ -13,16 +13,21 @@ import (
 type userStore interface {
 	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.User, error)
 	GetByName(ctx context.Context, tx txmanager.Tx, name string) (*model.User, error)
-	List(ctx context.Context, tx txmanager.Tx) ([]*model.User, error)
 	Exists(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+	ListPage(ctx context.Context, tx txmanager.Tx, watchlistID int, page model.PageRequest) ([]*model.User, *model.Page, error)
+}
+
+type watchlistStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error)
 }
 
 type UseCase struct {
-	cfg    *config.Config
-	lg     *log.Logger
-	txm    txmanager.TxManager
-	user   userStore
-	osuApi osuapi.Interface
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	user      userStore
+	watchlist watchlistStore
+	osuApi    osuapi.Interface
 }
 
 func New(
@@ -30,13 +35,15 @@ func New(
 	lg *log.Logger,
 	txm txmanager.TxManager,
 	user userStore,
+	watchlist watchlistStore,
 	osuApi osuapi.Interface,
 ) *UseCase {
 	return &UseCase{
-		cfg:    cfg,
-		lg:     lg,
-		txm:    txm,
-		user:   user,
-		osuApi: osuApi,
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		user:      user,
+		watchlist: watchlist,
+		osuApi:    osuApi,
 	}
 }
@@ -2,10 +2,15 @@ package userprovide
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
 	"strconv"
 )
 
@@ -43,8 +48,11 @@ func (uc *UseCase) Get(
 	var userDto *dto.User
 	if !userExists {
 		apiUser, err := uc.osuApi.GetUser(ctx, strconv.Itoa(id))
+		if errors.Is(err, osuapi.ErrNotFound) {
+			return nil, domainerror.NotFound("user %v not found", id)
+		}
 		if err != nil {
-			return nil, err
+			return nil, domainerror.Unavailable(err, "failed to fetch user %v from osu! api", id)
 		}
 
 		userDto, err = MapOsuApiUserToUserDTO(apiUser)
@@ -59,6 +67,9 @@ func (uc *UseCase) Get(
 		}
 	}
 
+	// forecast uses whole history, so it goes before stats are trimmed
+	userDto.Forecast = mappers.MapUserStatsToForecast(userDto.UserStats)
+
 	mappers.KeepLastNKeyValuesFromStats(userDto.UserStats, statsMaxElements)
 	return userDto, nil
 }
@@ -71,6 +82,9 @@ func (uc *UseCase) GetByName(
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		var err error
 		user, err = uc.user.GetByName(ctx, tx, name)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("user %q not found", name)
+		}
 		if err != nil {
 			return err
 		}
@@ -90,18 +104,44 @@ func (uc *UseCase) GetByName(
 	return userDto, nil
 }
 
+// ListCommand narrows list to mappers in watchlist of AccountID when WatchlistID is set
+type ListCommand struct {
+	pagination.Query
+	AccountID   int
+	WatchlistID int
+}
+
+type ListResponse struct {
+	Users []*dto.User
+	Page  *dto.Page
+}
+
 func (uc *UseCase) List(
 	ctx context.Context,
-) ([]*dto.User, error) {
+	cmd *ListCommand,
+) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
 	var users []*model.User
+	var page *model.Page
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
-		var err error
-		users, err = uc.user.List(ctx, tx)
-		if err != nil {
-			return err
+		if cmd.WatchlistID != 0 {
+			watchlist, err := uc.watchlist.Get(ctx, tx, cmd.WatchlistID)
+			if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && watchlist.AccountID != cmd.AccountID) {
+				return domainerror.NotFound("watchlist %v not found", cmd.WatchlistID)
+			}
+			if err != nil {
+				return err
+			}
 		}
 
-		return nil
+		var err error
+		users, page, err = uc.user.ListPage(ctx, tx, cmd.WatchlistID, pageReq)
+
+		return pagination.Error(err)
 	})
 	if txErr != nil {
 		return nil, txErr
@@ -116,5 +156,8 @@ func (uc *UseCase) List(
 		mappers.KeepLastNKeyValuesFromStats(user.UserStats, statsMaxElements)
 	}
 
-	return outUsers, nil
+	return &ListResponse{
+		Users: outUsers,
+		Page:  mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"playcount-monitor-backend/internal/config"
 	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/usecase/cache"
 )
 
 type userStore interface {
@@ -19,18 +20,39 @@ type mapsetStore interface {
 	ListStatusesForUser(ctx context.Context, tx txmanager.Tx, userID int) ([]string, error)
 }
 
-type beatmapStore interface {
-	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Beatmap, error)
-	ListForMapset(ctx context.Context, tx txmanager.Tx, mapsetID int) ([]*model.Beatmap, error)
+type listingStore interface {
+	Attach(ctx context.Context, tx txmanager.Tx, stats model.ListingStats, mapsets ...*model.Mapset) ([]*model.MapsetListing, error)
+}
+
+type anomalyStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.AnomalyFilter,
+		page model.PageRequest,
+	) ([]*model.Anomaly, *model.Page, error)
+}
+
+type milestoneStore interface {
+	List(
+		ctx context.Context,
+		tx txmanager.Tx,
+		filter model.MilestoneFilter,
+		page model.PageRequest,
+	) ([]*model.Milestone, *model.Page, error)
 }
 
 type UseCase struct {
-	cfg     *config.Config
-	lg      *log.Logger
-	txm     txmanager.TxManager
-	user    userStore
-	mapset  mapsetStore
-	beatmap beatmapStore
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	user      userStore
+	mapset    mapsetStore
+	listing   listingStore
+	anomaly   anomalyStore
+	milestone milestoneStore
+	// cache keeps results until the next tracking run, nil disables it
+	cache cache.Interface
 }
 
 func New(
@@ -39,14 +61,20 @@ func New(
 	txm txmanager.TxManager,
 	user userStore,
 	mapset mapsetStore,
-	beatmap beatmapStore,
+	listing listingStore,
+	anomaly anomalyStore,
+	milestone milestoneStore,
+	cache cache.Interface,
 ) *UseCase {
 	return &UseCase{
-		cfg:     cfg,
-		lg:      lg,
-		txm:     txm,
-		user:    user,
-		mapset:  mapset,
-		beatmap: beatmap,
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		user:      user,
+		mapset:    mapset,
+		listing:   listing,
+		anomaly:   anomaly,
+		milestone: milestone,
+		cache:     cache,
 	}
 }
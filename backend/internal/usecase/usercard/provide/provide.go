@@ -2,15 +2,28 @@ package usercardprovide
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
 	"playcount-monitor-backend/internal/database/txmanager"
 	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/cache"
+	"playcount-monitor-backend/internal/usecase/domainerror"
 	"playcount-monitor-backend/internal/usecase/mappers"
 )
 
 const mapsetsPerPage = 50
 const statsMaxElements = 7
+const anomaliesMaxElements = 10
+const milestonesMaxElements = 10
 
-func (uc *UseCase) Get(
+func (uc *UseCase) Get(ctx context.Context, userID int, page int) (*dto.UserCard, error) {
+	return cache.Fetch(uc.cache, cache.Key("usercard.Get", userID, page), func() (*dto.UserCard, error) {
+		return uc.get(ctx, userID, page)
+	})
+}
+
+func (uc *UseCase) get(
 	ctx context.Context,
 	userID int,
 	page int,
@@ -19,6 +32,9 @@ func (uc *UseCase) Get(
 	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
 		// get user
 		user, err := uc.user.Get(ctx, tx, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerror.NotFound("user %v not found", userID)
+		}
 		if err != nil {
 			return err
 		}
@@ -34,19 +50,39 @@ func (uc *UseCase) Get(
 			return err
 		}
 
-		// for each mapset get its beatmaps and map to DTO
-		for _, mapset := range mapsets {
-			beatmaps, err := uc.beatmap.ListForMapset(ctx, tx, mapset.ID)
-			if err != nil {
-				return err
-			}
+		// beatmaps of the whole page are read at once
+		listings, err := uc.listing.Attach(ctx, tx, model.ListingStatsHistory, mapsets...)
+		if err != nil {
+			return err
+		}
 
-			mapsetWithMaps, err := mappers.MapMapsetModelToMapsetDTO(mapset, beatmaps)
-			if err != nil {
-				return err
-			}
-			userCard.Mapsets = append(userCard.Mapsets, mapsetWithMaps)
+		userCard.Mapsets, err = mappers.MapMapsetListingsToMapsetDTOs(listings)
+		if err != nil {
+			return err
+		}
+
+		// latest anomalies, full list is available at anomalies endpoint
+		anomalies, _, err := uc.anomaly.List(
+			ctx,
+			tx,
+			model.AnomalyFilter{model.AnomalyUserIDField: userID},
+			model.PageRequest{Limit: anomaliesMaxElements},
+		)
+		if err != nil {
+			return err
+		}
+		userCard.Anomalies = mappers.MapAnomalyModelsToAnomalyDTOs(anomalies)
+
+		milestones, _, err := uc.milestone.List(
+			ctx,
+			tx,
+			model.MilestoneFilter{model.MilestoneUserIDField: userID},
+			model.PageRequest{Limit: milestonesMaxElements},
+		)
+		if err != nil {
+			return err
 		}
+		userCard.Milestones = mappers.MapMilestoneModelsToMilestoneDTOs(milestones)
 
 		return nil
 	})
@@ -0,0 +1,43 @@
+package watchedmapsetmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/service/osuapi"
+)
+
+type watchedMapsetStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, watched *model.WatchedMapset) error
+	Delete(ctx context.Context, tx txmanager.Tx, id int) (bool, error)
+}
+
+type mapsetSource interface {
+	GetMapset(ctx context.Context, mapsetID string) (*osuapi.Mapset, error)
+}
+
+type UseCase struct {
+	cfg           *config.Config
+	lg            *log.Logger
+	txm           txmanager.TxManager
+	watchedMapset watchedMapsetStore
+	osuApi        mapsetSource
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	watchedMapset watchedMapsetStore,
+	osuApi mapsetSource,
+) *UseCase {
+	return &UseCase{
+		cfg:           cfg,
+		lg:            lg,
+		txm:           txm,
+		watchedMapset: watchedMapset,
+		osuApi:        osuApi,
+	}
+}
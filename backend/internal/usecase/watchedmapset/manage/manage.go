@@ -0,0 +1,65 @@
+package watchedmapsetmanage
+
+import (
+	"context"
+	"errors"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"strconv"
+	"time"
+)
+
+// Create starts tracking mapset without following its host, mapset is looked up in osu! first
+// so typos don't end up fetched on every run. it is stored by tracker on its next run
+func (uc *UseCase) Create(ctx context.Context, id int) (*dto.WatchedMapset, error) {
+	if id <= 0 {
+		return nil, domainerror.Validation("invalid mapset id %v", id)
+	}
+
+	_, err := uc.osuApi.GetMapset(ctx, strconv.Itoa(id))
+	if errors.Is(err, osuapi.ErrNotFound) {
+		return nil, domainerror.NotFound("mapset %v not found in osu!", id)
+	}
+	if err != nil {
+		return nil, domainerror.Unavailable(err, "failed to fetch mapset %v from osu! api", id)
+	}
+
+	watched := &model.WatchedMapset{
+		ID:        id,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		err := uc.watchedMapset.Create(ctx, tx, watched)
+		if repository.IsUniqueViolation(err) {
+			return domainerror.Conflict(err, "mapset %v is already watched", id)
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWatchedMapsetModelToWatchedMapsetDTO(watched), nil
+}
+
+// Delete stops tracking mapset, already tracked data is kept
+func (uc *UseCase) Delete(ctx context.Context, id int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		deleted, err := uc.watchedMapset.Delete(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return domainerror.NotFound("mapset %v is not watched", id)
+		}
+
+		return nil
+	})
+}
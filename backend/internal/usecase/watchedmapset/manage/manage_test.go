@@ -0,0 +1,87 @@
+package watchedmapsetmanage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/service/osuapi"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatchedMapsetStore map[int]*model.WatchedMapset
+
+func (s fakeWatchedMapsetStore) Create(_ context.Context, _ txmanager.Tx, watched *model.WatchedMapset) error {
+	if _, ok := s[watched.ID]; ok {
+		return fmt.Errorf("failed to create watched mapset %v: %w", watched.ID, &pgconn.PgError{Code: "23505"})
+	}
+
+	s[watched.ID] = watched
+	return nil
+}
+
+func (s fakeWatchedMapsetStore) Delete(_ context.Context, _ txmanager.Tx, id int) (bool, error) {
+	_, ok := s[id]
+	delete(s, id)
+	return ok, nil
+}
+
+type fakeMapsetSource map[int]error
+
+func (s fakeMapsetSource) GetMapset(_ context.Context, mapsetID string) (*osuapi.Mapset, error) {
+	id, _ := strconv.Atoi(mapsetID)
+	if err, ok := s[id]; ok {
+		return nil, err
+	}
+
+	return &osuapi.Mapset{Id: id}, nil
+}
+
+func newTestUseCase(store fakeWatchedMapsetStore, api fakeMapsetSource) *UseCase {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	return New(&config.Config{}, lg, txmanagertest.TxManager{}, store, api)
+}
+
+func TestCreate(t *testing.T) {
+	store := make(fakeWatchedMapsetStore)
+	uc := newTestUseCase(store, fakeMapsetSource{
+		2: fmt.Errorf("mapset 2: %w", osuapi.ErrNotFound),
+		3: errors.New("connection reset"),
+	})
+	ctx := context.Background()
+
+	watched, err := uc.Create(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, watched.ID)
+	assert.Nil(t, watched.LastFetched)
+	assert.Contains(t, store, 1)
+
+	_, err = uc.Create(ctx, 1)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+
+	_, err = uc.Create(ctx, 2)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+	_, err = uc.Create(ctx, 3)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindUnavailable))
+
+	_, err = uc.Create(ctx, 0)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+
+	require.NoError(t, uc.Delete(ctx, 1))
+	err = uc.Delete(ctx, 1)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+}
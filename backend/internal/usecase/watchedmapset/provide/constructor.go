@@ -0,0 +1,34 @@
+package watchedmapsetprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type watchedMapsetStore interface {
+	List(ctx context.Context, tx txmanager.Tx, page model.PageRequest) ([]*model.WatchedMapset, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg           *config.Config
+	lg            *log.Logger
+	txm           txmanager.TxManager
+	watchedMapset watchedMapsetStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	watchedMapset watchedMapsetStore,
+) *UseCase {
+	return &UseCase{
+		cfg:           cfg,
+		lg:            lg,
+		txm:           txm,
+		watchedMapset: watchedMapset,
+	}
+}
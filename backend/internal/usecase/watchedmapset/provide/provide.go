@@ -0,0 +1,44 @@
+package watchedmapsetprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+type ListCommand struct {
+	pagination.Query
+}
+
+type ListResponse struct {
+	Mapsets []*dto.WatchedMapset
+	Page    *dto.Page
+}
+
+func (uc *UseCase) List(ctx context.Context, cmd *ListCommand) (*ListResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var watched []*model.WatchedMapset
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		watched, page, err = uc.watchedMapset.List(ctx, tx, pageReq)
+
+		return pagination.Error(err)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return &ListResponse{
+		Mapsets: mappers.MapWatchedMapsetModelsToWatchedMapsetDTOs(watched),
+		Page:    mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package watchlistmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type watchlistStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, watchlist *model.Watchlist) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error)
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+	UpsertEntry(ctx context.Context, tx txmanager.Tx, entry *model.WatchlistEntry) error
+	DeleteEntry(ctx context.Context, tx txmanager.Tx, watchlistID int, entityType model.WatchlistEntityType, entityID int) (bool, error)
+	ListEntries(ctx context.Context, tx txmanager.Tx, watchlistIDs ...int) ([]*model.WatchlistEntry, error)
+}
+
+type UseCase struct {
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	watchlist watchlistStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	watchlist watchlistStore,
+) *UseCase {
+	return &UseCase{
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		watchlist: watchlist,
+	}
+}
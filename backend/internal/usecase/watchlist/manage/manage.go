@@ -0,0 +1,190 @@
+package watchlistmanage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNameLength = 100
+	maxNoteLength = 2000
+	maxTags       = 20
+	maxTagLength  = 32
+)
+
+type CreateCommand struct {
+	Name string
+}
+
+// EntryCommand describes watched mapper or mapset, putting it again replaces its note and tags
+type EntryCommand struct {
+	EntityType string
+	EntityID   int
+	Note       string
+	Tags       []string
+}
+
+func (uc *UseCase) Create(ctx context.Context, accountID int, cmd *CreateCommand) (*dto.Watchlist, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, domainerror.Validation("invalid watchlist: name must be 1 to %d characters long", maxNameLength)
+	}
+
+	now := time.Now().UTC()
+	watchlist := &model.Watchlist{
+		AccountID: accountID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		err := uc.watchlist.Create(ctx, tx, watchlist)
+		if repository.IsUniqueViolation(err) {
+			return domainerror.Conflict(err, "watchlist %q already exists", name)
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWatchlistModelToWatchlistDTO(watchlist, nil)
+}
+
+func (uc *UseCase) Delete(ctx context.Context, accountID, id int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		if _, err := uc.getOwned(ctx, tx, accountID, id); err != nil {
+			return err
+		}
+
+		return uc.watchlist.Delete(ctx, tx, id)
+	})
+}
+
+// PutEntry adds mapper or mapset to watchlist and returns watchlist with all its entries
+func (uc *UseCase) PutEntry(ctx context.Context, accountID, id int, cmd *EntryCommand) (*dto.Watchlist, error) {
+	if err := validateEntry(cmd); err != nil {
+		return nil, err
+	}
+
+	tags, err := mappers.MapWatchlistTagsToTagsJSON(cmd.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var watchlist *model.Watchlist
+	var entries []*model.WatchlistEntry
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		watchlist, err = uc.getOwned(ctx, tx, accountID, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		err = uc.watchlist.UpsertEntry(ctx, tx, &model.WatchlistEntry{
+			WatchlistID: id,
+			EntityType:  cmd.EntityType,
+			EntityID:    cmd.EntityID,
+			Note:        cmd.Note,
+			Tags:        tags,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			return err
+		}
+
+		entries, err = uc.watchlist.ListEntries(ctx, tx, id)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWatchlistModelToWatchlistDTO(watchlist, entries)
+}
+
+func (uc *UseCase) DeleteEntry(ctx context.Context, accountID, id int, entityType string, entityID int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		if _, err := uc.getOwned(ctx, tx, accountID, id); err != nil {
+			return err
+		}
+
+		deleted, err := uc.watchlist.DeleteEntry(ctx, tx, id, model.WatchlistEntityType(entityType), entityID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return domainerror.NotFound("%s %v is not in watchlist %v", entityType, entityID, id)
+		}
+
+		return nil
+	})
+}
+
+// getOwned gets watchlist of account, watchlists of other accounts are reported as missing
+// so their ids are not disclosed
+func (uc *UseCase) getOwned(ctx context.Context, tx txmanager.Tx, accountID, id int) (*model.Watchlist, error) {
+	watchlist, err := uc.watchlist.Get(ctx, tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && watchlist.AccountID != accountID) {
+		return nil, domainerror.NotFound("watchlist %v not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return watchlist, nil
+}
+
+// validateEntry checks command and normalizes its tags
+func validateEntry(cmd *EntryCommand) error {
+	switch model.WatchlistEntityType(cmd.EntityType) {
+	case model.WatchlistUser, model.WatchlistMapset:
+	default:
+		return domainerror.Validation("invalid watchlist entry: type must be %q or %q", model.WatchlistUser, model.WatchlistMapset)
+	}
+
+	if cmd.EntityID <= 0 {
+		return domainerror.Validation("invalid watchlist entry id %v", cmd.EntityID)
+	}
+
+	if utf8.RuneCountInString(cmd.Note) > maxNoteLength {
+		return domainerror.Validation("invalid watchlist entry: note is longer than %d characters", maxNoteLength)
+	}
+
+	tags := make([]string, 0, len(cmd.Tags))
+	seen := make(map[string]bool, len(cmd.Tags))
+	for _, tag := range cmd.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return domainerror.Validation("invalid watchlist entry: tag %q is longer than %d characters", tag, maxTagLength)
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTags {
+		return domainerror.Validation("invalid watchlist entry: more than %d tags", maxTags)
+	}
+	cmd.Tags = tags
+
+	return nil
+}
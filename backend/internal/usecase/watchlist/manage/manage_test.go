@@ -0,0 +1,165 @@
+package watchlistmanage
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/database/txmanager/txmanagertest"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatchlistStore struct {
+	watchlists map[int]*model.Watchlist
+	entries    []*model.WatchlistEntry
+}
+
+func (s *fakeWatchlistStore) Create(_ context.Context, _ txmanager.Tx, watchlist *model.Watchlist) error {
+	watchlist.ID = len(s.watchlists) + 1
+	s.watchlists[watchlist.ID] = watchlist
+	return nil
+}
+
+func (s *fakeWatchlistStore) Get(_ context.Context, _ txmanager.Tx, id int) (*model.Watchlist, error) {
+	watchlist, ok := s.watchlists[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get watchlist %v: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	return watchlist, nil
+}
+
+func (s *fakeWatchlistStore) Delete(_ context.Context, _ txmanager.Tx, id int) error {
+	delete(s.watchlists, id)
+	return nil
+}
+
+func (s *fakeWatchlistStore) UpsertEntry(_ context.Context, _ txmanager.Tx, entry *model.WatchlistEntry) error {
+	for _, stored := range s.entries {
+		if stored.WatchlistID == entry.WatchlistID && stored.EntityType == entry.EntityType && stored.EntityID == entry.EntityID {
+			stored.Note, stored.Tags = entry.Note, entry.Tags
+			return nil
+		}
+	}
+
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *fakeWatchlistStore) DeleteEntry(
+	_ context.Context,
+	_ txmanager.Tx,
+	watchlistID int,
+	entityType model.WatchlistEntityType,
+	entityID int,
+) (bool, error) {
+	for i, stored := range s.entries {
+		if stored.WatchlistID == watchlistID && stored.EntityType == string(entityType) && stored.EntityID == entityID {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *fakeWatchlistStore) ListEntries(_ context.Context, _ txmanager.Tx, watchlistIDs ...int) ([]*model.WatchlistEntry, error) {
+	var res []*model.WatchlistEntry
+	for _, entry := range s.entries {
+		for _, id := range watchlistIDs {
+			if entry.WatchlistID == id {
+				res = append(res, entry)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func newTestUseCase() *UseCase {
+	lg := log.New()
+	lg.SetOutput(io.Discard)
+
+	return New(&config.Config{}, lg, txmanagertest.TxManager{}, &fakeWatchlistStore{watchlists: make(map[int]*model.Watchlist)})
+}
+
+func TestPutEntry(t *testing.T) {
+	uc := newTestUseCase()
+	ctx := context.Background()
+
+	watchlist, err := uc.Create(ctx, 7, &CreateCommand{Name: " rankers "})
+	require.NoError(t, err)
+	assert.Equal(t, "rankers", watchlist.Name)
+
+	watchlist, err = uc.PutEntry(ctx, 7, watchlist.ID, &EntryCommand{
+		EntityType: "user",
+		EntityID:   2,
+		Note:       "hitsounds",
+		Tags:       []string{" jump ", "stream", "jump", ""},
+	})
+	require.NoError(t, err)
+	require.Len(t, watchlist.Entries, 1)
+	assert.Equal(t, []string{"jump", "stream"}, watchlist.Entries[0].Tags)
+
+	watchlist, err = uc.PutEntry(ctx, 7, watchlist.ID, &EntryCommand{EntityType: "user", EntityID: 2, Note: "updated"})
+	require.NoError(t, err)
+	require.Len(t, watchlist.Entries, 1, "putting entry again replaces it")
+	assert.Equal(t, "updated", watchlist.Entries[0].Note)
+	assert.Empty(t, watchlist.Entries[0].Tags)
+
+	require.NoError(t, uc.DeleteEntry(ctx, 7, watchlist.ID, "user", 2))
+	err = uc.DeleteEntry(ctx, 7, watchlist.ID, "user", 2)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+}
+
+func TestPutEntryValidation(t *testing.T) {
+	uc := newTestUseCase()
+	ctx := context.Background()
+
+	watchlist, err := uc.Create(ctx, 7, &CreateCommand{Name: "rankers"})
+	require.NoError(t, err)
+
+	tooManyTags := make([]string, maxTags+1)
+	for i := range tooManyTags {
+		tooManyTags[i] = fmt.Sprintf("tag%d", i)
+	}
+
+	for name, cmd := range map[string]*EntryCommand{
+		"unknown type":  {EntityType: "beatmap", EntityID: 1},
+		"invalid id":    {EntityType: "mapset", EntityID: 0},
+		"long note":     {EntityType: "mapset", EntityID: 1, Note: strings.Repeat("a", maxNoteLength+1)},
+		"long tag":      {EntityType: "mapset", EntityID: 1, Tags: []string{strings.Repeat("a", maxTagLength+1)}},
+		"too many tags": {EntityType: "mapset", EntityID: 1, Tags: tooManyTags},
+	} {
+		_, err := uc.PutEntry(ctx, 7, watchlist.ID, cmd)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation), name)
+	}
+
+	_, err = uc.Create(ctx, 7, &CreateCommand{Name: "  "})
+	assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+}
+
+func TestWatchlistOfOtherAccountIsNotFound(t *testing.T) {
+	uc := newTestUseCase()
+	ctx := context.Background()
+
+	watchlist, err := uc.Create(ctx, 7, &CreateCommand{Name: "rankers"})
+	require.NoError(t, err)
+
+	_, err = uc.PutEntry(ctx, 8, watchlist.ID, &EntryCommand{EntityType: "mapset", EntityID: 1})
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+	err = uc.Delete(ctx, 8, watchlist.ID)
+	assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+	require.NoError(t, uc.Delete(ctx, 7, watchlist.ID))
+}
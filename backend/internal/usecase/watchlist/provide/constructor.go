@@ -0,0 +1,36 @@
+package watchlistprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type watchlistStore interface {
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.Watchlist, error)
+	ListForAccount(ctx context.Context, tx txmanager.Tx, accountID int) ([]*model.Watchlist, error)
+	ListEntries(ctx context.Context, tx txmanager.Tx, watchlistIDs ...int) ([]*model.WatchlistEntry, error)
+}
+
+type UseCase struct {
+	cfg       *config.Config
+	lg        *log.Logger
+	txm       txmanager.TxManager
+	watchlist watchlistStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	watchlist watchlistStore,
+) *UseCase {
+	return &UseCase{
+		cfg:       cfg,
+		lg:        lg,
+		txm:       txm,
+		watchlist: watchlist,
+	}
+}
@@ -0,0 +1,65 @@
+package watchlistprovide
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+)
+
+// List returns every watchlist of account with its entries
+func (uc *UseCase) List(ctx context.Context, accountID int) ([]*dto.Watchlist, error) {
+	var watchlists []*model.Watchlist
+	var entries []*model.WatchlistEntry
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		watchlists, err = uc.watchlist.ListForAccount(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int, len(watchlists))
+		for i, watchlist := range watchlists {
+			ids[i] = watchlist.ID
+		}
+
+		entries, err = uc.watchlist.ListEntries(ctx, tx, ids...)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWatchlistModelsToWatchlistDTOs(watchlists, entries)
+}
+
+func (uc *UseCase) Get(ctx context.Context, accountID, id int) (*dto.Watchlist, error) {
+	var watchlist *model.Watchlist
+	var entries []*model.WatchlistEntry
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		watchlist, err = uc.watchlist.Get(ctx, tx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && watchlist.AccountID != accountID) {
+			return domainerror.NotFound("watchlist %v not found", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		entries, err = uc.watchlist.ListEntries(ctx, tx, id)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWatchlistModelToWatchlistDTO(watchlist, entries)
+}
@@ -0,0 +1,37 @@
+package webhookmanage
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type webhookStore interface {
+	Create(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error
+	Get(ctx context.Context, tx txmanager.Tx, id int) (*model.WebhookSubscription, error)
+	Update(ctx context.Context, tx txmanager.Tx, subscription *model.WebhookSubscription) error
+	Delete(ctx context.Context, tx txmanager.Tx, id int) error
+}
+
+type UseCase struct {
+	cfg     *config.Config
+	lg      *log.Logger
+	txm     txmanager.TxManager
+	webhook webhookStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	webhook webhookStore,
+) *UseCase {
+	return &UseCase{
+		cfg:     cfg,
+		lg:      lg,
+		txm:     txm,
+		webhook: webhook,
+	}
+}
@@ -0,0 +1,183 @@
+package webhookmanage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"net/url"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/service/notifier"
+	"playcount-monitor-backend/internal/usecase/domainerror"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"time"
+)
+
+const generatedSecretBytes = 32
+
+// Command describes subscription, empty Events subscribe to every event,
+// Templates override default discord message templates per event type
+type Command struct {
+	URL       string
+	Secret    string
+	Events    []string
+	Kind      string
+	Templates map[string]string
+}
+
+// Create registers subscription, secret is generated when not provided and returned only here
+func (uc *UseCase) Create(ctx context.Context, cmd *Command) (*dto.WebhookSubscription, error) {
+	if err := validate(cmd); err != nil {
+		return nil, err
+	}
+
+	secret := cmd.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	events, err := mappers.MapWebhookEventsToEventsJSON(cmd.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := mappers.MapWebhookTemplatesToTemplatesJSON(cmd.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	subscription := &model.WebhookSubscription{
+		URL:       cmd.URL,
+		Secret:    secret,
+		Events:    events,
+		Kind:      cmd.Kind,
+		Templates: templates,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return uc.webhook.Create(ctx, tx, subscription)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	res, err := mappers.MapWebhookSubscriptionModelToWebhookSubscriptionDTO(subscription)
+	if err != nil {
+		return nil, err
+	}
+	res.Secret = secret
+
+	return res, nil
+}
+
+// Update replaces url, events, kind and templates of subscription, secret is rotated only when provided
+func (uc *UseCase) Update(ctx context.Context, id int, cmd *Command) (*dto.WebhookSubscription, error) {
+	if err := validate(cmd); err != nil {
+		return nil, err
+	}
+
+	events, err := mappers.MapWebhookEventsToEventsJSON(cmd.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := mappers.MapWebhookTemplatesToTemplatesJSON(cmd.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *model.WebhookSubscription
+	txErr := uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		subscription, err = uc.get(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		subscription.URL = cmd.URL
+		subscription.Events = events
+		subscription.Kind = cmd.Kind
+		subscription.Templates = templates
+		subscription.UpdatedAt = time.Now().UTC()
+		if cmd.Secret != "" {
+			subscription.Secret = cmd.Secret
+		}
+
+		return uc.webhook.Update(ctx, tx, subscription)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWebhookSubscriptionModelToWebhookSubscriptionDTO(subscription)
+}
+
+func (uc *UseCase) Delete(ctx context.Context, id int) error {
+	return uc.txm.ReadWrite(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		if _, err := uc.get(ctx, tx, id); err != nil {
+			return err
+		}
+
+		return uc.webhook.Delete(ctx, tx, id)
+	})
+}
+
+func (uc *UseCase) get(ctx context.Context, tx txmanager.Tx, id int) (*model.WebhookSubscription, error) {
+	subscription, err := uc.webhook.Get(ctx, tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerror.NotFound("webhook subscription %v not found", id)
+	}
+
+	return subscription, err
+}
+
+// validate checks command and defaults its kind to plain webhook
+func validate(cmd *Command) error {
+	if cmd.Kind == "" {
+		cmd.Kind = string(notifier.KindWebhook)
+	}
+	if !notifier.IsKnownSubscriptionKind(cmd.Kind) {
+		return domainerror.Validation("invalid webhook subscription: unknown kind %q", cmd.Kind)
+	}
+
+	u, err := url.Parse(cmd.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domainerror.Validation("invalid webhook subscription: url must be absolute http(s) url")
+	}
+
+	for _, event := range cmd.Events {
+		if !notifier.IsKnownEventType(event) {
+			return domainerror.Validation("invalid webhook subscription: unknown event %q", event)
+		}
+	}
+
+	if len(cmd.Templates) > 0 {
+		if cmd.Kind != string(notifier.KindDiscord) {
+			return domainerror.Validation("invalid webhook subscription: templates are supported only by discord subscriptions")
+		}
+		if _, err := notifier.ParseDiscordTemplates(cmd.Templates); err != nil {
+			return domainerror.Validation("invalid webhook subscription: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,40 @@
+package webhookprovide
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/config"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+)
+
+type webhookStore interface {
+	List(ctx context.Context, tx txmanager.Tx) ([]*model.WebhookSubscription, error)
+	ListDeliveries(
+		ctx context.Context,
+		tx txmanager.Tx,
+		subscriptionID int,
+		page model.PageRequest,
+	) ([]*model.WebhookDelivery, *model.Page, error)
+}
+
+type UseCase struct {
+	cfg     *config.Config
+	lg      *log.Logger
+	txm     txmanager.TxManager
+	webhook webhookStore
+}
+
+func New(
+	cfg *config.Config,
+	lg *log.Logger,
+	txm txmanager.TxManager,
+	webhook webhookStore,
+) *UseCase {
+	return &UseCase{
+		cfg:     cfg,
+		lg:      lg,
+		txm:     txm,
+		webhook: webhook,
+	}
+}
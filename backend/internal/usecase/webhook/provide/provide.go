@@ -0,0 +1,64 @@
+package webhookprovide
+
+import (
+	"context"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/internal/dto"
+	"playcount-monitor-backend/internal/usecase/mappers"
+	"playcount-monitor-backend/internal/usecase/pagination"
+)
+
+func (uc *UseCase) List(ctx context.Context) ([]*dto.WebhookSubscription, error) {
+	var subscriptions []*model.WebhookSubscription
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		subscriptions, err = uc.webhook.List(ctx, tx)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return mappers.MapWebhookSubscriptionModelsToWebhookSubscriptionDTOs(subscriptions)
+}
+
+type ListDeliveriesCommand struct {
+	pagination.Query
+	SubscriptionID int
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries []*dto.WebhookDelivery
+	Page       *dto.Page
+}
+
+func (uc *UseCase) ListDeliveries(
+	ctx context.Context,
+	cmd *ListDeliveriesCommand,
+) (*ListDeliveriesResponse, error) {
+	pageReq, err := pagination.Request(cmd.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*model.WebhookDelivery
+	var page *model.Page
+
+	txErr := uc.txm.ReadOnly(ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		var err error
+		deliveries, page, err = uc.webhook.ListDeliveries(ctx, tx, cmd.SubscriptionID, pageReq)
+
+		return pagination.Error(err)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return &ListDeliveriesResponse{
+		Deliveries: mappers.MapWebhookDeliveryModelsToWebhookDeliveryDTOs(deliveries),
+		Page:       mappers.MapPageModelToPageDTO(pageReq, page),
+	}, nil
+}
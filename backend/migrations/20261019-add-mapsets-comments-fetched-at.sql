@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE mapsets ADD COLUMN comments_fetched_at timestamp;
+
+-- +migrate Down
+
+ALTER TABLE mapsets DROP COLUMN comments_fetched_at;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE mapsets ADD COLUMN removal_status text not null default '';
+ALTER TABLE mapsets ADD COLUMN removed_at timestamp;
+
+-- +migrate Down
+
+ALTER TABLE mapsets DROP COLUMN removal_status;
+ALTER TABLE mapsets DROP COLUMN removed_at;
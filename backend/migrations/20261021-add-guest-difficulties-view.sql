@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE INDEX beatmaps_user_id_idx ON beatmaps (user_id);
+
+-- guest difficulty is a beatmap mapped by someone other than host of its mapset
+CREATE VIEW guest_difficulties AS
+SELECT b.id                         AS beatmap_id,
+       b.mapset_id                  AS mapset_id,
+       b.version                    AS version,
+       b.difficulty_rating          AS difficulty_rating,
+       m.user_id                    AS host_id,
+       m.creator                    AS host_username,
+       b.user_id                    AS mapper_id,
+       COALESCE(u.username, '')     AS mapper_username
+FROM beatmaps b
+         JOIN mapsets m ON m.id = b.mapset_id
+         LEFT JOIN users u ON u.id = b.user_id
+WHERE b.user_id <> m.user_id
+  AND m.removal_status = '';
+
+-- +migrate Down
+
+DROP VIEW guest_difficulties;
+DROP INDEX beatmaps_user_id_idx;
@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE mapsets ADD COLUMN last_favorites integer not null default 0;
+ALTER TABLE mapsets ADD COLUMN last_comments integer not null default 0;
+ALTER TABLE mapsets ADD COLUMN submitted_date timestamp;
+
+-- fill from the latest stats entry, next tracking run keeps them up to date
+UPDATE mapsets m
+SET last_favorites = COALESCE((latest.stats ->> 'favourite_count')::integer, 0),
+    last_comments  = COALESCE((latest.stats ->> 'comments_count')::integer, 0)
+FROM (SELECT DISTINCT ON (id) id, e.value AS stats
+      FROM mapsets,
+           jsonb_each(mapset_stats) e
+      ORDER BY id, e.key::timestamptz DESC) latest
+WHERE latest.id = m.id;
+
+-- +migrate Down
+
+ALTER TABLE mapsets DROP COLUMN last_favorites;
+ALTER TABLE mapsets DROP COLUMN last_comments;
+ALTER TABLE mapsets DROP COLUMN submitted_date;
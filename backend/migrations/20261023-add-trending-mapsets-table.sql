@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE trending_mapsets
+(
+    period_days    integer   not null,
+    mapset_id      integer   not null,
+    constraint trending_mapset_id_fk foreign key (mapset_id) references mapsets (id) on delete cascade,
+    playcount_gain integer   not null,
+    relative_gain  real      not null,
+    computed_at    timestamp not null,
+    primary key (period_days, mapset_id)
+);
+
+-- +migrate Down
+DROP TABLE trending_mapsets;
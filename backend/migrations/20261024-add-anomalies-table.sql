@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE anomalies
+(
+    id           serial primary key,
+    entity_type  text      not null, -- mapset or beatmap
+    entity_id    integer   not null,
+    mapset_id    integer   not null,
+    user_id      integer   not null, -- mapset host, anomalies are shown on their user card
+    metric       text      not null,
+    kind         text      not null,
+    severity     text      not null,
+    detected_for timestamp not null, -- day of the flagged delta
+    value        integer   not null,
+    delta        integer   not null,
+    baseline     real      not null,
+    score        real      not null,
+    context      jsonb,
+    created_at   timestamp default NOW(),
+    unique (entity_type, entity_id, metric, kind, detected_for)
+);
+
+CREATE INDEX anomalies_user_id_idx ON anomalies (user_id);
+
+-- +migrate Down
+DROP TABLE anomalies;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE milestones
+(
+    id          serial primary key,
+    entity_type text      not null, -- user or mapset
+    entity_id   integer   not null,
+    user_id     integer   not null, -- user or mapset host, milestones are shown on their user card
+    kind        text      not null,
+    threshold   integer   not null,
+    value       integer   not null, -- value at the snapshot threshold was crossed in
+    reached_at  timestamp not null,
+    created_at  timestamp default NOW(),
+    unique (entity_type, entity_id, kind, threshold)
+);
+
+CREATE INDEX milestones_user_id_idx ON milestones (user_id);
+
+-- +migrate Down
+DROP TABLE milestones;
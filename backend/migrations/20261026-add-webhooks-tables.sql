@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE webhook_subscriptions
+(
+    id         serial primary key,
+    url        text not null,
+    secret     text not null, -- payloads are signed with HMAC-SHA256 using this secret
+    events     jsonb,         -- list of event types, empty list matches every event
+    created_at timestamp default NOW(),
+    updated_at timestamp default NOW()
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              serial primary key,
+    subscription_id integer not null references webhook_subscriptions (id) on delete cascade,
+    event_type      text    not null,
+    payload         jsonb   not null,
+    attempts        integer not null,
+    status_code     integer not null, -- last response status, 0 when no response was received
+    success         boolean not null,
+    error           text    not null default '',
+    delivered_at    timestamp,
+    created_at      timestamp default NOW()
+);
+
+CREATE INDEX webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id);
+
+-- +migrate Down
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
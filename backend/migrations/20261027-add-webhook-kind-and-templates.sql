@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN kind      text not null default 'webhook', -- webhook posts event json, discord posts rendered embeds
+    ADD COLUMN templates jsonb;                           -- event type -> message template, overrides defaults
+
+-- +migrate Down
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN kind,
+    DROP COLUMN templates;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE mapset_events
+(
+    id              serial primary key,
+    mapset_id       integer   not null references mapsets (id) on delete cascade,
+    user_id         integer   not null,
+    kind            text      not null, -- new_mapset or status_change
+    previous_status text      not null default '',
+    status          text      not null,
+    occurred_at     timestamp not null,
+    created_at      timestamp default NOW()
+);
+
+CREATE INDEX mapset_events_user_id_idx ON mapset_events (user_id);
+
+-- +migrate Down
+DROP TABLE mapset_events;
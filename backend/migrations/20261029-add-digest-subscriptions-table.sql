@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE digest_subscriptions
+(
+    id           serial primary key,
+    email        text not null unique,
+    frequency    text not null, -- daily or weekly
+    user_ids     jsonb,         -- mappers digest is built for, empty list means every tracked mapper
+    last_sent_at timestamp,
+    created_at   timestamp default NOW()
+);
+
+-- +migrate Down
+DROP TABLE digest_subscriptions;
@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE api_keys
+(
+    id           serial primary key,
+    name         text not null,
+    prefix       text not null,        -- first characters of key, shown to tell keys apart
+    key_hash     text not null unique, -- hex encoded sha256 of key, plaintext key is never stored
+    role         text not null,        -- viewer, editor or admin
+    last_used_at timestamp,
+    revoked_at   timestamp,
+    created_at   timestamp default NOW()
+);
+
+CREATE TABLE audit_log
+(
+    id         serial primary key,
+    api_key_id integer references api_keys (id) on delete set null,
+    actor      text    not null, -- key name at the time of request, kept after key is deleted
+    method     text    not null,
+    path       text    not null,  -- matched route, e.g. /api/webhooks/:id
+    uri        text    not null,
+    status     integer not null,
+    request_id text    not null default '',
+    created_at timestamp default NOW()
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+
+-- +migrate Down
+DROP TABLE audit_log;
+DROP TABLE api_keys;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE osu_accounts
+(
+    user_id                 integer primary key, -- osu! user id of mapper who logged in
+    username                text      not null,
+    access_token_encrypted  text      not null,  -- AES-GCM encrypted, see TOKEN_ENCRYPTION_KEY
+    refresh_token_encrypted text      not null,
+    token_expires_at        timestamp not null,
+    created_at              timestamp default NOW(),
+    updated_at              timestamp default NOW()
+);
+
+CREATE TABLE sessions
+(
+    token_hash   text primary key, -- hex encoded sha256 of session cookie, plaintext token is never stored
+    user_id      integer   not null references osu_accounts (user_id) on delete cascade,
+    expires_at   timestamp not null,
+    created_at   timestamp default NOW()
+);
+
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
+
+-- +migrate Down
+DROP TABLE sessions;
+DROP TABLE osu_accounts;
@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE watchlists
+(
+    id         serial primary key,
+    account_id integer not null references osu_accounts (user_id) on delete cascade,
+    name       text    not null,
+    created_at timestamp default NOW(),
+    updated_at timestamp default NOW(),
+    unique (account_id, name)
+);
+
+CREATE TABLE watchlist_entries
+(
+    id           serial primary key,
+    watchlist_id integer not null references watchlists (id) on delete cascade,
+    entity_type  text    not null, -- user or mapset
+    entity_id    integer not null, -- osu! id, entity doesn't have to be stored yet
+    note         text    not null default '',
+    tags         jsonb,            -- []string, private to watchlist owner like the note
+    created_at   timestamp default NOW(),
+    updated_at   timestamp default NOW(),
+    unique (watchlist_id, entity_type, entity_id)
+);
+
+-- tracker looks up every watched user regardless of watchlist
+CREATE INDEX watchlist_entries_entity_idx ON watchlist_entries (entity_type, entity_id);
+
+-- +migrate Down
+DROP TABLE watchlist_entries;
+DROP TABLE watchlists;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- mapsets can be watched on their own now, so their host doesn't have to be tracked or stored
+ALTER TABLE mapsets DROP CONSTRAINT user_id_fk;
+
+CREATE TABLE watched_mapsets
+(
+    id           integer primary key, -- osu! mapset id, mapset doesn't have to be stored yet
+    created_at   timestamp default NOW(),
+    last_fetched timestamp
+);
+
+-- +migrate Down
+DROP TABLE watched_mapsets;
+ALTER TABLE mapsets ADD CONSTRAINT user_id_fk foreign key (user_id) references users (id);
@@ -0,0 +1,84 @@
+-- +migrate Up
+-- simple configuration since titles, artists and tags are in many languages, words are matched as they are
+ALTER TABLE mapsets ADD COLUMN search_vector tsvector;
+
+-- +migrate StatementBegin
+CREATE FUNCTION mapset_search_vector(m mapsets) RETURNS tsvector AS
+$$
+SELECT setweight(to_tsvector('simple', coalesce(m.artist, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(m.title, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(m.creator, '')), 'B') ||
+       setweight(to_tsvector('simple', coalesce(m.tags, '')), 'C') ||
+       setweight(to_tsvector('simple', coalesce((SELECT string_agg(b.version, ' ')
+                                                 FROM beatmaps b
+                                                 WHERE b.mapset_id = m.id), '')), 'D')
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION mapsets_search_vector_trigger() RETURNS trigger AS
+$$
+BEGIN
+    NEW.search_vector := mapset_search_vector(NEW);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- tracker upserts every mapset on every run, vector is only rebuilt when searched text changes
+CREATE TRIGGER mapsets_search_vector_insert
+    BEFORE INSERT ON mapsets
+    FOR EACH ROW
+EXECUTE FUNCTION mapsets_search_vector_trigger();
+
+CREATE TRIGGER mapsets_search_vector_update
+    BEFORE UPDATE OF artist, title, creator, tags ON mapsets
+    FOR EACH ROW
+    WHEN (OLD.artist IS DISTINCT FROM NEW.artist OR OLD.title IS DISTINCT FROM NEW.title OR
+          OLD.creator IS DISTINCT FROM NEW.creator OR OLD.tags IS DISTINCT FROM NEW.tags)
+EXECUTE FUNCTION mapsets_search_vector_trigger();
+
+-- difficulty names are part of mapset vector, so it is rebuilt when they change
+-- +migrate StatementBegin
+CREATE FUNCTION beatmaps_search_vector_trigger() RETURNS trigger AS
+$$
+DECLARE
+    changed_mapset_id integer;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_mapset_id := OLD.mapset_id;
+    ELSE
+        changed_mapset_id := NEW.mapset_id;
+    END IF;
+
+    UPDATE mapsets m SET search_vector = mapset_search_vector(m) WHERE m.id = changed_mapset_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER beatmaps_search_vector_insert_delete
+    AFTER INSERT OR DELETE ON beatmaps
+    FOR EACH ROW
+EXECUTE FUNCTION beatmaps_search_vector_trigger();
+
+CREATE TRIGGER beatmaps_search_vector_update
+    AFTER UPDATE OF version ON beatmaps
+    FOR EACH ROW
+    WHEN (OLD.version IS DISTINCT FROM NEW.version)
+EXECUTE FUNCTION beatmaps_search_vector_trigger();
+
+UPDATE mapsets m SET search_vector = mapset_search_vector(m);
+
+CREATE INDEX mapsets_search_vector_idx ON mapsets USING gin (search_vector);
+
+-- +migrate Down
+DROP INDEX mapsets_search_vector_idx;
+DROP TRIGGER beatmaps_search_vector_update ON beatmaps;
+DROP TRIGGER beatmaps_search_vector_insert_delete ON beatmaps;
+DROP FUNCTION beatmaps_search_vector_trigger();
+DROP TRIGGER mapsets_search_vector_update ON mapsets;
+DROP TRIGGER mapsets_search_vector_insert ON mapsets;
+DROP FUNCTION mapsets_search_vector_trigger();
+DROP FUNCTION mapset_search_vector(mapsets);
+ALTER TABLE mapsets DROP COLUMN search_vector;
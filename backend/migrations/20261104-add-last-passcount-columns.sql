@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE beatmaps ADD COLUMN last_playcount integer not null default 0;
+ALTER TABLE beatmaps ADD COLUMN last_passcount integer not null default 0;
+ALTER TABLE mapsets ADD COLUMN last_passcount integer not null default 0;
+
+-- fill from the latest stats entry, next tracking run keeps them up to date
+UPDATE beatmaps b
+SET last_playcount = COALESCE((latest.stats ->> 'play_count')::integer, 0),
+    last_passcount = COALESCE((latest.stats ->> 'pass_count')::integer, 0)
+FROM (SELECT DISTINCT ON (id) id, e.value AS stats
+      FROM beatmaps,
+           jsonb_each(beatmap_stats) e
+      ORDER BY id, e.key::timestamptz DESC) latest
+WHERE latest.id = b.id;
+
+UPDATE mapsets m
+SET last_passcount = totals.passcount
+FROM (SELECT mapset_id, SUM(last_passcount) AS passcount
+      FROM beatmaps
+      GROUP BY mapset_id) totals
+WHERE totals.mapset_id = m.id;
+
+-- +migrate Down
+
+ALTER TABLE beatmaps DROP COLUMN last_playcount;
+ALTER TABLE beatmaps DROP COLUMN last_passcount;
+ALTER TABLE mapsets DROP COLUMN last_passcount;
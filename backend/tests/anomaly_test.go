@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/database/repository/anomalyrepository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+func (s *IntegrationSuite) Test_CreateAnomaliesReturnsInserted() {
+	s.Require().NoError(s.db.Exec("DELETE FROM anomalies").Error)
+
+	lg := log.New()
+	txm := bootstrap.ConnectTxManager("anomaly_test", time.Second, s.db, lg)
+	repo := anomalyrepository.New(s.cfg, lg)
+
+	newAnomaly := func(entityID int) *model.Anomaly {
+		return &model.Anomaly{
+			EntityType:  string(model.AnomalyMapset),
+			EntityID:    entityID,
+			MapsetID:    entityID,
+			UserID:      1,
+			Metric:      "play_count",
+			Kind:        "spike",
+			Severity:    string(model.AnomalyLow),
+			DetectedFor: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	create := func(anomalies ...*model.Anomaly) []*model.Anomaly {
+		var created []*model.Anomaly
+		err := txm.ReadWrite(s.ctx, func(ctx context.Context, tx txmanager.Tx) error {
+			var err error
+			created, err = repo.Create(ctx, tx, anomalies...)
+			return err
+		})
+		s.Require().NoError(err)
+
+		return created
+	}
+
+	first := create(newAnomaly(1))
+	s.Require().Len(first, 1)
+	s.NotZero(first[0].ID)
+
+	// anomaly of mapset 1 is flagged again, only mapset 2 one is new
+	second := newAnomaly(2)
+	created := create(newAnomaly(1), second)
+	s.Equal([]*model.Anomaly{second}, created)
+	s.NotZero(second.ID)
+
+	var count int64
+	s.Require().NoError(s.db.Table("anomalies").Count(&count).Error)
+	s.Equal(int64(2), count)
+}
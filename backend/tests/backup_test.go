@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/database/repository/backuprepository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/usecase/backup"
+	"slices"
+	"time"
+)
+
+// backupFixture has rows in every backed up table, ids are picked above what migrations seed
+// so that restored sequences can be checked
+const backupFixture = `
+INSERT INTO users (id, avatar_url, username, unranked_beatmapset_count, graveyard_beatmapset_count, user_stats, created_at)
+VALUES (1, 'avatar.com', 'mapper', 1, 0, '{"2024-01-01T00:00:00Z":{"play_count":100,"favourite_count":2,"map_count":1}}', '2024-01-01');
+
+INSERT INTO mapsets (id, artist, title, covers, status, last_updated, user_id, creator, preview_url, tags, bpm,
+                     mapset_stats, last_playcount, genre, language, created_at)
+VALUES (10, 'artist', 'title', '{"cover":"cover.com"}', 'ranked', '2024-01-01', 1, 'mapper', 'preview.com', 'tag1 tag2', 180,
+        '{"2024-01-01T00:00:00Z":{"play_count":100,"favourite_count":2}}', 100, 'Rock', 'English', '2024-01-01');
+
+INSERT INTO beatmaps (id, mapset_id, difficulty_rating, version, accuracy, ar, bpm, cs, status, url, total_length, user_id,
+                      last_updated, beatmap_stats, last_playcount, created_at)
+VALUES (100, 10, 5.5, 'insane', 8, 9, 180, 4, 'ranked', 'beatmap.com', 120, 1,
+        '2024-01-01', '{"2024-01-01T00:00:00Z":{"play_count":100,"pass_count":10}}', 100, '2024-01-01');
+
+INSERT INTO following (id, username, created_at) VALUES (1, 'mapper', '2024-01-01');
+
+INSERT INTO tracks (id, tracked_at) VALUES (40, '2024-01-02');
+INSERT INTO cleans (id, cleaned_at) VALUES (40, '2024-01-02');
+
+INSERT INTO trending_mapsets (period_days, mapset_id, playcount_gain, relative_gain, computed_at)
+VALUES (7, 10, 50, 0.5, '2024-01-02');
+
+INSERT INTO anomalies (id, entity_type, entity_id, mapset_id, user_id, metric, kind, severity, detected_for,
+                       value, delta, baseline, score, context)
+VALUES (41, 'mapset', 10, 10, 1, 'play_count', 'spike', 'high', '2024-01-02', 1000, 900, 10, 90, '{"title":"artist - title"}');
+
+INSERT INTO milestones (id, entity_type, entity_id, user_id, kind, threshold, value, reached_at)
+VALUES (42, 'user', 1, 1, 'play_count', 100, 100, '2024-01-02');
+
+INSERT INTO mapset_events (id, mapset_id, user_id, kind, previous_status, status, occurred_at)
+VALUES (43, 10, 1, 'status_change', 'pending', 'ranked', '2024-01-02');
+
+INSERT INTO watched_mapsets (id, last_fetched) VALUES (20, '2024-01-02');
+
+INSERT INTO webhook_subscriptions (id, url, secret, events, kind, templates)
+VALUES (44, 'https://example.com/hook', 'secret', '["milestone"]', 'discord', '{"milestone":"{{.Title}}"}');
+
+INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, attempts, status_code, success, delivered_at)
+VALUES (45, 44, 'milestone', '{"type":"milestone"}', 1, 200, true, '2024-01-02');
+
+INSERT INTO digest_subscriptions (id, email, frequency, user_ids) VALUES (46, 'mapper@example.com', 'weekly', '[1]');
+
+INSERT INTO api_keys (id, name, prefix, key_hash, role) VALUES (47, 'ci', 'pcm_abc', 'hash', 'admin');
+
+INSERT INTO audit_log (id, api_key_id, actor, method, path, uri, status, request_id)
+VALUES (48, 47, 'ci', 'POST', '/api/webhooks', '/api/webhooks', 201, 'req');
+
+INSERT INTO osu_accounts (user_id, username, access_token_encrypted, refresh_token_encrypted, token_expires_at)
+VALUES (1, 'mapper', 'access', 'refresh', '2024-02-01');
+
+INSERT INTO sessions (token_hash, user_id, expires_at) VALUES ('session', 1, '2024-02-01');
+
+INSERT INTO watchlists (id, account_id, name) VALUES (49, 1, 'favourites');
+
+INSERT INTO watchlist_entries (id, watchlist_id, entity_type, entity_id, note, tags)
+VALUES (50, 49, 'mapset', 10, 'note', '["tag"]');
+`
+
+// notBackedUpTables are tables left out of backups on purpose, any other table has to be in model.BackupTables
+var notBackedUpTables = []string{"sessions", "gorp_migrations"}
+
+func (s *IntegrationSuite) Test_BackupTablesCoverSchema() {
+	var tables []string
+	err := s.db.Raw(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'`).Scan(&tables).Error
+	s.Require().NoError(err)
+	s.Require().NotEmpty(tables)
+
+	for _, table := range tables {
+		s.True(model.BackupTable(table).IsValid() || slices.Contains(notBackedUpTables, table),
+			"table %s is neither backed up nor excluded from backups", table)
+	}
+}
+
+func (s *IntegrationSuite) Test_BackupRoundTrip() {
+	s.clearBackupTables()
+	s.Require().NoError(s.db.Exec(backupFixture).Error)
+
+	before := s.snapshotBackupTables()
+	for _, table := range model.BackupTables {
+		s.NotEmpty(before[table], "fixture has no %s rows", table)
+	}
+
+	lg := log.New()
+	txm := bootstrap.ConnectTxManager("backup_test", time.Second, s.db, lg)
+	uc := backup.New(s.cfg, lg, txm, backuprepository.New(s.cfg, lg))
+
+	var buf bytes.Buffer
+	manifest, err := uc.Write(s.ctx, &buf, time.Now())
+	s.Require().NoError(err)
+	s.Require().Len(manifest.Tables, len(model.BackupTables))
+
+	// restore expects migrated database, migrations seed following, tracks and cleans again
+	s.clearBackupTables()
+	s.Require().NoError(s.db.Exec(`
+		INSERT INTO following (id, username, created_at) VALUES (7192129, 'Gasha', NOW());
+		INSERT INTO tracks (id, tracked_at) VALUES (1, '2024-01-26 15:30:30');
+		INSERT INTO cleans (id, cleaned_at) VALUES (1, '2024-01-26 15:30:30');
+	`).Error)
+
+	path := filepath.Join(s.T().TempDir(), "backup.zip")
+	s.Require().NoError(os.WriteFile(path, buf.Bytes(), 0o644))
+
+	_, err = uc.Restore(s.ctx, path)
+	s.Require().NoError(err)
+
+	after := s.snapshotBackupTables()
+	for _, table := range model.BackupTables {
+		s.ElementsMatch(before[table], after[table], "table %s", table)
+	}
+
+	// sessions are not backed up
+	var sessions int64
+	s.Require().NoError(s.db.Table("sessions").Count(&sessions).Error)
+	s.Zero(sessions)
+
+	// sequences continue after restored ids
+	for table, restoredID := range map[string]int{"anomalies": 41, "tracks": 40, "watchlist_entries": 50} {
+		var next int
+		err := s.db.Raw(fmt.Sprintf("SELECT nextval(pg_get_serial_sequence('%s', 'id'))", table)).Scan(&next).Error
+		s.Require().NoError(err)
+		s.Greater(next, restoredID, "table %s", table)
+	}
+
+	// restore into database with data is refused and leaves it as it is
+	_, err = uc.Restore(s.ctx, path)
+	s.ErrorIs(err, backup.ErrDatabaseNotEmpty)
+
+	unchanged := s.snapshotBackupTables()
+	for _, table := range model.BackupTables {
+		s.ElementsMatch(after[table], unchanged[table], "table %s", table)
+	}
+}
+
+// clearBackupTables empties backed up tables and sessions, referencing tables go first
+func (s *IntegrationSuite) clearBackupTables() {
+	s.Require().NoError(s.db.Exec("DELETE FROM sessions").Error)
+
+	tables := slices.Clone(model.BackupTables)
+	slices.Reverse(tables)
+	for _, table := range tables {
+		s.Require().NoError(s.db.Exec(fmt.Sprintf("DELETE FROM %s", table)).Error)
+	}
+}
+
+// snapshotBackupTables reads every backed up table as json rows, independently of backup repository
+func (s *IntegrationSuite) snapshotBackupTables() map[model.BackupTable][]map[string]interface{} {
+	res := make(map[model.BackupTable][]map[string]interface{}, len(model.BackupTables))
+	for _, table := range model.BackupTables {
+		var rows []string
+		err := s.db.Raw(fmt.Sprintf("SELECT to_jsonb(t)::text FROM %s t", table)).Scan(&rows).Error
+		s.Require().NoError(err)
+
+		for _, row := range rows {
+			var decoded map[string]interface{}
+			s.Require().NoError(json.Unmarshal([]byte(row), &decoded))
+			res[table] = append(res[table], decoded)
+		}
+	}
+
+	return res
+}
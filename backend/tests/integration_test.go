@@ -44,6 +44,8 @@ func (s *IntegrationSuite) SetupSuite() {
 
 	s.port = s.cfg.IntegrationTestHTTPPort
 	s.cfg.HTTPAddr = s.cfg.IntegrationTestHTTPAddr
+	// rows are inserted straight into db, cached results would hide them
+	s.cfg.CacheSize = 0
 
 	s.ctx, s.cancelCtx = context.WithCancel(
 		context.WithValue(context.Background(), EnvKey, "integration-test"),
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"playcount-monitor-backend/internal/app/mapsetserviceapi"
 	"playcount-monitor-backend/internal/database/repository"
 	"playcount-monitor-backend/internal/database/repository/model"
@@ -176,8 +177,12 @@ func (s *IntegrationSuite) Test_ListMapsets() {
 							},
 						},
 					},
-					CurrentPage: 1,
-					Pages:       1,
+					Page: &dto.Page{
+						Total:       2,
+						Limit:       50,
+						CurrentPage: 1,
+						Pages:       1,
+					},
 				},
 				outCode: 200,
 			},
@@ -215,6 +220,7 @@ func (s *IntegrationSuite) Test_ListMapsets() {
 				s.Require().NoError(err)
 
 				s.Assert().Equal(2, len(actual.Mapsets))
+				s.Assert().Equal(tc.out.Page, actual.Page)
 
 				for i, actualMapset := range actual.Mapsets {
 					expectedMapset := tc.out.Mapsets[i]
@@ -264,3 +270,69 @@ func (s *IntegrationSuite) Test_ListMapsets() {
 		s.T().Fatal(err)
 	}
 }
+
+func (s *IntegrationSuite) Test_ListGuestMapsetsForUser() {
+	defer func() {
+		s.Require().NoError(integration.ClearTables(s.ctx, s.db))
+	}()
+
+	s.Require().NoError(s.db.Create(&model.User{ID: 1, Username: "host", UserStats: repository.JSON(`{}`)}).Error)
+	s.Require().NoError(s.db.Create(&model.User{ID: 2, Username: "guest", UserStats: repository.JSON(`{}`)}).Error)
+
+	// 3 mapsets of host with one own and one guest difficulty each, 4th one without guest difficulty
+	for id := 1; id <= 4; id++ {
+		s.Require().NoError(s.db.Create(&model.Mapset{
+			ID:          id,
+			Covers:      repository.JSON(`{}`),
+			Status:      "graveyard",
+			LastUpdated: time.Now().UTC(),
+			UserID:      1,
+			Creator:     "host",
+			MapsetStats: repository.JSON(`{}`),
+			CreatedAt:   time.Date(2024, 1, id, 0, 0, 0, 0, time.UTC),
+		}).Error)
+
+		mappers := []int{1, 2}
+		if id == 4 {
+			mappers = []int{1}
+		}
+		for _, mapperID := range mappers {
+			s.Require().NoError(s.db.Create(&model.Beatmap{
+				ID:           id*10 + mapperID,
+				MapsetID:     id,
+				Status:       "graveyard",
+				UserID:       mapperID,
+				BeatmapStats: repository.JSON(`{}`),
+			}).Error)
+		}
+	}
+
+	list := func(query string) mapsetserviceapi.GuestMapsetListResponse {
+		out, err := http.Get(fmt.Sprintf("http://localhost:%s/api/beatmapset/list_guest_for_user/2?%s", s.port, query))
+		s.Require().NoError(err)
+		defer out.Body.Close()
+		s.Require().Equal(http.StatusOK, out.StatusCode)
+
+		var actual mapsetserviceapi.GuestMapsetListResponse
+		s.Require().NoError(json.NewDecoder(out.Body).Decode(&actual))
+		s.Require().NotNil(actual.Page)
+
+		return actual
+	}
+
+	var ids []int
+	first := list("limit=2")
+	s.Equal(3, first.Page.Total)
+	s.Equal(2, first.Page.Pages)
+	s.Require().NotEmpty(first.Page.Next)
+
+	second := list("limit=2&cursor=" + url.QueryEscape(first.Page.Next))
+	s.Empty(second.Page.Next)
+
+	for _, mapset := range append(first.Mapsets, second.Mapsets...) {
+		ids = append(ids, mapset.Id)
+		s.Require().Len(mapset.Beatmaps, 1)
+		s.Equal(2, mapset.Beatmaps[0].UserId)
+	}
+	s.Equal([]int{3, 2, 1}, ids)
+}
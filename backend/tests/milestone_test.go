@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/database/repository/milestonerepository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"time"
+)
+
+func (s *IntegrationSuite) Test_CreateMilestonesReturnsInserted() {
+	s.Require().NoError(s.db.Exec("DELETE FROM milestones").Error)
+
+	lg := log.New()
+	txm := bootstrap.ConnectTxManager("milestone_test", time.Second, s.db, lg)
+	repo := milestonerepository.New(s.cfg, lg)
+
+	newMilestone := func(threshold int) *model.Milestone {
+		return &model.Milestone{
+			EntityType: string(model.MilestoneUser),
+			EntityID:   1,
+			UserID:     1,
+			Kind:       string(model.MilestonePlaycount),
+			Threshold:  threshold,
+			Value:      threshold,
+			ReachedAt:  time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	create := func(milestones ...*model.Milestone) []*model.Milestone {
+		var created []*model.Milestone
+		err := txm.ReadWrite(s.ctx, func(ctx context.Context, tx txmanager.Tx) error {
+			var err error
+			created, err = repo.Create(ctx, tx, milestones...)
+			return err
+		})
+		s.Require().NoError(err)
+
+		return created
+	}
+
+	first := create(newMilestone(100))
+	s.Require().Len(first, 1)
+	s.NotZero(first[0].ID)
+
+	// 100 threshold is reached again, only 1000 one is new
+	second := newMilestone(1000)
+	created := create(newMilestone(100), second)
+	s.Equal([]*model.Milestone{second}, created)
+	s.NotZero(second.ID)
+
+	var count int64
+	s.Require().NoError(s.db.Table("milestones").Count(&count).Error)
+	s.Equal(int64(2), count)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"playcount-monitor-backend/internal/bootstrap"
+	"playcount-monitor-backend/internal/database/repository"
+	"playcount-monitor-backend/internal/database/repository/beatmaprepository"
+	"playcount-monitor-backend/internal/database/repository/mapsetrepository"
+	"playcount-monitor-backend/internal/database/repository/model"
+	"playcount-monitor-backend/internal/database/txmanager"
+	"playcount-monitor-backend/tests/integration"
+	"time"
+)
+
+func (s *IntegrationSuite) Test_UpsertMapsetsWithBeatmaps() {
+	err := integration.ClearTables(s.ctx, s.db)
+	s.Require().NoError(err)
+
+	lg := log.New()
+	txm := bootstrap.ConnectTxManager("upsert_test", time.Second, s.db, lg)
+	mapsets := mapsetrepository.New(s.cfg, lg)
+	beatmaps := beatmaprepository.New(s.cfg, lg)
+
+	upsert := func(ms *model.Mapset, bm *model.Beatmap) {
+		err := txm.ReadWrite(s.ctx, func(ctx context.Context, tx txmanager.Tx) error {
+			if err := mapsets.Upsert(ctx, tx, ms); err != nil {
+				return err
+			}
+			return beatmaps.Upsert(ctx, tx, bm)
+		})
+		s.Require().NoError(err)
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	upsert(
+		&model.Mapset{
+			ID:          1,
+			Artist:      "artist",
+			Title:       "title",
+			Covers:      repository.JSON(`{}`),
+			Status:      "wip",
+			UserID:      1,
+			Creator:     "creator",
+			MapsetStats: repository.JSON(`{"2024-01-01T00:00:00Z":{"play_count":1,"favourite_count":1}}`),
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		&model.Beatmap{
+			ID:           10,
+			MapsetID:     1,
+			Version:      "hard",
+			Status:       "wip",
+			BeatmapStats: repository.JSON(`{"2024-01-01T00:00:00Z":{"play_count":1,"pass_count":0}}`),
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+		},
+	)
+
+	updatedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	upsert(
+		&model.Mapset{
+			ID:          1,
+			Artist:      "artist",
+			Title:       "new title",
+			Covers:      repository.JSON(`{}`),
+			Status:      "ranked",
+			UserID:      1,
+			Creator:     "creator",
+			MapsetStats: repository.JSON(`{"2024-01-02T00:00:00Z":{"play_count":5,"favourite_count":2}}`),
+			CreatedAt:   updatedAt,
+			UpdatedAt:   updatedAt,
+		},
+		&model.Beatmap{
+			ID:           10,
+			MapsetID:     1,
+			Version:      "insane",
+			Status:       "ranked",
+			BeatmapStats: repository.JSON(`{"2024-01-02T00:00:00Z":{"play_count":5,"pass_count":1}}`),
+			CreatedAt:    updatedAt,
+			UpdatedAt:    updatedAt,
+		},
+	)
+
+	var actualMapset model.Mapset
+	err = s.db.Table("mapsets").Where("id = ?", 1).First(&actualMapset).Error
+	s.Require().NoError(err)
+
+	s.Equal("new title", actualMapset.Title)
+	s.Equal("ranked", actualMapset.Status)
+	s.True(createdAt.Equal(actualMapset.CreatedAt), "created_at must be kept on update")
+	s.True(updatedAt.Equal(actualMapset.UpdatedAt))
+
+	var mapsetStats model.MapsetStats
+	s.Require().NoError(json.Unmarshal(actualMapset.MapsetStats, &mapsetStats))
+	s.Len(mapsetStats, 2)
+	s.Equal(1, mapsetStats[createdAt].Playcount)
+	s.Equal(5, mapsetStats[updatedAt].Playcount)
+
+	var actualBeatmap model.Beatmap
+	err = s.db.Table("beatmaps").Where("id = ?", 10).First(&actualBeatmap).Error
+	s.Require().NoError(err)
+
+	s.Equal("insane", actualBeatmap.Version)
+	s.Equal("ranked", actualBeatmap.Status)
+	s.True(createdAt.Equal(actualBeatmap.CreatedAt), "created_at must be kept on update")
+
+	var beatmapStats model.BeatmapStats
+	s.Require().NoError(json.Unmarshal(actualBeatmap.BeatmapStats, &beatmapStats))
+	s.Len(beatmapStats, 2)
+	s.Equal(1, beatmapStats[createdAt].Playcount)
+	s.Equal(1, beatmapStats[updatedAt].Passcount)
+	s.Equal(5, beatmapStats[updatedAt].Playcount)
+}
+
+func (s *IntegrationSuite) Test_MarkRemovedMapsets() {
+	err := integration.ClearTables(s.ctx, s.db)
+	s.Require().NoError(err)
+
+	lg := log.New()
+	txm := bootstrap.ConnectTxManager("mark_removed_test", time.Second, s.db, lg)
+	mapsets := mapsetrepository.New(s.cfg, lg)
+
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, ms := range []*model.Mapset{
+		{ID: 1, Covers: repository.JSON(`{}`), MapsetStats: repository.JSON(`{}`), RemovalStatus: string(model.MapsetActive)},
+		{ID: 2, Covers: repository.JSON(`{}`), MapsetStats: repository.JSON(`{}`), RemovalStatus: string(model.MapsetDeleted), RemovedAt: &deletedAt},
+	} {
+		s.Require().NoError(s.db.Create(ms).Error)
+	}
+
+	transferredAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	err = txm.ReadWrite(s.ctx, func(ctx context.Context, tx txmanager.Tx) error {
+		return mapsets.MarkRemoved(ctx, tx, model.MapsetTransferred, transferredAt, 1, 2)
+	})
+	s.Require().NoError(err)
+
+	var actual []*model.Mapset
+	err = s.db.Table("mapsets").Order("id").Find(&actual).Error
+	s.Require().NoError(err)
+	s.Require().Len(actual, 2)
+
+	s.Equal(string(model.MapsetTransferred), actual[0].RemovalStatus)
+	s.Require().NotNil(actual[0].RemovedAt)
+	s.True(transferredAt.Equal(*actual[0].RemovedAt))
+
+	// already removed mapset keeps its original status and removal time
+	s.Equal(string(model.MapsetDeleted), actual[1].RemovalStatus)
+	s.Require().NotNil(actual[1].RemovedAt)
+	s.True(deletedAt.Equal(*actual[1].RemovedAt))
+}
@@ -15,9 +15,21 @@ const initialState: UsersState = {
 export const fetchUsers = createAsyncThunk(
     'users/fetch',
     async (): Promise<{ users: User[] }> => {
-        const response = await fetch(`/api/user/list`);
-        const userData = await response.json();
-        return {users: userData}
+        // list is paged, follow next cursors until the last page to get every user
+        const users: User[] = [];
+        let cursor: string | undefined;
+        do {
+            const cursorParam = cursor ? `&cursor=${encodeURIComponent(cursor)}` : '';
+            const response = await fetch(`/api/user/list?limit=200${cursorParam}`);
+            if (!response.ok) {
+                throw new Error(`failed to fetch users: ${response.status}`);
+            }
+            const userData = await response.json();
+            users.push(...userData.users);
+            cursor = userData.next;
+        } while (cursor);
+
+        return {users: users}
     }
 )
 